@@ -4,27 +4,52 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mem"
+	"github.com/samber/lo"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/api"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/grpcserver"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/service"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/tracing"
 
-	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/abstract"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/bot"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/cache"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/cli"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/coingecko"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/rates"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/telegram"
 	config2 "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/config"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/db"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/repository"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/sinks"
 	"go.uber.org/zap"
 )
 
+const (
+	telegramTransport = "telegram"
+	// cliTransport drives the bot from terminal on behalf of one user
+	cliTransport = "cli"
+)
+
 func main() {
+	port := flag.Int("port", 9095, "the port to listen")
+	grpcPort := flag.Int("grpc-port", 9096, "the port to serve gRPC API")
+	grpcHost := flag.String("grpc-host", "127.0.0.1", "the interface to serve gRPC API on, 0.0.0.0 exposes it on all interfaces")
+	transport := flag.String("transport", telegramTransport, "where updates are received from: telegram or cli")
+	cliUserID := flag.Int64("cli-user", 1, "id of user chatting in cli transport")
+	cliLanguage := flag.String("cli-lang", "en", "language code of user chatting in cli transport")
+	flag.Parse()
+
 	tracing.InitTracing()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
@@ -35,7 +60,6 @@ func main() {
 
 	http.Handle("/metrics", promhttp.Handler())
 	go func() {
-		port := flag.Int("port", 9095, "the port to listen")
 		logger.Info("starting http server", zap.Int("port", *port))
 		err := http.ListenAndServe(fmt.Sprintf(":%d", *port), nil)
 		if err != nil {
@@ -44,10 +68,23 @@ func main() {
 	}()
 
 	// ----- clients -----
-	telegramClient, err := telegram.New(config)
-	handleError(err, "telegram client init failed")
+	var messenger bot.Messenger
+	var telegramClient *telegram.Client
+	var chat *cli.Chat
+	switch *transport {
+	case telegramTransport:
+		telegramClient, err = telegram.New(config)
+		handleError(err, "telegram client init failed")
+		messenger = telegramClient
+	case cliTransport:
+		chat = cli.New(os.Stdin, os.Stdout, *cliUserID, *cliLanguage)
+		messenger = chat
+	default:
+		logger.Fatal("unknown transport", zap.String("transport", *transport))
+	}
 
-	abstractClient := abstract.NewCurrencyClient(config)
+	rateProviders, err := rates.Providers(config)
+	handleError(err, "rate providers init failed")
 
 	// ----- db init -----
 	dbPool, err := db.InitPool(config)
@@ -58,24 +95,136 @@ func main() {
 	transactionRepo := repository.NewTransactionRepository(dbPool)
 	userRepo := repository.NewUserRepository(dbPool)
 	categoryRepo := repository.NewCategoryRepository(dbPool)
+	currencyRepo := repository.NewCurrencyRepository(dbPool)
 	rateRepo := repository.NewRateRepository(dbPool)
 	limitationRepo := repository.NewLimitationRepository(dbPool)
+	rateAlertRepo := repository.NewRateAlertRepository(dbPool)
+	apiTokenRepo := repository.NewAPITokenRepository(dbPool)
+	outboxRepo := repository.NewOutboxRepository(dbPool)
+	webhookRepo := repository.NewWebhookRepository(dbPool)
 
 	// ----- services -----
-	//ratesCache := mem.New(defaultExpiration, cleanupInterval)
-	//simpleCache := service.NewSimpleCache(ctx, config.RatesCacheDefaultExpiration(), config.RatesCacheCleanupInterval())
-	memcached := mem.NewMemcached(config.CacheHost())
+	appCache := cache.New(ctx, config)
+
+	currencyListService := service.NewCurrencyListService(currencyRepo, config.AdminIDs())
+	err = currencyListService.EnableConfigured(ctx, config.Currencies())
+	handleError(err, "cannot enable configured currencies")
+
+	rateProviderChain := service.NewProviderChain(config.RateProviderFailures(), config.RateProviderCooldown(), rateProviders...)
+	rateRouter := service.NewAssetRateRouter(rateProviderChain, coingecko.NewRatesClient())
+	rateAlertService := service.NewRateAlertService(rateAlertRepo, currencyRepo, messages.NewRateAlertNotifier(messenger, userRepo))
+	rateService := service.NewCurrencyExchangeService(ctx, rateRouter, appCache, rateRepo, currencyRepo, rateAlertService)
 
-	rateService := service.NewCurrencyExchangeService(ctx, abstractClient, memcached, rateRepo)
+	backfillWorker := service.NewRateBackfillWorker(config, rateRouter, rateRepo, currencyRepo, appCache)
+	go backfillWorker.Run(ctx)
 
-	calcService := service.NewCalculatorService(config, transactionRepo, rateRepo, rateService, memcached)
+	calcService := service.NewCalculatorService(config, transactionRepo, rateRepo, backfillWorker, appCache,
+		cache.NewGenerations(cache.Shared(appCache)))
+
+	dialogStore := dialog.NewStore(appCache, config.DialogStateExpiration())
+	apiTokenService := service.NewAPITokenService(apiTokenRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	operationService := service.NewOperationService(transactionRepo, categoryRepo, limitationRepo, userRepo, currencyRepo,
+		rateService, calcService)
+
+	var kafkaProducer *queue.KafkaProducer
+	if config.ReportQueue() == queue.KafkaBackend || lo.Contains(config.OutboxSinks(), sinks.QueueName) {
+		kafkaProducer = queue.NewKafkaProducer(config.KafkaBrokers())
+		defer kafkaProducer.Close()
+	}
+
+	// ----- report queue -----
+	var reportQueue service.Publisher
+	switch config.ReportQueue() {
+	case queue.MemoryBackend:
+		memoryQueue := queue.NewMemory(config.ReportQueueSize())
+		reportWorker := service.NewReportWorker(messenger, calcService, categoryRepo, currencyRepo)
+		go func() {
+			err := memoryQueue.Consume(ctx, config.ReportTopic(), reportWorker.Handle)
+			if err != nil {
+				logger.Error("report worker failed", zap.Error(err))
+			}
+		}()
+		reportQueue = memoryQueue
+	case queue.KafkaBackend:
+		reportQueue = kafkaProducer
+	default:
+		logger.Fatal("unknown report queue", zap.String("queue", config.ReportQueue()))
+	}
+	reportPublisher := service.NewReportPublisher(reportQueue, config.ReportTopic())
+
+	// ----- outbox -----
+	// webhooks of users are not optional, they are registered by users themselves,
+	// relay only queues events for them and deliveries are retried per webhook by the dispatcher
+	userWebhooks := sinks.NewUserWebhooks(config, webhookRepo)
+	go userWebhooks.Run(ctx)
+	eventSinks := append(getEventSinks(config, kafkaProducer), userWebhooks)
+	outboxRelay := service.NewOutboxRelay(config, outboxRepo, eventSinks...)
+	go outboxRelay.Run(ctx)
 
 	// ----- logic -----
-	msgModel := messages.New(telegramClient, userRepo, categoryRepo)
-	callbackModel := callbacks.New(telegramClient, transactionRepo, userRepo, categoryRepo, limitationRepo,
-		rateService, calcService, memcached)
+	callbackModel := callbacks.New(messenger, transactionRepo, userRepo, categoryRepo, currencyRepo, limitationRepo,
+		rateService, calcService, dialogStore, reportPublisher)
+	converterService := service.NewCurrencyConverterService(rateService, currencyRepo)
+	rateHistoryService := service.NewRateHistoryService(rateRepo, currencyRepo)
+	msgModel := messages.New(messenger, userRepo, categoryRepo, callbackModel, currencyListService, converterService,
+		rateHistoryService, rateAlertService, apiTokenService, webhookService, messenger, callbackModel, transactionRepo)
+
+	apiServer := api.New(apiTokenService, operationService, transactionRepo, categoryRepo, limitationRepo, userRepo,
+		calcService)
+	http.Handle(api.Prefix, apiServer.Handler())
+
+	if config.GRPCServiceToken() != "" {
+		grpcListener, err := net.Listen("tcp", net.JoinHostPort(*grpcHost, strconv.Itoa(*grpcPort)))
+		handleError(err, "cannot listen grpc port")
+		go func() {
+			err := grpcserver.New(config.GRPCServiceToken(), operationService, transactionRepo, calcService).
+				Serve(ctx, grpcListener)
+			if err != nil {
+				logger.Error("grpc server failed", zap.Error(err))
+			}
+		}()
+	} else {
+		logger.Warn("grpc api is disabled, grpc_service_token is not configured")
+	}
+
+	core := bot.New(msgModel, callbackModel)
+
+	if chat != nil {
+		err = chat.Listen(ctx, core)
+		handleError(err, "cli chat failed")
+		return
+	}
+	if config.TelegramMode() == telegram.WebhookMode {
+		http.Handle(telegramClient.WebhookPath(), telegramClient.WebhookHandler(core))
+		err = telegramClient.ServeWebhook(ctx)
+		handleError(err, "telegram webhook failed")
+		return
+	}
+	telegramClient.ListenUpdates(ctx, core)
+}
 
-	telegramClient.ListenUpdates(ctx, msgModel, callbackModel)
+// getEventSinks builds sinks of domain events from config
+func getEventSinks(config *config2.Service, kafkaProducer *queue.KafkaProducer) []service.EventSink {
+	available := map[string]func() service.EventSink{
+		sinks.LogName: func() service.EventSink { return sinks.NewLog() },
+		sinks.WebhookName: func() service.EventSink {
+			if config.OutboxWebhookURL() == "" {
+				logger.Fatal("outbox webhook url is required by webhook sink")
+			}
+			return sinks.NewWebhook(config.OutboxWebhookURL(), config.OutboxWebhookTimeout())
+		},
+		sinks.QueueName: func() service.EventSink { return sinks.NewQueue(kafkaProducer, config.OutboxTopic()) },
+	}
+	eventSinks := make([]service.EventSink, 0, len(config.OutboxSinks()))
+	for _, name := range config.OutboxSinks() {
+		newSink, ok := available[name]
+		if !ok {
+			logger.Fatal("unknown outbox sink", zap.String("sink", name))
+		}
+		eventSinks = append(eventSinks, newSink())
+	}
+	return eventSinks
 }
 
 func handleError(err error, message string) {
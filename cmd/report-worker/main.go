@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/cache"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/coingecko"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/rates"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/telegram"
+	config2 "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/config"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/db"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/repository"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// report-worker consumes report requests published by bot to Kafka, calculates reports
+// and sends them to users through Telegram
+func main() {
+	port := flag.Int("port", 9097, "the port to serve metrics")
+	flag.Parse()
+
+	tracing.InitTracing()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	config, err := config2.New()
+	handleError(err, "config init failed")
+	if config.ReportQueue() != queue.KafkaBackend {
+		logger.Fatal("report worker consumes kafka queue only, memory queue is consumed by bot itself",
+			zap.String("queue", config.ReportQueue()))
+	}
+	if config.CacheBackend() == cache.LocalBackend {
+		logger.Fatal("report worker needs memcached shared with bot to see invalidated reports",
+			zap.String("cacheBackend", config.CacheBackend()))
+	}
+
+	http.Handle("/metrics", promhttp.Handler())
+	go func() {
+		logger.Info("starting http server", zap.Int("port", *port))
+		err := http.ListenAndServe(fmt.Sprintf(":%d", *port), nil)
+		if err != nil {
+			logger.Fatal("error starting http server", zap.Error(err))
+		}
+	}()
+
+	// ----- clients -----
+	telegramClient, err := telegram.New(config)
+	handleError(err, "telegram client init failed")
+
+	rateProviders, err := rates.Providers(config)
+	handleError(err, "rate providers init failed")
+
+	// ----- db init -----
+	dbPool, err := db.InitPool(config)
+	handleError(err, "could set up database")
+	defer dbPool.Close()
+
+	// ----- repositories -----
+	transactionRepo := repository.NewTransactionRepository(dbPool)
+	categoryRepo := repository.NewCategoryRepository(dbPool)
+	currencyRepo := repository.NewCurrencyRepository(dbPool)
+	rateRepo := repository.NewRateRepository(dbPool)
+
+	// ----- services -----
+	appCache := cache.New(ctx, config)
+
+	rateProviderChain := service.NewProviderChain(config.RateProviderFailures(), config.RateProviderCooldown(), rateProviders...)
+	rateRouter := service.NewAssetRateRouter(rateProviderChain, coingecko.NewRatesClient())
+	backfillWorker := service.NewRateBackfillWorker(config, rateRouter, rateRepo, currencyRepo, appCache)
+	go backfillWorker.Run(ctx)
+
+	calcService := service.NewCalculatorService(config, transactionRepo, rateRepo, backfillWorker, appCache,
+		cache.NewGenerations(cache.Shared(appCache)))
+	reportWorker := service.NewReportWorker(telegramClient, calcService, categoryRepo, currencyRepo)
+
+	// ----- consumer -----
+	consumer := queue.NewKafkaConsumer(config.KafkaBrokers(), config.ReportConsumerGroup())
+	logger.Info("starting report worker", zap.String("topic", config.ReportTopic()))
+	err = consumer.Consume(ctx, config.ReportTopic(), reportWorker.Handle)
+	handleError(err, "report worker failed")
+	logger.Info("report worker stopped")
+}
+
+func handleError(err error, message string) {
+	if err != nil {
+		logger.Fatal(message, zap.Error(err))
+	}
+}
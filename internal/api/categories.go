@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+)
+
+type categoryJSON struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type categoryListJSON struct {
+	Items []categoryJSON `json:"items"`
+}
+
+// listCategories handles GET /categories?lang=en, names are in language of user by default
+func (s *Server) listCategories(r *http.Request, userID int64) (int, interface{}, error) {
+	ctx := r.Context()
+
+	lang := s.userLanguage(ctx, userID)
+	if code := r.URL.Query().Get("lang"); code != "" {
+		var ok bool
+		if lang, ok = i18n.Parse(code); !ok {
+			return 0, nil, badRequest("unsupported language " + code)
+		}
+	}
+	categories, err := s.categoryRepo.GetAllCategories(ctx, string(lang))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot list categories")
+	}
+	list := categoryListJSON{Items: make([]categoryJSON, 0, len(categories))}
+	for _, c := range categories {
+		list.Items = append(list.Items, categoryJSON{ID: c.ID, Name: c.Name})
+	}
+	return http.StatusOK, list, nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// limitJSON is monthly spending limit of category, amounts are kept in server currency
+type limitJSON struct {
+	CategoryID string          `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency"`
+	UntilDate  time.Time       `json:"until_date"`
+}
+
+type limitListJSON struct {
+	Items []limitJSON `json:"items"`
+}
+
+// limitUpdateJSON is limit in given currency or in currency of user if it is omitted
+type limitUpdateJSON struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency,omitempty"`
+}
+
+func toLimitJSON(l model.Limit) limitJSON {
+	return limitJSON{
+		CategoryID: l.CategoryID,
+		Amount:     l.UpperBorder,
+		Currency:   constants.ServerCurrency,
+		UntilDate:  l.UntilDate,
+	}
+}
+
+// listLimits handles GET /limits
+func (s *Server) listLimits(r *http.Request, userID int64) (int, interface{}, error) {
+	limits, err := s.limitationRepo.GetLimits(r.Context(), userID)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot list limits")
+	}
+	list := limitListJSON{Items: make([]limitJSON, 0, len(limits))}
+	for _, l := range limits {
+		list.Items = append(list.Items, toLimitJSON(l))
+	}
+	return http.StatusOK, list, nil
+}
+
+// setLimit handles PUT /limits/{category}, limit is set until the end of current month as in the bot
+func (s *Server) setLimit(r *http.Request, userID int64) (int, interface{}, error) {
+	categoryID, err := pathParam(r, "limits/")
+	if err != nil {
+		return 0, nil, err
+	}
+	var input limitUpdateJSON
+	if err = decodeBody(r, &input); err != nil {
+		return 0, nil, err
+	}
+
+	limit, err := s.operations.SetLimit(r.Context(), userID, categoryID, input.Amount, input.Currency)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toLimitJSON(limit), nil
+}
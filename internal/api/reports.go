@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// reportJSON is expenses by categories in requested currency
+type reportJSON struct {
+	Period       string                     `json:"period"`
+	Currency     string                     `json:"currency"`
+	Expenses     map[string]decimal.Decimal `json:"expenses"`
+	PendingRates int                        `json:"pending_rates"`
+	StaleRates   int                        `json:"stale_rates"`
+}
+
+// showReport handles GET /reports/{week|month|year}?currency=USD
+func (s *Server) showReport(r *http.Request, userID int64) (int, interface{}, error) {
+	ctx := r.Context()
+
+	period, err := pathParam(r, "reports/")
+	if err != nil {
+		return 0, nil, err
+	}
+	calc, ok := map[string]func(ctx context.Context, userID int64, currency string) (model.ReportData, error){
+		constants.WeekPeriod:  s.calcService.CalcByCurrentWeek,
+		constants.MonthPeriod: s.calcService.CalcByCurrentMonth,
+		constants.YearPeriod:  s.calcService.CalcByCurrentYear,
+	}[period]
+	if !ok {
+		return 0, nil, notFound("unknown period " + period)
+	}
+	currency, err := s.operations.ResolveCurrency(ctx, userID, r.URL.Query().Get("currency"))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	report, err := calc(ctx, userID, currency)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot calculate report")
+	}
+	expenses := report.Expenses
+	if expenses == nil {
+		expenses = map[string]decimal.Decimal{}
+	}
+	return http.StatusOK, reportJSON{
+		Period:       period,
+		Currency:     currency,
+		Expenses:     expenses,
+		PendingRates: report.PendingRates,
+		StaleRates:   report.StaleRates,
+	}, nil
+}
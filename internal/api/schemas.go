@@ -0,0 +1,55 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// schemas describe request and response bodies in JSON Schema, they are served under Prefix+"schemas/"
+//
+//go:embed schemas/*.json
+var schemas embed.FS
+
+const schemaExt = ".json"
+
+// serveSchemaIndex handles GET /schemas listing names of served schemas
+func serveSchemaIndex(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &apiError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: r.Method})
+		return
+	}
+	entries, err := schemas.ReadDir("schemas")
+	if err != nil {
+		writeError(w, &apiError{status: http.StatusInternalServerError, code: "internal", message: "cannot list schemas"})
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), schemaExt))
+	}
+	sort.Strings(names)
+	writeJSON(w, http.StatusOK, map[string][]string{"items": names})
+}
+
+// serveSchema handles GET /schemas/{name}, name may be given with or without extension
+func serveSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &apiError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: r.Method})
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, Prefix+"schemas/"), schemaExt)
+	if name == "" || strings.ContainsAny(name, "/.") {
+		writeError(w, notFound("no such schema"))
+		return
+	}
+	schema, err := schemas.ReadFile(path.Join("schemas", name+schemaExt))
+	if err != nil {
+		writeError(w, notFound("no such schema "+name))
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	_, _ = w.Write(schema)
+}
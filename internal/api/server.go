@@ -0,0 +1,286 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// Prefix is path all versioned routes of REST API are served under
+const Prefix = "/api/v1/"
+
+const maxBodySize = 64 << 10
+
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (int64, error)
+}
+
+// Operations adds expenses and limits given in any enabled currency
+type Operations interface {
+	AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string,
+		date time.Time) (model.Transaction, error)
+	SetLimit(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string) (model.Limit, error)
+	ResolveCurrency(ctx context.Context, userID int64, requested string) (string, error)
+}
+
+type TransactionStore interface {
+	ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error)
+}
+
+type CategoryStore interface {
+	GetAllCategories(ctx context.Context, lang string) (category []model.CategoryData, err error)
+}
+
+type LimitationStore interface {
+	GetLimits(ctx context.Context, userID int64) ([]model.Limit, error)
+}
+
+type UserStore interface {
+	SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error
+	GetUserLanguage(ctx context.Context, userID int64) (string, error)
+	SetUserLanguage(ctx context.Context, userID int64, lang string) error
+}
+
+type Calculator interface {
+	CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	InvalidateReports(userID int64) error
+}
+
+// Server is REST API over the same repositories and services the bot uses, users authenticate with tokens
+// issued by /token command
+type Server struct {
+	tokens          TokenAuthenticator
+	operations      Operations
+	transactionRepo TransactionStore
+	categoryRepo    CategoryStore
+	limitationRepo  LimitationStore
+	userRepo        UserStore
+	calcService     Calculator
+}
+
+func New(tokens TokenAuthenticator, operations Operations, transactionRepo TransactionStore, categoryRepo CategoryStore,
+	limitationRepo LimitationStore, userRepo UserStore, calcService Calculator) *Server {
+	return &Server{
+		tokens:          tokens,
+		operations:      operations,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		limitationRepo:  limitationRepo,
+		userRepo:        userRepo,
+		calcService:     calcService,
+	}
+}
+
+// handlerFunc serves request of authenticated user and returns status with body to encode as JSON
+type handlerFunc func(r *http.Request, userID int64) (int, interface{}, error)
+
+// route maps methods of one path to operations, operation names label metrics
+type route map[string]operation
+
+type operation struct {
+	name   string
+	handle handlerFunc
+}
+
+// Handler returns handler of all routes under Prefix
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(Prefix+"transactions", s.authorized(route{
+		http.MethodGet:  {"list_transactions", s.listTransactions},
+		http.MethodPost: {"add_transaction", s.addTransaction},
+	}))
+	mux.Handle(Prefix+"categories", s.authorized(route{
+		http.MethodGet: {"list_categories", s.listCategories},
+	}))
+	mux.Handle(Prefix+"limits", s.authorized(route{
+		http.MethodGet: {"list_limits", s.listLimits},
+	}))
+	mux.Handle(Prefix+"limits/", s.authorized(route{
+		http.MethodPut: {"set_limit", s.setLimit},
+	}))
+	mux.Handle(Prefix+"reports/", s.authorized(route{
+		http.MethodGet: {"show_report", s.showReport},
+	}))
+	mux.Handle(Prefix+"settings", s.authorized(route{
+		http.MethodGet:   {"get_settings", s.getSettings},
+		http.MethodPatch: {"update_settings", s.updateSettings},
+	}))
+	mux.HandleFunc(Prefix+"schemas", serveSchemaIndex)
+	mux.HandleFunc(Prefix+"schemas/", serveSchema)
+	mux.HandleFunc(Prefix, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, notFound("no such route"))
+	})
+	return mux
+}
+
+// authorized resolves user by bearer token before dispatching request by method
+func (s *Server) authorized(methods route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, ok := methods[r.Method]
+		if !ok {
+			allowed := make([]string, 0, len(methods))
+			for method := range methods {
+				allowed = append(allowed, method)
+			}
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeError(w, &apiError{status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: r.Method})
+			return
+		}
+
+		span, ctx := opentracing.StartSpanFromContext(r.Context(), "api:"+op.name)
+		defer span.Finish()
+
+		status := "ok"
+		start := time.Now()
+		defer func() {
+			tookTime := time.Since(start).Seconds()
+			metrics.IncomingRequestsTotalCounter.WithLabelValues("api", op.name, status).Inc()
+			metrics.IncomingRequestsHistogramResponseTime.WithLabelValues("api", op.name, status).Observe(tookTime)
+		}()
+
+		userID, err := s.authenticate(ctx, r)
+		if err == nil {
+			span.SetTag("userID", userID)
+			r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+			var code int
+			var body interface{}
+			if code, body, err = op.handle(r.WithContext(ctx), userID); err == nil {
+				writeJSON(w, code, body)
+				return
+			}
+		}
+
+		apiErr := toAPIError(err)
+		if apiErr == nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot serve api request",
+				zap.String("operation", op.name),
+				zap.Int64("userID", userID),
+				zap.Error(err))
+			apiErr = &apiError{status: http.StatusInternalServerError, code: "internal", message: "internal server error"}
+		}
+		status = "error"
+		if apiErr.status == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+		}
+		writeError(w, apiErr)
+	})
+}
+
+func (s *Server) authenticate(ctx context.Context, r *http.Request) (int64, error) {
+	const scheme = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) {
+		return 0, &apiError{status: http.StatusUnauthorized, code: "unauthorized", message: "bearer token is required"}
+	}
+	userID, err := s.tokens.Authenticate(ctx, strings.TrimSpace(header[len(scheme):]))
+	if errors.Is(err, constants.TokenNotFoundErr) {
+		return 0, &apiError{status: http.StatusUnauthorized, code: "unauthorized", message: "token is invalid or revoked"}
+	}
+	return userID, err
+}
+
+// apiError is returned to client as is, other errors are logged and hidden behind internal error
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.code + ": " + e.message
+}
+
+// toAPIError exposes validation errors of services to client, nil is returned for internal errors
+func toAPIError(err error) *apiError {
+	var apiErr *apiError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.Is(err, constants.UnknownCategoryErr), errors.Is(err, constants.DisabledCurrencyErr),
+		errors.Is(err, constants.NotPositiveAmountErr), errors.Is(err, constants.FutureDateErr):
+		return badRequest(err.Error())
+	case errors.Is(err, constants.MissingRateErr):
+		return &apiError{status: http.StatusServiceUnavailable, code: "rate_unavailable", message: err.Error()}
+	}
+	return nil
+}
+
+func badRequest(message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "bad_request", message: message}
+}
+
+func notFound(message string) *apiError {
+	return &apiError{status: http.StatusNotFound, code: "not_found", message: message}
+}
+
+type errorBody struct {
+	Error errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, errorBody{Error: errorDetails{Code: err.code, Message: err.message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Warn("cannot write api response", zap.Error(err))
+	}
+}
+
+// decodeBody rejects unknown fields so that typos in optional fields are not silently ignored
+func decodeBody(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return badRequest("invalid request body: " + err.Error())
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return badRequest("invalid request body: unexpected data after JSON object")
+	}
+	return nil
+}
+
+// queryInt parses optional non-negative integer parameter
+func queryInt(r *http.Request, name string, defaultValue int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, badRequest(name + " must be a non-negative integer")
+	}
+	return value, nil
+}
+
+// pathParam returns the last path segment after route prefix, e.g. category of /api/v1/limits/FOOD
+func pathParam(r *http.Request, route string) (string, error) {
+	param := strings.TrimPrefix(r.URL.Path, Prefix+route)
+	if param == "" || strings.Contains(param, "/") {
+		return "", notFound("no such route")
+	}
+	return param, nil
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	apiMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/api"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+const (
+	token  = "fb_token"
+	userID = int64(123)
+)
+
+type testServer struct {
+	handler         http.Handler
+	tokens          *apiMocks.MockTokenAuthenticator
+	operations      *apiMocks.MockOperations
+	transactionRepo *apiMocks.MockTransactionStore
+	categoryRepo    *apiMocks.MockCategoryStore
+	limitationRepo  *apiMocks.MockLimitationStore
+	userRepo        *apiMocks.MockUserStore
+	calcService     *apiMocks.MockCalculator
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ctrl := gomock.NewController(t)
+	s := &testServer{
+		tokens:          apiMocks.NewMockTokenAuthenticator(ctrl),
+		operations:      apiMocks.NewMockOperations(ctrl),
+		transactionRepo: apiMocks.NewMockTransactionStore(ctrl),
+		categoryRepo:    apiMocks.NewMockCategoryStore(ctrl),
+		limitationRepo:  apiMocks.NewMockLimitationStore(ctrl),
+		userRepo:        apiMocks.NewMockUserStore(ctrl),
+		calcService:     apiMocks.NewMockCalculator(ctrl),
+	}
+	s.handler = New(s.tokens, s.operations, s.transactionRepo, s.categoryRepo, s.limitationRepo, s.userRepo,
+		s.calcService).Handler()
+	return s
+}
+
+// authorized expects token of the test user to be checked
+func (s *testServer) authorized() *testServer {
+	s.tokens.EXPECT().Authenticate(gomock.Any(), token).Return(userID, nil)
+	return s
+}
+
+func (s *testServer) do(method, path, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) interface{} {
+	return decode(t, w)["error"].(map[string]interface{})["code"]
+}
+
+// decimalEq matches decimals by value since equal amounts may differ in exponent
+type decimalEq decimal.Decimal
+
+func (d decimalEq) Matches(x interface{}) bool {
+	v, ok := x.(decimal.Decimal)
+	return ok && v.Equal(decimal.Decimal(d))
+}
+
+func (d decimalEq) String() string {
+	return fmt.Sprintf("equals %s", decimal.Decimal(d))
+}
+
+func TestServer_RejectsMissingAndRevokedTokens(t *testing.T) {
+	s := newTestServer(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/settings", nil)
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "unauthorized", errorCode(t, w))
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	s.tokens.EXPECT().Authenticate(gomock.Any(), token).Return(int64(0), constants.TokenNotFoundErr)
+	w = s.do(http.MethodGet, "/api/v1/settings", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestServer_RejectsUnknownRoutesAndMethods(t *testing.T) {
+	s := newTestServer(t)
+
+	w := s.do(http.MethodDelete, "/api/v1/transactions", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "method_not_allowed", errorCode(t, w))
+	assert.Contains(t, w.Header().Get("Allow"), http.MethodPost)
+
+	w = s.do(http.MethodGet, "/api/v1/unknown", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	s.authorized()
+	w = s.do(http.MethodGet, "/api/v1/reports/decade", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServer_ListTransactionsByPages(t *testing.T) {
+	s := newTestServer(t).authorized()
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s.transactionRepo.EXPECT().ListTransactions(gomock.Any(), userID, 2, 4).Return([]model.Transaction{
+		{ID: 7, CategoryID: "FOOD", Amount: decimal.RequireFromString("150.5"), Date: date},
+	}, 5, nil)
+	w := s.do(http.MethodGet, "/api/v1/transactions?limit=2&offset=4", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"items":[{"id":7,"category_id":"FOOD","amount":"150.5","currency":"RUB",
+		"date":"2026-10-19T12:00:00Z"}],"limit":2,"offset":4,"total":5}`, w.Body.String())
+}
+
+func TestServer_ListTransactionsValidatesPage(t *testing.T) {
+	for _, query := range []string{"limit=0", "limit=101", "limit=x", "offset=-1"} {
+		t.Run(query, func(t *testing.T) {
+			s := newTestServer(t).authorized()
+
+			w := s.do(http.MethodGet, "/api/v1/transactions?"+query, "")
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "bad_request", errorCode(t, w))
+		})
+	}
+}
+
+func TestServer_AddTransaction(t *testing.T) {
+	s := newTestServer(t).authorized()
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s.operations.EXPECT().AddOperation(gomock.Any(), userID, "FOOD", decimalEq(decimal.NewFromInt(12)), "usd", date).
+		Return(model.Transaction{ID: 42, CategoryID: "FOOD", Amount: decimal.NewFromInt(1200), Date: date}, nil)
+
+	w := s.do(http.MethodPost, "/api/v1/transactions",
+		`{"category_id":"FOOD","amount":12,"currency":"usd","date":"2026-10-19T12:00:00Z"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id":42,"category_id":"FOOD","amount":"1200","currency":"RUB","date":"2026-10-19T12:00:00Z"}`,
+		w.Body.String())
+}
+
+func TestServer_AddTransactionValidatesBody(t *testing.T) {
+	for name, body := range map[string]string{
+		"malformed":     `{"category_id":`,
+		"unknown field": `{"category_id":"FOOD","amount":"1","comment":"x"}`,
+		"trailing data": `{"category_id":"FOOD","amount":"1"} {}`,
+		"no category":   `{"amount":"1"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t).authorized()
+
+			w := s.do(http.MethodPost, "/api/v1/transactions", body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestServer_ServiceValidationErrorsAreShown(t *testing.T) {
+	for _, err := range []error{constants.UnknownCategoryErr, constants.DisabledCurrencyErr,
+		constants.NotPositiveAmountErr, constants.FutureDateErr} {
+		t.Run(err.Error(), func(t *testing.T) {
+			s := newTestServer(t).authorized()
+			s.operations.EXPECT().AddOperation(gomock.Any(), userID, "FOOD", gomock.Any(), "GEL", gomock.Any()).
+				Return(model.Transaction{}, errors.Wrap(err, "GEL"))
+
+			w := s.do(http.MethodPost, "/api/v1/transactions", `{"category_id":"FOOD","amount":"1","currency":"GEL"}`)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), err.Error())
+		})
+	}
+}
+
+func TestServer_InternalErrorsAreHidden(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.limitationRepo.EXPECT().GetLimits(gomock.Any(), userID).Return(nil, errors.New("pq: password is wrong"))
+	w := s.do(http.MethodGet, "/api/v1/limits", "")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal", errorCode(t, w))
+	assert.NotContains(t, w.Body.String(), "password")
+}
+
+func TestServer_SetLimit(t *testing.T) {
+	s := newTestServer(t).authorized()
+	until := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	s.operations.EXPECT().SetLimit(gomock.Any(), userID, "FOOD", decimalEq(decimal.NewFromInt(500)), "").
+		Return(model.Limit{CategoryID: "FOOD", UpperBorder: decimal.NewFromInt(50000), UntilDate: until}, nil)
+
+	w := s.do(http.MethodPut, "/api/v1/limits/FOOD", `{"amount":"500"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"category_id":"FOOD","amount":"50000","currency":"RUB","until_date":"2026-10-31T00:00:00Z"}`,
+		w.Body.String())
+}
+
+func TestServer_MissingRateIsUnavailable(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.operations.EXPECT().SetLimit(gomock.Any(), userID, "FOOD", gomock.Any(), "USD").
+		Return(model.Limit{}, constants.MissingRateErr)
+
+	w := s.do(http.MethodPut, "/api/v1/limits/FOOD", `{"amount":"500","currency":"USD"}`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "rate_unavailable", errorCode(t, w))
+}
+
+func TestServer_ShowReportInRequestedCurrency(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.operations.EXPECT().ResolveCurrency(gomock.Any(), userID, "usd").Return("USD", nil)
+	s.calcService.EXPECT().CalcByCurrentMonth(gomock.Any(), userID, "USD").Return(model.ReportData{
+		Expenses:     map[string]decimal.Decimal{"FOOD": decimal.RequireFromString("12.5")},
+		PendingRates: 1,
+	}, nil)
+
+	w := s.do(http.MethodGet, "/api/v1/reports/month?currency=usd", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"period":"month","currency":"USD","expenses":{"FOOD":"12.5"},"pending_rates":1,
+		"stale_rates":0}`, w.Body.String())
+}
+
+func TestServer_UpdateSettings(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.operations.EXPECT().ResolveCurrency(gomock.Any(), userID, "usd").Return("USD", nil)
+	s.userRepo.EXPECT().SetUserCurrency(gomock.Any(), userID, "USD")
+	s.calcService.EXPECT().InvalidateReports(userID)
+	s.userRepo.EXPECT().SetUserLanguage(gomock.Any(), userID, "en")
+	s.operations.EXPECT().ResolveCurrency(gomock.Any(), userID, "").Return("USD", nil)
+	s.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+
+	w := s.do(http.MethodPatch, "/api/v1/settings", `{"currency":"usd","language":"en"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"currency":"USD","language":"en"}`, w.Body.String())
+}
+
+func TestServer_UpdateSettingsChangesNothingOnInvalidField(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.operations.EXPECT().ResolveCurrency(gomock.Any(), userID, "usd").Return("USD", nil)
+
+	w := s.do(http.MethodPatch, "/api/v1/settings", `{"currency":"usd","language":"de"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_ListCategoriesInRequestedLanguage(t *testing.T) {
+	s := newTestServer(t).authorized()
+
+	s.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("", nil)
+	s.categoryRepo.EXPECT().GetAllCategories(gomock.Any(), "en").Return([]model.CategoryData{{ID: "FOOD", Name: "Food"}}, nil)
+
+	w := s.do(http.MethodGet, "/api/v1/categories?lang=en", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[{"id":"FOOD","name":"Food"}]}`, w.Body.String())
+}
+
+func TestServer_ServesSchemasWithoutToken(t *testing.T) {
+	s := newTestServer(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/schemas/transaction", nil)
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "transaction.json", decode(t, w)["$id"])
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/schemas/../server.go", nil)
+	w = httptest.NewRecorder()
+	s.handler.ServeHTTP(w, r)
+	assert.NotEqual(t, http.StatusOK, w.Code)
+}
+
+func TestSchemas_AreValidJSONWithMatchingID(t *testing.T) {
+	files, err := fs.Glob(schemas, "schemas/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	for _, file := range files {
+		content, err := schemas.ReadFile(file)
+		assert.NoError(t, err)
+		var schema map[string]interface{}
+		assert.NoError(t, json.Unmarshal(content, &schema), file)
+		assert.Equal(t, strings.TrimPrefix(file, "schemas/"), schema["$id"])
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+)
+
+type settingsJSON struct {
+	Currency string `json:"currency"`
+	Language string `json:"language"`
+}
+
+// settingsUpdateJSON changes only fields which are set
+type settingsUpdateJSON struct {
+	Currency *string `json:"currency,omitempty"`
+	Language *string `json:"language,omitempty"`
+}
+
+// getSettings handles GET /settings
+func (s *Server) getSettings(r *http.Request, userID int64) (int, interface{}, error) {
+	settings, err := s.loadSettings(r.Context(), userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, settings, nil
+}
+
+// updateSettings handles PATCH /settings, both fields are validated before anything is changed
+func (s *Server) updateSettings(r *http.Request, userID int64) (int, interface{}, error) {
+	ctx := r.Context()
+
+	var input settingsUpdateJSON
+	if err := decodeBody(r, &input); err != nil {
+		return 0, nil, err
+	}
+	var currency string
+	if input.Currency != nil {
+		if *input.Currency == "" {
+			return 0, nil, badRequest("currency must not be empty")
+		}
+		var err error
+		if currency, err = s.operations.ResolveCurrency(ctx, userID, *input.Currency); err != nil {
+			return 0, nil, err
+		}
+	}
+	var lang i18n.Lang
+	if input.Language != nil {
+		var ok bool
+		if lang, ok = i18n.Parse(*input.Language); !ok {
+			return 0, nil, badRequest("unsupported language " + *input.Language)
+		}
+	}
+
+	if currency != "" {
+		if err := s.userRepo.SetUserCurrency(ctx, userID, currency); err != nil {
+			return 0, nil, errors.Wrap(err, "cannot change currency")
+		}
+		_ = s.calcService.InvalidateReports(userID)
+	}
+	if lang != "" {
+		if err := s.userRepo.SetUserLanguage(ctx, userID, string(lang)); err != nil {
+			return 0, nil, errors.Wrap(err, "cannot change language")
+		}
+	}
+	settings, err := s.loadSettings(ctx, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, settings, nil
+}
+
+func (s *Server) loadSettings(ctx context.Context, userID int64) (settingsJSON, error) {
+	currency, err := s.operations.ResolveCurrency(ctx, userID, "")
+	if err != nil {
+		return settingsJSON{}, err
+	}
+	return settingsJSON{
+		Currency: currency,
+		Language: string(s.userLanguage(ctx, userID)),
+	}, nil
+}
+
+// userLanguage falls back on default language for users who have not chosen one
+func (s *Server) userLanguage(ctx context.Context, userID int64) i18n.Lang {
+	preferred, err := s.userRepo.GetUserLanguage(ctx, userID)
+	if err != nil {
+		return i18n.DefaultLang
+	}
+	return i18n.Resolve(preferred, "")
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// transactionJSON is stored expense, amounts are kept in server currency
+type transactionJSON struct {
+	ID         int64           `json:"id"`
+	CategoryID string          `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency"`
+	Date       time.Time       `json:"date"`
+}
+
+type transactionPageJSON struct {
+	Items  []transactionJSON `json:"items"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+	Total  int               `json:"total"`
+}
+
+// newTransactionJSON is expense in given currency or in currency of user if it is omitted
+type newTransactionJSON struct {
+	CategoryID string          `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency,omitempty"`
+	Date       *time.Time      `json:"date,omitempty"`
+}
+
+func toTransactionJSON(t model.Transaction) transactionJSON {
+	return transactionJSON{
+		ID:         t.ID,
+		CategoryID: t.CategoryID,
+		Amount:     t.Amount,
+		Currency:   constants.ServerCurrency,
+		Date:       t.Date,
+	}
+}
+
+// listTransactions handles GET /transactions?limit=20&offset=0, the newest transactions go first
+func (s *Server) listTransactions(r *http.Request, userID int64) (int, interface{}, error) {
+	limit, err := queryInt(r, "limit", defaultPageSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	if limit == 0 || limit > maxPageSize {
+		return 0, nil, badRequest("limit must be between 1 and 100")
+	}
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	transactions, total, err := s.transactionRepo.ListTransactions(r.Context(), userID, limit, offset)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot list transactions")
+	}
+	page := transactionPageJSON{
+		Items:  make([]transactionJSON, 0, len(transactions)),
+		Limit:  limit,
+		Offset: offset,
+		Total:  total,
+	}
+	for _, t := range transactions {
+		page.Items = append(page.Items, toTransactionJSON(t))
+	}
+	return http.StatusOK, page, nil
+}
+
+// addTransaction handles POST /transactions, amount is converted to server currency by rate of its date
+func (s *Server) addTransaction(r *http.Request, userID int64) (int, interface{}, error) {
+	var input newTransactionJSON
+	if err := decodeBody(r, &input); err != nil {
+		return 0, nil, err
+	}
+	if input.CategoryID == "" {
+		return 0, nil, badRequest("category_id is required")
+	}
+	date := time.Now()
+	if input.Date != nil {
+		date = *input.Date
+	}
+
+	transaction, err := s.operations.AddOperation(r.Context(), userID, input.CategoryID, input.Amount, input.Currency, date)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toTransactionJSON(transaction), nil
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+)
+
+var EmptyUpdateErr = errors.New("update has neither message nor callback")
+
+// Messenger delivers replies of bot to users and downloads files they attach, every transport implements it
+type Messenger interface {
+	SendMessage(text string, userID int64) error
+	SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error
+	SendEditMessage(text string, userID int64, messageID int) error
+	SendEditMessageWithMarkupAndText(text string, markup [][]model.MarkupData, userID int64, messageID int) error
+	SendAttachment(attachment model.Attachment, userID int64) error
+	DownloadFile(ctx context.Context, fileID string) ([]byte, error)
+}
+
+// Update is event received by transport, exactly one of Message and Callback is set
+type Update struct {
+	Message  *messages.Message
+	Callback *callbacks.Callback
+}
+
+// UserID returns author of update, updates of one user have to be handled in order
+func (u Update) UserID() int64 {
+	switch {
+	case u.Message != nil:
+		return u.Message.UserID
+	case u.Callback != nil:
+		return u.Callback.UserID
+	}
+	return 0
+}
+
+type MessageHandler interface {
+	IncomingMessage(ctx context.Context, msg messages.Message) error
+}
+
+type CallbackHandler interface {
+	HandleIncomingCallback(ctx context.Context, callback callbacks.Callback) error
+}
+
+// Core is bot logic which does not depend on transport updates are received by
+type Core struct {
+	messages  MessageHandler
+	callbacks CallbackHandler
+}
+
+func New(messages MessageHandler, callbacks CallbackHandler) *Core {
+	return &Core{
+		messages:  messages,
+		callbacks: callbacks,
+	}
+}
+
+func (c *Core) HandleUpdate(ctx context.Context, update Update) error {
+	switch {
+	case update.Callback != nil:
+		return errors.Wrap(c.callbacks.HandleIncomingCallback(ctx, *update.Callback), "error occurred while processing callback")
+	case update.Message != nil:
+		return errors.Wrap(c.messages.IncomingMessage(ctx, *update.Message), "error occurred while processing message")
+	}
+	return EmptyUpdateErr
+}
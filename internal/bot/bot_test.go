@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	botMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/bot"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+)
+
+func TestCore_HandleUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	messageHandler := botMocks.NewMockMessageHandler(ctrl)
+	callbackHandler := botMocks.NewMockCallbackHandler(ctrl)
+	core := New(messageHandler, callbackHandler)
+
+	msg := messages.Message{Text: "/start", UserID: 1, LanguageCode: "en"}
+	messageHandler.EXPECT().IncomingMessage(gomock.Any(), msg)
+	assert.NoError(t, core.HandleUpdate(ctx, Update{Message: &msg}))
+
+	callback := callbacks.Callback{UserID: 2, MessageID: 3, Data: "report:week"}
+	callbackHandler.EXPECT().HandleIncomingCallback(gomock.Any(), callback).Return(errors.New("db is down"))
+	err := core.HandleUpdate(ctx, Update{Callback: &callback})
+	assert.ErrorContains(t, err, "db is down")
+
+	assert.True(t, errors.Is(core.HandleUpdate(ctx, Update{}), EmptyUpdateErr))
+}
+
+func TestUpdate_UserID(t *testing.T) {
+	assert.EqualValues(t, 1, Update{Message: &messages.Message{UserID: 1}}.UserID())
+	assert.EqualValues(t, 2, Update{Callback: &callbacks.Callback{UserID: 2}}.UserID())
+	assert.Zero(t, Update{}.UserID())
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+)
+
+// LocalBackend runs bot without memcached, cache is kept in process only
+const LocalBackend = "local"
+
+type Cache interface {
+	Get(key string) (string, bool)
+	Add(key string, value string, ttl time.Duration) error
+	Delete(key string) error
+}
+
+type Config interface {
+	CacheBackend() string
+	CacheHost() string
+	CacheLocalSize() int
+	CacheLocalTTL() time.Duration
+}
+
+// New returns local cache for local backend and local cache in front of memcached otherwise
+func New(ctx context.Context, config Config) Cache {
+	local := NewLocal(config.CacheLocalSize())
+	if config.CacheBackend() == LocalBackend {
+		logger.Info("memcached is disabled, using local cache only")
+		return local
+	}
+	return NewTiered(ctx, local, NewMemcached(config.CacheHost()), config.CacheLocalTTL())
+}
+
+// Shared returns store of values shared by all instances: remote tier of tiered cache, which is never
+// kept in local tier, or the cache itself for local backend
+func Shared(c Cache) Store {
+	if tiered, ok := c.(*Tiered); ok {
+		return tiered.Shared()
+	}
+	return c
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Generations keeps per-user cache generation which is a part of keys of user's cached entries,
+// bumping generation makes all of them unreachable at once. Store should be Shared, so that
+// bumps of other instances are seen without delay of local tier
+type Generations struct {
+	store   Store
+	now     func() time.Time
+	counter uint64
+}
+
+func NewGenerations(store Store) *Generations {
+	return &Generations{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Get returns current generation of user, new generation is started when it is missing
+// so entries of evicted generation are never reused
+func (g *Generations) Get(userID int64) string {
+	if generation, ok := g.store.Get(generationKey(userID)); ok {
+		return generation
+	}
+	generation, err := g.start(userID)
+	if err != nil {
+		logger.Warn("cannot save cache generation", zap.Int64("userID", userID), zap.Error(err))
+	}
+	return generation
+}
+
+// Bump starts new generation of user
+func (g *Generations) Bump(userID int64) error {
+	_, err := g.start(userID)
+	return err
+}
+
+func (g *Generations) start(userID int64) (string, error) {
+	// time keeps generations unique across restarts and instances, counter within one instance
+	generation := strconv.FormatInt(g.now().UnixNano(), 36) + "." +
+		strconv.FormatUint(atomic.AddUint64(&g.counter, 1), 36)
+	return generation, g.store.Add(generationKey(userID), generation, 0)
+}
+
+func generationKey(userID int64) string {
+	return fmt.Sprintf("GEN_%d", userID)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerations_BumpChangesGeneration(t *testing.T) {
+	c := NewLocal(10)
+	g := NewGenerations(c)
+
+	first := g.Get(1)
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, g.Get(1))
+	assert.NotEqual(t, first, g.Get(2))
+
+	assert.NoError(t, g.Bump(1))
+	assert.NotEqual(t, first, g.Get(1))
+}
+
+func TestGenerations_LostGenerationIsNotReused(t *testing.T) {
+	c := NewLocal(10)
+	g := NewGenerations(c)
+	first := g.Get(1)
+
+	assert.NoError(t, c.Delete(generationKey(1)))
+
+	assert.NotEqual(t, first, g.Get(1))
+}
+
+func TestGenerations_BumpIsSeenByOtherInstanceAtOnce(t *testing.T) {
+	remote := newFakeRemote()
+	bot := NewTiered(context.Background(), NewLocal(10), remote, time.Minute)
+	worker := NewTiered(context.Background(), NewLocal(10), remote, time.Minute)
+	botGenerations := NewGenerations(Shared(bot))
+	workerGenerations := NewGenerations(Shared(worker))
+
+	first := workerGenerations.Get(1)
+	assert.Equal(t, first, botGenerations.Get(1))
+
+	assert.NoError(t, botGenerations.Bump(1))
+
+	assert.NotEqual(t, first, workerGenerations.Get(1))
+	assert.Equal(t, botGenerations.Get(1), workerGenerations.Get(1))
+	_, cachedLocally := worker.local.Get(generationKey(1))
+	assert.False(t, cachedLocally)
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLocal(2)
+
+	assert.NoError(t, c.Add("a", "1", time.Hour))
+	assert.NoError(t, c.Add("b", "2", time.Hour))
+	_, _ = c.Get("a")
+	assert.NoError(t, c.Add("c", "3", time.Hour))
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLocal_Expiration(t *testing.T) {
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := NewLocal(10)
+	c.now = func() time.Time { return now }
+
+	assert.NoError(t, c.Add("rate", "0.016", time.Minute))
+	assert.NoError(t, c.Add("forever", "1", 0))
+	now = now.Add(time.Minute)
+
+	_, ok := c.Get("rate")
+	assert.False(t, ok)
+	_, ok = c.Get("forever")
+	assert.True(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLocal_AddReplacesAndDeleteRemoves(t *testing.T) {
+	c := NewLocal(10)
+
+	assert.NoError(t, c.Add("key", "old", time.Hour))
+	assert.NoError(t, c.Add("key", "new", time.Hour))
+	v, _ := c.Get("key")
+	assert.Equal(t, "new", v)
+
+	assert.NoError(t, c.Delete("key"))
+	assert.NoError(t, c.Delete("missing"))
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+}
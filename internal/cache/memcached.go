@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/pkg/errors"
+)
+
+// Memcached is remote tier, unlike Local it reports connection failures so Tiered can degrade
+type Memcached struct {
+	mc *memcache.Client
+}
+
+func NewMemcached(addresses ...string) *Memcached {
+	return &Memcached{
+		mc: memcache.New(addresses...),
+	}
+}
+
+// Get returns false without error when key is missing
+func (m *Memcached) Get(key string) (string, bool, error) {
+	it, err := m.mc.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.Wrap(err, "cannot get value from memcached")
+	}
+	return string(it.Value), true, nil
+}
+
+// Set stores value for ttl, value without ttl never expires
+func (m *Memcached) Set(key string, value string, ttl time.Duration) error {
+	item := &memcache.Item{Key: key, Value: []byte(value)}
+	if ttl > 0 {
+		item.Expiration = int32(time.Now().Add(ttl).Unix())
+	}
+	if err := m.mc.Set(item); err != nil {
+		return errors.Wrap(err, "cannot save value to memcached")
+	}
+	return nil
+}
+
+func (m *Memcached) Delete(key string) error {
+	if err := m.mc.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return errors.Wrap(err, "cannot remove value from memcached")
+	}
+	return nil
+}
+
+func (m *Memcached) DeleteAll() error {
+	return errors.Wrap(m.mc.DeleteAll(), "cannot flush memcached")
+}
+
+func (m *Memcached) Ping() error {
+	return m.mc.Ping()
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// RemoteUnavailableErr is returned by shared store while remote tier is unavailable
+var RemoteUnavailableErr = errors.New("remote cache is unavailable")
+
+// Remote is shared cache tier behind local one
+type Remote interface {
+	Get(key string) (string, bool, error)
+	Set(key string, value string, ttl time.Duration) error
+	Delete(key string) error
+	DeleteAll() error
+	Ping() error
+}
+
+const (
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = 30 * time.Second
+	// maxPendingDeletes bounds keys remembered during outage, remote is flushed when there are more
+	maxPendingDeletes = 10000
+)
+
+// Tiered keeps recently used values in local LRU in front of remote cache, values are kept locally
+// no longer than localTTL so deletions made by other instances are seen soon.
+// When remote is unavailable cache works with local tier only and reconnects in background,
+// keys deleted during outage are deleted from remote after reconnection so it does not return stale values
+type Tiered struct {
+	local    *Local
+	remote   Remote
+	localTTL time.Duration
+	ctx      context.Context
+	sleep    func(ctx context.Context, d time.Duration) bool
+
+	mu             sync.Mutex
+	available      bool
+	reconnecting   bool
+	pendingDeletes map[string]struct{}
+	flushPending   bool
+}
+
+func NewTiered(ctx context.Context, local *Local, remote Remote, localTTL time.Duration) *Tiered {
+	return newTiered(ctx, local, remote, localTTL, sleep)
+}
+
+func newTiered(ctx context.Context, local *Local, remote Remote, localTTL time.Duration,
+	sleep func(ctx context.Context, d time.Duration) bool) *Tiered {
+	t := &Tiered{
+		local:          local,
+		remote:         remote,
+		localTTL:       localTTL,
+		ctx:            ctx,
+		sleep:          sleep,
+		available:      true,
+		pendingDeletes: make(map[string]struct{}),
+	}
+	if err := remote.Ping(); err != nil {
+		t.fail(err)
+	} else {
+		metrics.CacheRemoteAvailableGauge.Set(1)
+	}
+	return t
+}
+
+func (t *Tiered) Get(key string) (string, bool) {
+	if value, ok := t.local.Get(key); ok {
+		metrics.CacheTierHitCounter.WithLabelValues(localTier).Inc()
+		return value, true
+	}
+	if !t.isAvailable() {
+		return "", false
+	}
+	value, ok, err := t.remote.Get(key)
+	if err != nil {
+		t.fail(err)
+		return "", false
+	}
+	if ok {
+		metrics.CacheTierHitCounter.WithLabelValues(remoteTier).Inc()
+		// remote ttl is unknown here, so value is kept locally for localTTL only
+		_ = t.local.Add(key, value, t.localTTL)
+	}
+	return value, ok
+}
+
+// Add stores value in both tiers, while remote is unavailable local tier keeps value for the whole ttl
+// and previous remote value is deleted after reconnection
+func (t *Tiered) Add(key string, value string, ttl time.Duration) error {
+	if !t.isAvailable() {
+		t.rememberDelete(key)
+		return t.local.Add(key, value, ttl)
+	}
+	_ = t.local.Add(key, value, t.capTTL(ttl))
+	if err := t.remote.Set(key, value, ttl); err != nil {
+		_ = t.local.Add(key, value, ttl)
+		t.rememberDelete(key)
+		t.fail(err)
+	}
+	return nil
+}
+
+func (t *Tiered) Delete(key string) error {
+	_ = t.local.Delete(key)
+	if !t.isAvailable() {
+		t.rememberDelete(key)
+		return nil
+	}
+	if err := t.remote.Delete(key); err != nil {
+		t.rememberDelete(key)
+		t.fail(err)
+	}
+	return nil
+}
+
+// Shared returns store reading and writing remote tier only, it keeps values changed by other instances
+// which must be seen by this instance at once, all reads miss while remote is unavailable
+func (t *Tiered) Shared() Store {
+	return sharedTier{tiered: t}
+}
+
+type sharedTier struct {
+	tiered *Tiered
+}
+
+func (s sharedTier) Get(key string) (string, bool) {
+	if !s.tiered.isAvailable() {
+		return "", false
+	}
+	value, ok, err := s.tiered.remote.Get(key)
+	if err != nil {
+		s.tiered.fail(err)
+		return "", false
+	}
+	return value, ok
+}
+
+func (s sharedTier) Add(key string, value string, ttl time.Duration) error {
+	if !s.tiered.isAvailable() {
+		return RemoteUnavailableErr
+	}
+	if err := s.tiered.remote.Set(key, value, ttl); err != nil {
+		s.tiered.fail(err)
+		return errors.Wrap(err, "cannot save value to remote cache")
+	}
+	return nil
+}
+
+// Available reports whether remote tier is used
+func (t *Tiered) Available() bool {
+	return t.isAvailable()
+}
+
+func (t *Tiered) capTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > t.localTTL {
+		return t.localTTL
+	}
+	return ttl
+}
+
+func (t *Tiered) isAvailable() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.available
+}
+
+func (t *Tiered) rememberDelete(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pendingDeletes) >= maxPendingDeletes {
+		t.flushPending = true
+		return
+	}
+	t.pendingDeletes[key] = struct{}{}
+}
+
+// fail switches cache to local tier and starts reconnection unless it is already running
+func (t *Tiered) fail(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.available = false
+	metrics.CacheRemoteAvailableGauge.Set(0)
+	if t.reconnecting {
+		return
+	}
+	t.reconnecting = true
+	logger.Warn("remote cache is unavailable, using local cache only", zap.Error(err))
+	go t.reconnect()
+}
+
+func (t *Tiered) reconnect() {
+	backoff := minReconnectBackoff
+	for t.sleep(t.ctx, backoff) {
+		if err := t.remote.Ping(); err != nil {
+			logger.Debug("remote cache is still unavailable", zap.Error(err))
+			backoff *= 2
+			if backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+			continue
+		}
+		if err := t.replayDeletes(); err != nil {
+			logger.Warn("cannot replay deletes on remote cache", zap.Error(err))
+			continue
+		}
+		if t.markAvailable() {
+			logger.Info("remote cache is available again")
+			return
+		}
+	}
+}
+
+// markAvailable switches back to remote tier unless more keys were deleted while deletes were replayed
+func (t *Tiered) markAvailable() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pendingDeletes) > 0 || t.flushPending {
+		return false
+	}
+	t.available = true
+	t.reconnecting = false
+	metrics.CacheRemoteAvailableGauge.Set(1)
+	return true
+}
+
+// replayDeletes removes keys deleted during outage, pending keys are kept until remote accepts them
+func (t *Tiered) replayDeletes() error {
+	t.mu.Lock()
+	keys := make([]string, 0, len(t.pendingDeletes))
+	for key := range t.pendingDeletes {
+		keys = append(keys, key)
+	}
+	flush := t.flushPending
+	t.mu.Unlock()
+
+	if flush {
+		if err := t.remote.DeleteAll(); err != nil {
+			return err
+		}
+	} else {
+		for _, key := range keys {
+			if err := t.remote.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range keys {
+		delete(t.pendingDeletes, key)
+	}
+	if flush {
+		t.pendingDeletes = make(map[string]struct{})
+		t.flushPending = false
+	}
+	return nil
+}
+
+const (
+	localTier  = "local"
+	remoteTier = "remote"
+)
+
+// sleep waits for d and reports false when context is done
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var connectionErr = errors.New("connection refused")
+
+// fakeRemote is memcached stand-in which can be switched off
+type fakeRemote struct {
+	mu    sync.Mutex
+	down  bool
+	items map[string]string
+}
+
+func newFakeRemote() *fakeRemote {
+	return &fakeRemote{items: make(map[string]string)}
+}
+
+func (r *fakeRemote) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+func (r *fakeRemote) Get(key string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return "", false, connectionErr
+	}
+	v, ok := r.items[key]
+	return v, ok, nil
+}
+
+func (r *fakeRemote) Set(key string, value string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return connectionErr
+	}
+	r.items[key] = value
+	return nil
+}
+
+func (r *fakeRemote) Delete(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return connectionErr
+	}
+	delete(r.items, key)
+	return nil
+}
+
+func (r *fakeRemote) DeleteAll() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = make(map[string]string)
+	return nil
+}
+
+func (r *fakeRemote) Ping() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return connectionErr
+	}
+	return nil
+}
+
+func (r *fakeRemote) value(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.items[key]
+	return v, ok
+}
+
+func newTestTiered(t *testing.T, remote *fakeRemote) *Tiered {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return newTiered(ctx, NewLocal(10), remote, time.Minute, func(ctx context.Context, _ time.Duration) bool {
+		time.Sleep(time.Millisecond)
+		return ctx.Err() == nil
+	})
+}
+
+func TestTiered_ReadsThroughRemote(t *testing.T) {
+	remote := newFakeRemote()
+	remote.items["shared"] = "value"
+	c := newTestTiered(t, remote)
+
+	v, ok := c.Get("shared")
+	assert.True(t, ok)
+	assert.Equal(t, "value", v)
+
+	assert.NoError(t, c.Add("key", "1", time.Hour))
+	v, ok = remote.value("key")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+
+	assert.NoError(t, remote.Delete("shared"))
+	v, ok = c.Get("shared")
+	assert.True(t, ok, "value read from remote is kept in local tier")
+	assert.Equal(t, "value", v)
+}
+
+func TestTiered_DegradesToLocalAndReconnects(t *testing.T) {
+	remote := newFakeRemote()
+	remote.items["report"] = "stale"
+	c := newTestTiered(t, remote)
+
+	remote.setDown(true)
+	assert.NoError(t, c.Add("dialog", "state", time.Hour))
+	assert.False(t, c.Available())
+
+	v, ok := c.Get("dialog")
+	assert.True(t, ok, "local tier serves values while remote is down")
+	assert.Equal(t, "state", v)
+	assert.NoError(t, c.Delete("report"))
+
+	remote.setDown(false)
+	assert.Eventually(t, c.Available, time.Second, time.Millisecond)
+	_, ok = remote.value("report")
+	assert.False(t, ok, "key deleted during outage is deleted from remote after reconnection")
+	_, ok = c.Get("report")
+	assert.False(t, ok)
+}
+
+func TestTiered_StartsWithoutRemote(t *testing.T) {
+	remote := newFakeRemote()
+	remote.setDown(true)
+
+	c := newTestTiered(t, remote)
+	assert.NoError(t, c.Add("key", "1", time.Hour))
+
+	v, ok := c.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+}
+
+func TestTiered_ValueAddedDuringOutageReplacesRemoteOne(t *testing.T) {
+	remote := newFakeRemote()
+	remote.items["GEN_1"] = "old"
+	c := newTestTiered(t, remote)
+
+	remote.setDown(true)
+	assert.NoError(t, c.Add("GEN_1", "new", 0))
+	remote.setDown(false)
+
+	assert.Eventually(t, c.Available, time.Second, time.Millisecond)
+	_, ok := remote.value("GEN_1")
+	assert.False(t, ok, "outdated remote value is deleted after reconnection")
+	v, _ := c.Get("GEN_1")
+	assert.Equal(t, "new", v)
+}
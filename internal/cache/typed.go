@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Store is part of Cache needed to keep typed values
+type Store interface {
+	Get(key string) (string, bool)
+	Add(key string, value string, ttl time.Duration) error
+}
+
+// GetJSON returns value saved by SetJSON, value which cannot be decoded is treated as missing
+func GetJSON[T any](store Store, key string) (T, bool) {
+	var value T
+	raw, ok := store.Get(key)
+	if !ok {
+		return value, false
+	}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		logger.Warn("cannot decode cached value", zap.String("key", key), zap.Error(err))
+		var empty T
+		return empty, false
+	}
+	return value, true
+}
+
+// SetJSON saves value encoded to json
+func SetJSON[T any](store Store, key string, value T, ttl time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode value for cache")
+	}
+	return store.Add(key, string(b), ttl)
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON_RoundTrip(t *testing.T) {
+	c := NewLocal(10)
+	rates := map[string]decimal.Decimal{"USD": decimal.RequireFromString("0.016")}
+
+	assert.NoError(t, SetJSON(c, "rates", rates, time.Hour))
+	raw, _ := c.Get("rates")
+	assert.Equal(t, `{"USD":"0.016"}`, raw)
+
+	got, ok := GetJSON[map[string]decimal.Decimal](c, "rates")
+	assert.True(t, ok)
+	assert.True(t, rates["USD"].Equal(got["USD"]))
+}
+
+func TestJSON_UndecodableValueIsMissing(t *testing.T) {
+	c := NewLocal(10)
+	assert.NoError(t, c.Add("rates", "not json", time.Hour))
+
+	got, ok := GetJSON[map[string]decimal.Decimal](c, "rates")
+	assert.False(t, ok)
+	assert.Nil(t, got)
+
+	_, ok = GetJSON[int](c, "missing")
+	assert.False(t, ok)
+}
@@ -4,29 +4,44 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/httpclient"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/config"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"go.uber.org/zap"
 )
 
+const ProviderName = "abstract"
+
+const (
+	requestTimeout = 10 * time.Second
+	baseBackoff    = 500 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
 type CurrencyClient struct {
 	baseURL string
 	apiKey  string
-	client  *http.Client
+	client  *httpclient.Client
 }
 
 func NewCurrencyClient(config *config.Service) *CurrencyClient {
-	transport := &http.Transport{
-		MaxIdleConns:    10,
-		IdleConnTimeout: 1 * time.Second,
-	}
-	client := &http.Client{Transport: transport}
+	client := httpclient.New(ProviderName, httpclient.Config{
+		Timeout:           requestTimeout,
+		Retries:           config.AbstractAPIRetries(),
+		BaseBackoff:       baseBackoff,
+		MaxBackoff:        maxBackoff,
+		RequestsPerSecond: config.AbstractAPIRequestsPerSecond(),
+		Burst:             1,
+		FailureThreshold:  config.AbstractAPIBreakerFailures(),
+		Cooldown:          config.AbstractAPIBreakerCooldown(),
+	})
 	return &CurrencyClient{
 		baseURL: "https://exchange-rates.abstractapi.com",
 		apiKey:  config.AbstractAPIKey(),
@@ -34,73 +49,104 @@ func NewCurrencyClient(config *config.Service) *CurrencyClient {
 	}
 }
 
-func (s *CurrencyClient) GetLiveCurrency(ctx context.Context) (map[string]decimal.Decimal, error) {
-	body, err := s.generalCurrencyRequestMaker(ctx, "v1/live")
+func (s *CurrencyClient) Name() string {
+	return ProviderName
+}
+
+func (s *CurrencyClient) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	body, err := s.generalCurrencyRequestMaker(ctx, "v1/live", currencies)
 	if err != nil {
 		logger.Error("error while request api in method GetLiveCurrency", zap.Error(err))
 		return nil, err
 	}
 	var result *CurrencyLiveResponse
-	if err1 := json.Unmarshal(body, &result); err1 != nil || result == nil {
-		logger.Error("cannot unmarshal response in method GetLiveCurrency", zap.Error(err))
-		return nil, err
+	if err1 := json.Unmarshal(body, &result); err1 != nil {
+		logger.Error("cannot unmarshal response in method GetLiveCurrency", zap.Error(err1))
+		return nil, errors.Wrap(err1, "cannot unmarshal abstract api response")
+	}
+	if result != nil && result.Error != nil {
+		return nil, errors.Wrap(result.Error, "abstract api returned error")
 	}
-	time.Sleep(time.Second) // because API one request per second constraint
-	return result.ExchangeRates, nil
+	if result == nil || len(result.ExchangeRates) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	published := time.Now()
+	if result.LastUpdated > 0 {
+		published = time.Unix(result.LastUpdated, 0)
+	}
+	return toQuotes(result.ExchangeRates, published), nil
 }
 
 const historicalDateFormat = "2006-01-02"
 
-func (s *CurrencyClient) GetHistoricalCurrency(ctx context.Context, day time.Time) (map[string]decimal.Decimal, error) {
+func (s *CurrencyClient) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
 	dateConstraint := fmt.Sprintf("&date=%s", day.Format(historicalDateFormat))
-	body, err := s.generalCurrencyRequestMaker(ctx, "v1/historical", dateConstraint)
+	body, err := s.generalCurrencyRequestMaker(ctx, "v1/historical", currencies, dateConstraint)
 	if err != nil {
 		logger.Error("error while request api in method GetHistoricalCurrency", zap.Error(err))
 		return nil, err
 	}
 	var result *CurrencyHistoricalResponse
-	if err1 := json.Unmarshal(body, &result); err1 != nil || result == nil {
-		logger.Error("cannot unmarshal response in method GetHistoricalCurrency", zap.Error(err))
-		return nil, err
+	if err1 := json.Unmarshal(body, &result); err1 != nil {
+		logger.Error("cannot unmarshal response in method GetHistoricalCurrency", zap.Error(err1))
+		return nil, errors.Wrap(err1, "cannot unmarshal abstract api response")
+	}
+	if result != nil && result.Error != nil {
+		return nil, errors.Wrap(result.Error, "abstract api returned error")
+	}
+	if result == nil || len(result.ExchangeRates) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	published, err := time.Parse(historicalDateFormat, result.Date)
+	if err != nil {
+		published = day
 	}
-	time.Sleep(time.Second) // because API one request per second constraint
-	return result.ExchangeRates, nil
+	return toQuotes(result.ExchangeRates, published), nil
 }
 
-func (s *CurrencyClient) generalCurrencyRequestMaker(ctx context.Context, method string, constraints ...string) ([]byte, error) {
-	url := fmt.Sprintf("%s/%s?api_key=%s&base=RUB&target=USD,EUR,CNY", s.baseURL, method, s.apiKey)
+func toQuotes(rates map[string]decimal.Decimal, published time.Time) map[string]model.Quote {
+	quotes := make(map[string]model.Quote, len(rates))
+	for currency, multiplier := range rates {
+		quotes[currency] = model.Quote{Multiplier: multiplier, Provider: ProviderName, Date: published}
+	}
+	return quotes
+}
+
+func (s *CurrencyClient) generalCurrencyRequestMaker(ctx context.Context, method string, currencies []string, constraints ...string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s?api_key=%s&base=%s&target=%s", s.baseURL, method, s.apiKey,
+		constants.ServerCurrency, strings.Join(currencies, ","))
 	if len(constraints) > 0 {
 		url = fmt.Sprintf("%s&%s", url, strings.Join(constraints, "&"))
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		logger.Error(fmt.Sprintf("cannot create request to abstract currency api in method '%s'", method), zap.Error(err))
-		return nil, err
-	}
-
-	logger.Debug("outgoing http request", zap.String("url", url), zap.String("method", http.MethodGet))
-	resp, err := s.client.Do(req)
+	logger.Debug("outgoing http request", zap.String("method", method))
+	body, err := s.client.Get(ctx, url)
 	if err != nil {
 		logger.Error(fmt.Sprintf("error while making request to abstract currency api in method '%s'", method), zap.Error(err))
 		return nil, err
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error(fmt.Sprintf("cannot read response from abstract currency api in method '%s'", method), zap.Error(err))
-		return nil, err
-	}
 	return body, nil
 }
 
+// APIError is sent by abstract api with 200 status for some failures, e.g. unsupported currency
+type APIError struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
 type CurrencyLiveResponse struct {
 	Base          string                     `json:"base"`
 	LastUpdated   int64                      `json:"last_updated"`
 	ExchangeRates map[string]decimal.Decimal `json:"exchange_rates"`
+	Error         *APIError                  `json:"error"`
 }
 
 type CurrencyHistoricalResponse struct {
 	Base          string                     `json:"base"`
 	Date          string                     `json:"date"`
 	ExchangeRates map[string]decimal.Decimal `json:"exchange_rates"`
+	Error         *APIError                  `json:"error"`
 }
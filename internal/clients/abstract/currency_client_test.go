@@ -0,0 +1,93 @@
+package abstract
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/httpclient"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *CurrencyClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &CurrencyClient{
+		baseURL: server.URL,
+		apiKey:  "key",
+		client:  httpclient.New(ProviderName, httpclient.Config{Retries: 1, Timeout: time.Second}),
+	}
+}
+
+func TestCurrencyClient_GetLiveCurrency(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"base":"RUB","last_updated":1666051200,"exchange_rates":{"USD":0.016,"EUR":0.0165}}`))
+	})
+
+	rates, err := c.GetLiveCurrency(context.Background(), []string{"USD", "EUR"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "api_key=key&base=RUB&target=USD,EUR", query)
+	assert.True(t, decimal.NewFromFloat(0.016).Equal(rates["USD"].Multiplier))
+	assert.True(t, decimal.NewFromFloat(0.0165).Equal(rates["EUR"].Multiplier))
+}
+
+func TestCurrencyClient_GetHistoricalCurrency_Errors(t *testing.T) {
+	day := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{
+			name:    "invalid api key",
+			status:  http.StatusUnauthorized,
+			body:    `{"error":{"message":"Invalid API key","code":"unauthorized"}}`,
+			wantErr: httpclient.UnauthorizedErr,
+		},
+		{
+			name:    "outage",
+			status:  http.StatusServiceUnavailable,
+			wantErr: httpclient.ServerErr,
+		},
+		{
+			name:    "no rates for date",
+			status:  http.StatusOK,
+			body:    `{"base":"RUB","date":"2022-10-18","exchange_rates":{}}`,
+			wantErr: constants.EmptyRatesErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "2022-10-18", r.URL.Query().Get("date"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := c.GetHistoricalCurrency(context.Background(), day, []string{"USD"})
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+		})
+	}
+}
+
+func TestCurrencyClient_GetLiveCurrency_APIError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error":{"message":"Target currency is not supported","code":"validation_error"}}`))
+	})
+
+	_, err := c.GetLiveCurrency(context.Background(), []string{"XXX"})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "validation_error", apiErr.Code)
+}
@@ -0,0 +1,138 @@
+package cbr
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const ProviderName = "cbr"
+
+const (
+	// dateFormat of date_req parameter, feed returns latest rates published on or before the date
+	dateFormat = "02/01/2006"
+	// feedDateFormat of date rates are published for
+	feedDateFormat = "02.01.2006"
+)
+
+// RatesClient loads daily rates of the Central Bank of Russia, which are quoted in rubles
+type RatesClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewRatesClient() *RatesClient {
+	return &RatesClient{
+		baseURL: "https://www.cbr.ru",
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *RatesClient) Name() string {
+	return ProviderName
+}
+
+func (c *RatesClient) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	return c.getRates(ctx, "", currencies)
+}
+
+func (c *RatesClient) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	return c.getRates(ctx, day.Format(dateFormat), currencies)
+}
+
+type valCurs struct {
+	Date    string   `xml:"Date,attr"`
+	Valutes []valute `xml:"Valute"`
+}
+
+type valute struct {
+	CharCode string `xml:"CharCode"`
+	Nominal  string `xml:"Nominal"`
+	Value    string `xml:"Value"`
+}
+
+func (c *RatesClient) getRates(ctx context.Context, date string, currencies []string) (map[string]model.Quote, error) {
+	url := fmt.Sprintf("%s/scripts/XML_daily.asp", c.baseURL)
+	if date != "" {
+		url = fmt.Sprintf("%s?date_req=%s", url, date)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create request to cbr")
+	}
+
+	logger.Debug("outgoing http request", zap.String("url", url), zap.String("method", http.MethodGet))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot make request to cbr")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected cbr response status: %d", resp.StatusCode)
+	}
+
+	var result valCurs
+	decoder := xml.NewDecoder(resp.Body)
+	decoder.CharsetReader = charsetReader
+	if err = decoder.Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "cannot decode cbr response")
+	}
+	published, err := time.Parse(feedDateFormat, result.Date)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse date of cbr rates %q", result.Date)
+	}
+	return toQuotes(result.Valutes, currencies, published)
+}
+
+// toQuotes turns price of nominal units in rubles into units of currency per one ruble
+func toQuotes(valutes []valute, currencies []string, published time.Time) (map[string]model.Quote, error) {
+	rates := make(map[string]model.Quote, len(currencies))
+	for _, v := range valutes {
+		if !contains(currencies, v.CharCode) {
+			continue
+		}
+		nominal, err := decimal.NewFromString(v.Nominal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse nominal of %s", v.CharCode)
+		}
+		value, err := decimal.NewFromString(strings.Replace(v.Value, ",", ".", 1))
+		if err != nil || !value.IsPositive() {
+			return nil, fmt.Errorf("cannot parse rate of %s: %q", v.CharCode, v.Value)
+		}
+		rates[v.CharCode] = model.Quote{Multiplier: nominal.Div(value), Provider: ProviderName, Date: published}
+	}
+	if len(rates) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	return rates, nil
+}
+
+// charsetReader decodes windows-1251 feed, other encodings are passed as is
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	if strings.EqualFold(label, "windows-1251") {
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	}
+	return input, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
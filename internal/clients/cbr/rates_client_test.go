@@ -0,0 +1,60 @@
+package cbr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const dailyXML = `<?xml version="1.0" encoding="windows-1251"?>
+<ValCurs Date="18.10.2022" name="Foreign Currency Market">
+<Valute ID="R01235"><NumCode>840</NumCode><CharCode>USD</CharCode><Nominal>1</Nominal><Name>Доллар США</Name><Value>62,5000</Value></Valute>
+<Valute ID="R01375"><NumCode>156</NumCode><CharCode>CNY</CharCode><Nominal>10</Nominal><Name>Китайский юань</Name><Value>80,0000</Value></Valute>
+<Valute ID="R01820"><NumCode>392</NumCode><CharCode>JPY</CharCode><Nominal>100</Nominal><Name>Японских иен</Name><Value>42,0000</Value></Valute>
+</ValCurs>`
+
+var currencies = []string{"USD", "EUR", "CNY"}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *RatesClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := NewRatesClient()
+	c.baseURL = server.URL
+	return c
+}
+
+func TestRatesClient_GetHistoricalCurrency(t *testing.T) {
+	body, err := charmap.Windows1251.NewEncoder().String(dailyXML)
+	assert.NoError(t, err)
+	var requestedDate string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requestedDate = r.URL.Query().Get("date_req")
+		_, _ = w.Write([]byte(body))
+	})
+
+	rates, err := c.GetHistoricalCurrency(context.Background(), time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC), currencies)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "18/10/2022", requestedDate)
+	assert.Len(t, rates, 2)
+	assert.True(t, decimal.RequireFromString("0.016").Equal(rates["USD"].Multiplier), rates["USD"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.125").Equal(rates["CNY"].Multiplier), rates["CNY"].Multiplier.String())
+	assert.Equal(t, ProviderName, rates["USD"].Provider)
+	assert.Equal(t, time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC), rates["USD"].Date)
+}
+
+func TestRatesClient_UnexpectedStatus(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.GetLiveCurrency(context.Background(), currencies)
+
+	assert.Error(t, err)
+}
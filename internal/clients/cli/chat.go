@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/bot"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+	"go.uber.org/zap"
+)
+
+var _ bot.Messenger = (*Chat)(nil)
+
+var noButtonErr = errors.New("no such button")
+
+// pressPattern is input which presses button shown with the same number, e.g. "[2]"
+var pressPattern = regexp.MustCompile(`^\[(\d+)]$`)
+
+// attachPrefix starts input which sends local file with optional caption, e.g. "@receipt.jpg /receipt"
+const attachPrefix = "@"
+
+// UpdateHandler is bot logic which typed lines are passed to
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update bot.Update) error
+}
+
+type button struct {
+	messageID int
+	data      string
+}
+
+// Chat is terminal transport driving the bot on behalf of one user. Every line is sent as message,
+// buttons of the last message with keyboard are numbered and pressed by typing their number in brackets,
+// local files are attached by typing their path after @
+type Chat struct {
+	in           io.Reader
+	out          io.Writer
+	userID       int64
+	languageCode string
+
+	mu            sync.Mutex
+	lastMessageID int
+	buttons       []button
+}
+
+func New(in io.Reader, out io.Writer, userID int64, languageCode string) *Chat {
+	return &Chat{
+		in:           in,
+		out:          out,
+		userID:       userID,
+		languageCode: languageCode,
+	}
+}
+
+// Listen passes typed lines to handler until input ends or ctx is done
+func (c *Chat) Listen(ctx context.Context, handler UpdateHandler) error {
+	scanner := bufio.NewScanner(c.in)
+	for ctx.Err() == nil && scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		update, err := c.toUpdate(line)
+		if err != nil {
+			c.print("! %s\n", err)
+			continue
+		}
+		if err = handler.HandleUpdate(ctx, update); err != nil {
+			logger.Error("cannot handle update", zap.Error(err))
+			c.print("! %s\n", err)
+		}
+	}
+	return errors.Wrap(scanner.Err(), "cannot read chat input")
+}
+
+func (c *Chat) toUpdate(line string) (bot.Update, error) {
+	if strings.HasPrefix(line, attachPrefix) {
+		return c.attach(strings.TrimPrefix(line, attachPrefix))
+	}
+	match := pressPattern.FindStringSubmatch(line)
+	if match == nil {
+		return bot.Update{Message: &messages.Message{
+			Text:         line,
+			UserID:       c.userID,
+			LanguageCode: c.languageCode,
+		}}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, _ := strconv.Atoi(match[1])
+	if n < 1 || n > len(c.buttons) {
+		return bot.Update{}, errors.Wrapf(noButtonErr, "[%d]", n)
+	}
+	pressed := c.buttons[n-1]
+	return bot.Update{Callback: &callbacks.Callback{
+		UserID:       c.userID,
+		MessageID:    pressed.messageID,
+		LanguageCode: c.languageCode,
+		Data:         pressed.data,
+	}}, nil
+}
+
+// attach sends local file as photo if it is JPEG, as voice message if it is OGG, otherwise as document,
+// path is id of the file
+func (c *Chat) attach(input string) (bot.Update, error) {
+	path, caption, _ := strings.Cut(input, " ")
+	info, err := os.Stat(path)
+	if err != nil {
+		return bot.Update{}, errors.Wrap(err, "cannot attach file")
+	}
+	if info.IsDir() {
+		return bot.Update{}, errors.Errorf("cannot attach directory %s", path)
+	}
+	attachment := &model.Attachment{FileID: path, Size: info.Size()}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".jpg", ".jpeg":
+		attachment.Kind, attachment.MimeType = model.PhotoAttachment, "image/jpeg"
+	case ".ogg", ".oga":
+		attachment.Kind, attachment.MimeType = model.VoiceAttachment, "audio/ogg"
+	default:
+		attachment.Kind, attachment.MimeType = model.DocumentAttachment, mime.TypeByExtension(ext)
+		attachment.FileName = filepath.Base(path)
+	}
+	return bot.Update{Message: &messages.Message{
+		Text:         strings.TrimSpace(caption),
+		UserID:       c.userID,
+		LanguageCode: c.languageCode,
+		Attachment:   attachment,
+	}}, nil
+}
+
+// SendAttachment prints path of file attached by user earlier
+func (c *Chat) SendAttachment(attachment model.Attachment, userID int64) error {
+	return c.SendMessage(fmt.Sprintf("[%s %s]", attachment.Kind, attachment.FileID), userID)
+}
+
+// DownloadFile reads local file attached by user
+func (c *Chat) DownloadFile(_ context.Context, fileID string) ([]byte, error) {
+	data, err := os.ReadFile(fileID)
+	return data, errors.Wrap(err, "cannot read attached file")
+}
+
+func (c *Chat) SendMessage(text string, userID int64) error {
+	return c.SendMessageWithMarkup(text, nil, userID)
+}
+
+func (c *Chat) SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error {
+	c.mu.Lock()
+	c.lastMessageID++
+	messageID := c.lastMessageID
+	c.mu.Unlock()
+	return c.show(fmt.Sprintf("#%d", messageID), text, markup, userID, messageID)
+}
+
+func (c *Chat) SendEditMessage(text string, userID int64, messageID int) error {
+	return c.SendEditMessageWithMarkupAndText(text, nil, userID, messageID)
+}
+
+func (c *Chat) SendEditMessageWithMarkupAndText(text string, markup [][]model.MarkupData, userID int64, messageID int) error {
+	return c.show(fmt.Sprintf("#%d (edited)", messageID), text, markup, userID, messageID)
+}
+
+// show prints message with its keyboard, messages for other users (e.g. alerts) are marked with recipient
+func (c *Chat) show(header string, text string, markup [][]model.MarkupData, userID int64, messageID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if userID != c.userID {
+		header += fmt.Sprintf(" to %d", userID)
+	}
+	var b strings.Builder
+	b.WriteString(header + "\n" + text + "\n")
+	if len(markup) > 0 && userID == c.userID {
+		c.buttons = c.buttons[:0]
+		for _, row := range markup {
+			labels := make([]string, 0, len(row))
+			for _, data := range row {
+				c.buttons = append(c.buttons, button{messageID: messageID, data: data.Data})
+				labels = append(labels, fmt.Sprintf("[%d] %s", len(c.buttons), data.Text))
+			}
+			b.WriteString("  " + strings.Join(labels, "  ") + "\n")
+		}
+	}
+	_, err := io.WriteString(c.out, b.String())
+	return errors.Wrap(err, "cannot write to chat output")
+}
+
+func (c *Chat) print(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(c.out, format, args...)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/bot"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// echoHandler replies to every message with keyboard and records received updates
+type echoHandler struct {
+	chat    *Chat
+	updates []bot.Update
+}
+
+func (h *echoHandler) HandleUpdate(_ context.Context, update bot.Update) error {
+	h.updates = append(h.updates, update)
+	if update.Callback != nil {
+		return h.chat.SendEditMessage("pressed "+update.Callback.Data, update.UserID(), update.Callback.MessageID)
+	}
+	return h.chat.SendMessageWithMarkup("choose", [][]model.MarkupData{
+		{{Text: "Week", Data: "report:week"}, {Text: "Month", Data: "report:month"}},
+		{{Text: "Year", Data: "report:year"}},
+	}, update.UserID())
+}
+
+func TestChat_DrivesBotByTypedLines(t *testing.T) {
+	out := &bytes.Buffer{}
+	chat := New(strings.NewReader("/report\n\n[3]\n[9]\n"), out, 42, "en")
+	handler := &echoHandler{chat: chat}
+
+	err := chat.Listen(context.Background(), handler)
+
+	assert.NoError(t, err)
+	assert.Len(t, handler.updates, 2)
+	assert.Equal(t, "/report", handler.updates[0].Message.Text)
+	assert.EqualValues(t, 42, handler.updates[0].UserID())
+	assert.Equal(t, "en", handler.updates[0].Message.LanguageCode)
+	assert.Equal(t, "report:year", handler.updates[1].Callback.Data)
+	assert.Equal(t, 1, handler.updates[1].Callback.MessageID)
+	assert.Equal(t, "#1\nchoose\n  [1] Week  [2] Month\n  [3] Year\n"+
+		"#1 (edited)\npressed report:year\n"+
+		"! [9]: no such button\n", out.String())
+}
+
+func TestChat_MarksMessagesForOtherUsers(t *testing.T) {
+	out := &bytes.Buffer{}
+	chat := New(strings.NewReader(""), out, 42, "en")
+
+	assert.NoError(t, chat.SendMessageWithMarkup("alert", [][]model.MarkupData{{{Text: "Ok", Data: "ok"}}}, 7))
+
+	assert.Equal(t, "#1 to 7\nalert\n", out.String())
+	_, err := chat.toUpdate("[1]")
+	assert.Error(t, err)
+}
+
+func TestChat_AttachesLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "receipt.jpg")
+	assert.NoError(t, os.WriteFile(photo, []byte("jpeg"), 0o600))
+	out := &bytes.Buffer{}
+	chat := New(strings.NewReader("@"+photo+" /receipt\n@"+filepath.Join(dir, "missing.png")+"\n"), out, 42, "en")
+	handler := &echoHandler{chat: chat}
+
+	err := chat.Listen(context.Background(), handler)
+
+	assert.NoError(t, err)
+	assert.Len(t, handler.updates, 1)
+	msg := handler.updates[0].Message
+	assert.Equal(t, "/receipt", msg.Text)
+	assert.Equal(t, &model.Attachment{Kind: model.PhotoAttachment, FileID: photo, MimeType: "image/jpeg", Size: 4}, msg.Attachment)
+	assert.Contains(t, out.String(), "! cannot attach file")
+
+	data, err := chat.DownloadFile(context.Background(), msg.Attachment.FileID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("jpeg"), data)
+}
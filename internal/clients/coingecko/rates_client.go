@@ -0,0 +1,179 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/httpclient"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+const ProviderName = "coingecko"
+
+const (
+	historyDateFormat = "02-01-2006"
+	requestTimeout    = 10 * time.Second
+	// public api allows about ten calls per minute
+	requestsPerSecond = 0.2
+	burst             = 5
+	retries           = 2
+	baseBackoff       = time.Second
+	maxBackoff        = 10 * time.Second
+	failureThreshold  = 5
+	cooldown          = time.Minute
+)
+
+// coinIDs maps asset codes to coingecko coin ids
+var coinIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"TON":  "the-open-network",
+	"USDT": "tether",
+	"USDC": "usd-coin",
+}
+
+// metals are quoted by coingecko only as vs currencies, their rates are taken from prices of referenceCoin
+var metals = map[string]string{
+	"XAU": "xau",
+	"XAG": "xag",
+}
+
+const referenceCoin = "bitcoin"
+
+// RatesClient loads prices of crypto currencies and precious metals
+// and converts them to units of asset per one unit of server currency
+type RatesClient struct {
+	baseURL string
+	base    string
+	client  *httpclient.Client
+}
+
+func NewRatesClient() *RatesClient {
+	return &RatesClient{
+		baseURL: "https://api.coingecko.com/api/v3",
+		base:    strings.ToLower(constants.ServerCurrency),
+		client: httpclient.New(ProviderName, httpclient.Config{
+			Timeout:           requestTimeout,
+			Retries:           retries,
+			BaseBackoff:       baseBackoff,
+			MaxBackoff:        maxBackoff,
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             burst,
+			FailureThreshold:  failureThreshold,
+			Cooldown:          cooldown,
+		}),
+	}
+}
+
+func (c *RatesClient) Name() string {
+	return ProviderName
+}
+
+func (c *RatesClient) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	coins, vs := c.split(currencies)
+	if len(coins) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	query := url.Values{}
+	query.Set("ids", strings.Join(coins, ","))
+	query.Set("vs_currencies", strings.Join(vs, ","))
+
+	var prices map[string]map[string]decimal.Decimal
+	if err := c.get(ctx, "simple/price?"+query.Encode(), &prices); err != nil {
+		return nil, err
+	}
+	return c.toQuotes(currencies, time.Now(), func(coin string) map[string]decimal.Decimal {
+		return prices[coin]
+	})
+}
+
+// GetHistoricalCurrency returns daily prices at 00:00 UTC of the day, every coin is requested separately
+func (c *RatesClient) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	coins, _ := c.split(currencies)
+	prices := make(map[string]map[string]decimal.Decimal, len(coins))
+	for _, coin := range coins {
+		query := url.Values{}
+		query.Set("date", day.Format(historyDateFormat))
+		query.Set("localization", "false")
+
+		var history historyResponse
+		if err := c.get(ctx, fmt.Sprintf("coins/%s/history?%s", coin, query.Encode()), &history); err != nil {
+			return nil, err
+		}
+		prices[coin] = history.MarketData.CurrentPrice
+	}
+	return c.toQuotes(currencies, day, func(coin string) map[string]decimal.Decimal {
+		return prices[coin]
+	})
+}
+
+type historyResponse struct {
+	MarketData struct {
+		CurrentPrice map[string]decimal.Decimal `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// split returns coins to request and currencies to quote them in, metals need price of reference coin
+func (c *RatesClient) split(currencies []string) (coins, vs []string) {
+	vs = []string{c.base}
+	for _, currency := range currencies {
+		if id, ok := coinIDs[currency]; ok {
+			coins = append(coins, id)
+		}
+		if metal, ok := metals[currency]; ok {
+			coins = append(coins, referenceCoin)
+			vs = append(vs, metal)
+		}
+	}
+	return lo.Uniq(coins), vs
+}
+
+// toQuotes converts prices in server currency into units of asset per one unit of server currency
+func (c *RatesClient) toQuotes(currencies []string, published time.Time,
+	pricesOf func(coin string) map[string]decimal.Decimal) (map[string]model.Quote, error) {
+	rates := make(map[string]model.Quote, len(currencies))
+	quote := func(multiplier decimal.Decimal) model.Quote {
+		return model.Quote{Multiplier: multiplier, Provider: ProviderName, Date: published}
+	}
+	for _, currency := range currencies {
+		if id, ok := coinIDs[currency]; ok {
+			if price := pricesOf(id)[c.base]; price.IsPositive() {
+				rates[currency] = quote(decimal.NewFromInt(1).Div(price))
+			}
+			continue
+		}
+		if metal, ok := metals[currency]; ok {
+			reference := pricesOf(referenceCoin)
+			// coin costs reference[base] units of server currency or reference[metal] ounces of metal
+			if base := reference[c.base]; base.IsPositive() && reference[metal].IsPositive() {
+				rates[currency] = quote(reference[metal].Div(base))
+			}
+		}
+	}
+	if len(rates) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	return rates, nil
+}
+
+func (c *RatesClient) get(ctx context.Context, path string, result interface{}) error {
+	logger.Debug("outgoing http request", zap.String("provider", ProviderName), zap.String("path", path))
+	body, err := c.client.Get(ctx, fmt.Sprintf("%s/%s", c.baseURL, path))
+	if err != nil {
+		return errors.Wrap(err, "cannot make request to coingecko")
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return errors.Wrap(err, "cannot decode coingecko response")
+	}
+	return nil
+}
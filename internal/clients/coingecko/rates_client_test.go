@@ -0,0 +1,70 @@
+package coingecko
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/httpclient"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *RatesClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := NewRatesClient()
+	c.baseURL = server.URL
+	c.client = httpclient.New(ProviderName, httpclient.Config{Timeout: time.Second})
+	return c
+}
+
+func TestRatesClient_GetLiveCurrency(t *testing.T) {
+	var ids, vs string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/simple/price", r.URL.Path)
+		ids, vs = r.URL.Query().Get("ids"), r.URL.Query().Get("vs_currencies")
+		_, _ = w.Write([]byte(`{"bitcoin":{"rub":1600000,"xau":8},"tether":{"rub":80}}`))
+	})
+
+	rates, err := c.GetLiveCurrency(context.Background(), []string{"BTC", "USDT", "XAU"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bitcoin,tether", ids)
+	assert.Equal(t, "rub,xau", vs)
+	assert.True(t, decimal.RequireFromString("0.000000625").Equal(rates["BTC"].Multiplier), rates["BTC"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.0125").Equal(rates["USDT"].Multiplier), rates["USDT"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.000005").Equal(rates["XAU"].Multiplier), rates["XAU"].Multiplier.String())
+}
+
+func TestRatesClient_GetHistoricalCurrency(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "18-10-2022", r.URL.Query().Get("date"))
+		_, _ = w.Write([]byte(`{"market_data":{"current_price":{"rub":1250000,"xau":7.5}}}`))
+	})
+
+	rates, err := c.GetHistoricalCurrency(context.Background(), time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC),
+		[]string{"BTC", "XAU"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/coins/bitcoin/history"}, paths)
+	assert.True(t, decimal.RequireFromString("0.0000008").Equal(rates["BTC"].Multiplier), rates["BTC"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.000006").Equal(rates["XAU"].Multiplier), rates["XAU"].Multiplier.String())
+}
+
+func TestRatesClient_NotQuoted(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, err := c.GetLiveCurrency(context.Background(), []string{"BTC"})
+	assert.ErrorIs(t, err, constants.EmptyRatesErr)
+
+	_, err = c.GetLiveCurrency(context.Background(), []string{"USD"})
+	assert.ErrorIs(t, err, constants.EmptyRatesErr)
+}
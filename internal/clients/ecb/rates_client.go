@@ -0,0 +1,210 @@
+package ecb
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+const ProviderName = "ecb"
+
+const (
+	dailyFeed       = "eurofxref-daily.xml"
+	recentFeed      = "eurofxref-hist-90d.xml"
+	fullHistoryFeed = "eurofxref-hist.xml"
+	recentFeedDays  = 90
+	dateFormat      = "2006-01-02"
+	baseCurrency    = "EUR"
+)
+
+var NotQuotedErr = errors.New("currency is not quoted by ecb")
+
+// EuroRateSource provides price of euro in server currency when ecb does not quote it
+type EuroRateSource interface {
+	GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error)
+	GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error)
+}
+
+// RatesClient loads euro foreign exchange reference rates of the European Central Bank
+// and converts them to cross rates against server currency
+type RatesClient struct {
+	baseURL  string
+	base     string
+	client   *http.Client
+	euroRate EuroRateSource
+
+	historyMu sync.Mutex
+	history   []cubeDay // full history feed, downloaded once and reused while it covers requested days
+}
+
+func NewRatesClient(euroRate EuroRateSource) *RatesClient {
+	return &RatesClient{
+		baseURL:  "https://www.ecb.europa.eu/stats/eurofxref",
+		base:     constants.ServerCurrency,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		euroRate: euroRate,
+	}
+}
+
+func (c *RatesClient) Name() string {
+	return ProviderName
+}
+
+func (c *RatesClient) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	days, err := c.getFeed(ctx, dailyFeed)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	return c.toQuotes(ctx, days[0], currencies, true)
+}
+
+// GetHistoricalCurrency returns rates of the day or of the closest previous working day
+func (c *RatesClient) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	date := day.Format(dateFormat)
+	var (
+		days []cubeDay
+		err  error
+	)
+	if time.Since(day) > recentFeedDays*24*time.Hour {
+		days, err = c.getHistory(ctx, date)
+	} else {
+		days, err = c.getFeed(ctx, recentFeed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range days { // feed is ordered from the latest day
+		if d.Time <= date {
+			return c.toQuotes(ctx, d, currencies, false)
+		}
+	}
+	return nil, constants.EmptyRatesErr
+}
+
+// getHistory returns full history feed, it is downloaded again only when it ends before the date
+func (c *RatesClient) getHistory(ctx context.Context, date string) ([]cubeDay, error) {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+	if len(c.history) > 0 && c.history[0].Time >= date {
+		return c.history, nil
+	}
+	days, err := c.getFeed(ctx, fullHistoryFeed)
+	if err != nil {
+		return nil, err
+	}
+	c.history = days
+	return days, nil
+}
+
+type envelope struct {
+	Days []cubeDay `xml:"Cube>Cube"`
+}
+
+type cubeDay struct {
+	Time  string     `xml:"time,attr"`
+	Rates []cubeRate `xml:"Cube"`
+}
+
+type cubeRate struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
+
+func (c *RatesClient) getFeed(ctx context.Context, feed string) ([]cubeDay, error) {
+	url := fmt.Sprintf("%s/%s", c.baseURL, feed)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create request to ecb")
+	}
+
+	logger.Debug("outgoing http request", zap.String("url", url), zap.String("method", http.MethodGet))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot make request to ecb")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected ecb response status: %d", resp.StatusCode)
+	}
+
+	var result envelope
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "cannot decode ecb response")
+	}
+	return result.Days, nil
+}
+
+// toQuotes converts rates per euro into units of currency per one unit of base currency
+func (c *RatesClient) toQuotes(ctx context.Context, day cubeDay, currencies []string, live bool) (map[string]model.Quote, error) {
+	published, err := time.Parse(dateFormat, day.Time)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse date of ecb rates %q", day.Time)
+	}
+	perEuro := map[string]decimal.Decimal{baseCurrency: decimal.NewFromInt(1)}
+	for _, q := range day.Rates {
+		rate, err := decimal.NewFromString(q.Rate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse rate of %s", q.Currency)
+		}
+		perEuro[q.Currency] = rate
+	}
+
+	crossRate, err := c.crossRate(ctx, perEuro, published, live)
+	if err != nil {
+		return nil, err
+	}
+	rates := make(map[string]model.Quote, len(currencies))
+	for _, currency := range currencies {
+		if rate, ok := perEuro[currency]; ok {
+			rates[currency] = model.Quote{Multiplier: crossRate(rate), Provider: ProviderName, Date: published}
+		}
+	}
+	if len(rates) == 0 {
+		return nil, constants.EmptyRatesErr
+	}
+	return rates, nil
+}
+
+// crossRate converts rate per euro into rate per unit of base currency, ecb has not quoted ruble since March 2022,
+// so price of euro in base currency is taken from euro rate source when it is missing in the feed
+func (c *RatesClient) crossRate(ctx context.Context, perEuro map[string]decimal.Decimal, published time.Time, live bool) (func(decimal.Decimal) decimal.Decimal, error) {
+	if base, ok := perEuro[c.base]; ok && base.IsPositive() {
+		return func(rate decimal.Decimal) decimal.Decimal { return rate.Div(base) }, nil
+	}
+	if c.euroRate == nil {
+		return nil, errors.Wrap(NotQuotedErr, c.base)
+	}
+
+	var (
+		quotes map[string]model.Quote
+		err    error
+	)
+	if live {
+		quotes, err = c.euroRate.GetLiveCurrency(ctx, []string{baseCurrency})
+	} else {
+		quotes, err = c.euroRate.GetHistoricalCurrency(ctx, published, []string{baseCurrency})
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get price of %s in %s", baseCurrency, c.base)
+	}
+	euroPerBase, ok := quotes[baseCurrency]
+	if !ok || !euroPerBase.Multiplier.IsPositive() {
+		return nil, errors.Wrap(NotQuotedErr, c.base)
+	}
+	return func(rate decimal.Decimal) decimal.Decimal { return rate.Mul(euroPerBase.Multiplier) }, nil
+}
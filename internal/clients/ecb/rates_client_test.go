@@ -0,0 +1,112 @@
+package ecb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	ecbMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/ecb"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// historyXML follows the real feed, which has no ruble since March 2022
+const historyXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2022-10-18">
+			<Cube currency="USD" rate="0.9835"/>
+			<Cube currency="JPY" rate="146.63"/>
+			<Cube currency="CNY" rate="7.0963"/>
+		</Cube>
+		<Cube time="2022-10-14">
+			<Cube currency="USD" rate="0.9739"/>
+			<Cube currency="JPY" rate="144.62"/>
+			<Cube currency="CNY" rate="7.0154"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+var currencies = []string{"USD", "EUR", "CNY"}
+
+func newTestClient(t *testing.T, body string) (*RatesClient, *ecbMocks.MockEuroRateSource, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	euroRate := ecbMocks.NewMockEuroRateSource(gomock.NewController(t))
+	c := NewRatesClient(euroRate)
+	c.baseURL = server.URL
+	return c, euroRate, &requests
+}
+
+func euroQuote(multiplier string, date time.Time) map[string]model.Quote {
+	return map[string]model.Quote{"EUR": {Multiplier: decimal.RequireFromString(multiplier), Provider: "cbr", Date: date}}
+}
+
+func TestRatesClient_GetHistoricalCurrency_ClosestPreviousDay(t *testing.T) {
+	c, euroRate, _ := newTestClient(t, historyXML)
+	published := time.Date(2022, 10, 14, 0, 0, 0, 0, time.UTC)
+	euroRate.EXPECT().GetHistoricalCurrency(gomock.Any(), published, []string{"EUR"}).Return(euroQuote("0.016", published), nil)
+
+	rates, err := c.GetHistoricalCurrency(context.Background(), time.Date(2022, 10, 16, 0, 0, 0, 0, time.UTC), currencies)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("0.0155824").Equal(rates["USD"].Multiplier), rates["USD"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.016").Equal(rates["EUR"].Multiplier), rates["EUR"].Multiplier.String())
+	assert.Equal(t, ProviderName, rates["USD"].Provider)
+	assert.Equal(t, published, rates["USD"].Date)
+}
+
+func TestRatesClient_GetHistoricalCurrency_DownloadsFullHistoryOnce(t *testing.T) {
+	c, euroRate, requests := newTestClient(t, historyXML)
+	euroRate.EXPECT().GetHistoricalCurrency(gomock.Any(), gomock.Any(), []string{"EUR"}).Return(euroQuote("0.016", time.Time{}), nil).Times(2)
+
+	_, err := c.GetHistoricalCurrency(context.Background(), time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC), currencies)
+	assert.NoError(t, err)
+	_, err = c.GetHistoricalCurrency(context.Background(), time.Date(2022, 10, 14, 0, 0, 0, 0, time.UTC), currencies)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRatesClient_GetLiveCurrency_CrossRates(t *testing.T) {
+	c, euroRate, _ := newTestClient(t, historyXML)
+	euroRate.EXPECT().GetLiveCurrency(gomock.Any(), []string{"EUR"}).Return(euroQuote("0.0165", time.Time{}), nil)
+
+	rates, err := c.GetLiveCurrency(context.Background(), currencies)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("0.01622775").Equal(rates["USD"].Multiplier), rates["USD"].Multiplier.String())
+	assert.True(t, decimal.RequireFromString("0.11708895").Equal(rates["CNY"].Multiplier), rates["CNY"].Multiplier.String())
+}
+
+func TestRatesClient_GetLiveCurrency_QuotedBaseCurrency(t *testing.T) {
+	c, _, _ := newTestClient(t, historyXML)
+	c.base = "USD"
+
+	rates, err := c.GetLiveCurrency(context.Background(), currencies)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(1).Equal(rates["USD"].Multiplier), rates["USD"].Multiplier.String())
+}
+
+func TestRatesClient_BaseCurrencyIsNotQuoted(t *testing.T) {
+	c, euroRate, _ := newTestClient(t, historyXML)
+	euroRate.EXPECT().GetLiveCurrency(gomock.Any(), []string{"EUR"}).Return(map[string]model.Quote{}, nil)
+
+	_, err := c.GetLiveCurrency(context.Background(), currencies)
+
+	assert.ErrorIs(t, err, NotQuotedErr)
+}
@@ -0,0 +1,52 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	b.Failure()
+	b.Success()
+	b.Failure()
+	b.Failure()
+	assert.NoError(t, b.Allow(), "success resets consecutive failures")
+
+	b.Failure()
+	assert.Equal(t, open, b.State())
+	assert.ErrorIs(t, b.Allow(), CircuitOpenErr)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, halfOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), CircuitOpenErr, "only one probe is allowed")
+
+	b.Failure()
+	assert.Equal(t, open, b.State())
+	assert.ErrorIs(t, b.Allow(), CircuitOpenErr)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Success()
+	assert.Equal(t, closed, b.State())
+}
+
+func TestCircuitBreaker_AbortReleasesProbe(t *testing.T) {
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Abort()
+
+	assert.NoError(t, b.Allow())
+}
@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// maxErrorBody limits response body kept in StatusError
+const maxErrorBody = 512
+
+type Config struct {
+	Timeout           time.Duration
+	Retries           int
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+	RequestsPerSecond float64
+	Burst             int
+	FailureThreshold  int
+	Cooldown          time.Duration
+}
+
+// Client makes GET requests with rate limiting, retries with exponential backoff and jitter
+// and circuit breaker, outcome of every attempt is counted in metrics by client name
+type Client struct {
+	name    string
+	http    *http.Client
+	config  Config
+	limiter *tokenBucket
+	breaker *circuitBreaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func New(name string, config Config) *Client {
+	return &Client{
+		name: name,
+		http: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				MaxIdleConns:    10,
+				IdleConnTimeout: 90 * time.Second,
+			},
+		},
+		config:  config,
+		limiter: newTokenBucket(config.RequestsPerSecond, config.Burst),
+		breaker: newCircuitBreaker(config.FailureThreshold, config.Cooldown),
+		sleep:   sleep,
+	}
+}
+
+// Get returns body of 2xx response, other statuses are returned as *StatusError
+func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt <= c.config.Retries; attempt++ {
+		var body []byte
+		var retryAfter time.Duration
+		body, retryAfter, err = c.attempt(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable(ctx, err) || attempt == c.config.Retries {
+			break
+		}
+		wait := c.backoff(attempt)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		logger.Warn("http request failed, retrying",
+			zap.String("client", c.name),
+			zap.Int("attempt", attempt),
+			zap.Duration("wait", wait),
+			zap.Error(err))
+		if sleepErr := c.sleep(ctx, wait); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+	return nil, err
+}
+
+func (c *Client) attempt(ctx context.Context, url string) (body []byte, retryAfter time.Duration, err error) {
+	if err = c.breaker.Allow(); err != nil {
+		metrics.OutgoingRequestsCounter.WithLabelValues(c.name, outcome(ctx, err)).Inc()
+		return nil, 0, err
+	}
+	defer func() {
+		switch {
+		case ctx.Err() != nil:
+			c.breaker.Abort()
+		case retryable(ctx, err): // service is down, overloaded or does not respond in time
+			c.breaker.Failure()
+		default:
+			c.breaker.Success()
+		}
+		metrics.CircuitBreakerStateGauge.WithLabelValues(c.name).Set(float64(c.breaker.State()))
+	}()
+	if err = c.limiter.Wait(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "cannot create request")
+	}
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	defer func() {
+		metrics.OutgoingRequestsCounter.WithLabelValues(c.name, outcome(ctx, err)).Inc()
+		metrics.OutgoingRequestsHistogramResponseTime.WithLabelValues(c.name).Observe(time.Since(start).Seconds())
+	}()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "cannot read response")
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody]
+		}
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")), &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return body, 0, nil
+}
+
+// backoff is exponential with full jitter, so clients which failed together do not retry together
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.config.BaseBackoff << attempt
+	if ceiling <= 0 || (c.config.MaxBackoff > 0 && ceiling > c.config.MaxBackoff) {
+		ceiling = c.config.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) // nolint:gosec
+}
+
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
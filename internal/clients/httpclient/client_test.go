@@ -0,0 +1,177 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, config Config, handler http.HandlerFunc) (*Client, string, *[]time.Duration) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := New("test", config)
+	waits := &[]time.Duration{}
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return c, server.URL, waits
+}
+
+func TestClient_Get_RetriesServerErrors(t *testing.T) {
+	var calls int32
+	c, url, waits := newTestClient(t, Config{Retries: 3, BaseBackoff: time.Second, MaxBackoff: 2 * time.Second},
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		})
+
+	body, err := c.Get(context.Background(), url)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, string(body))
+	assert.EqualValues(t, 3, calls)
+	assert.Len(t, *waits, 2)
+	assert.Less(t, (*waits)[0], time.Second)
+	assert.Less(t, (*waits)[1], 2*time.Second)
+}
+
+func TestClient_Get_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	c, url, _ := newTestClient(t, Config{Retries: 3}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid api key"}`))
+	})
+
+	_, err := c.Get(context.Background(), url)
+
+	assert.True(t, errors.Is(err, UnauthorizedErr))
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	assert.Equal(t, `{"error":"invalid api key"}`, statusErr.Body)
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestClient_Get_HonoursRetryAfter(t *testing.T) {
+	var calls int32
+	c, url, waits := newTestClient(t, Config{Retries: 1, BaseBackoff: time.Millisecond},
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "3")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+	_, err := c.Get(context.Background(), url)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{3 * time.Second}, *waits)
+}
+
+func TestClient_Get_ReturnsLastErrorWhenRetriesExhausted(t *testing.T) {
+	var calls int32
+	c, url, _ := newTestClient(t, Config{Retries: 2}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.Get(context.Background(), url)
+
+	assert.True(t, errors.Is(err, ServerErr))
+	assert.EqualValues(t, 3, calls)
+}
+
+func TestClient_Get_CircuitBreakerStopsRequests(t *testing.T) {
+	var calls int32
+	c, url, _ := newTestClient(t, Config{FailureThreshold: 2, Cooldown: time.Minute},
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		})
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	c.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Get(ctx, url)
+		assert.True(t, errors.Is(err, ServerErr))
+	}
+	_, err := c.Get(ctx, url)
+	assert.True(t, errors.Is(err, CircuitOpenErr))
+	assert.EqualValues(t, 2, calls)
+
+	now = now.Add(time.Minute)
+	_, err = c.Get(ctx, url)
+	assert.NoError(t, err)
+	assert.Equal(t, closed, c.breaker.State())
+}
+
+func TestClient_Get_RespectsContext(t *testing.T) {
+	c, url, _ := newTestClient(t, Config{Retries: 3}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Get(ctx, url)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, closed, c.breaker.State())
+}
+
+func TestClient_Get_RetriesStalledServer(t *testing.T) {
+	var calls int32
+	c, url, waits := newTestClient(t, Config{Timeout: 20 * time.Millisecond, Retries: 1, FailureThreshold: 2, Cooldown: time.Minute},
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		})
+
+	_, err := c.Get(context.Background(), url)
+
+	assert.Error(t, err)
+	assert.Equal(t, "timeout", outcome(context.Background(), err))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	assert.Len(t, *waits, 1)
+	assert.Equal(t, open, c.breaker.State())
+}
+
+func TestClient_Get_CallerDeadlineIsNotFailure(t *testing.T) {
+	var calls int32
+	c, url, _ := newTestClient(t, Config{Retries: 3, FailureThreshold: 1, Cooldown: time.Minute},
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, url)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, "canceled", outcome(ctx, err))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	assert.Equal(t, closed, c.breaker.State())
+}
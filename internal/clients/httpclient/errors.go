@@ -0,0 +1,79 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	UnauthorizedErr = errors.New("unauthorized")
+	RateLimitedErr  = errors.New("rate limited")
+	BadRequestErr   = errors.New("bad request")
+	ServerErr       = errors.New("server error")
+	CircuitOpenErr  = errors.New("circuit breaker is open")
+)
+
+// StatusError is returned for non 2xx responses, errors.Is matches it with the error of its status class
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return UnauthorizedErr
+	case e.StatusCode == http.StatusTooManyRequests:
+		return RateLimitedErr
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ServerErr
+	default:
+		return BadRequestErr
+	}
+}
+
+// retryable reports whether the same request may succeed later, client and network timeouts are retried
+// and only cancellation of caller context stops retries
+func retryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return errors.Is(err, RateLimitedErr) || errors.Is(err, ServerErr)
+	}
+	return !errors.Is(err, CircuitOpenErr) // network errors are retried
+}
+
+// outcome is metric label of request result
+func outcome(ctx context.Context, err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return "ok"
+	case ctx.Err() != nil:
+		return "canceled"
+	case errors.As(err, &netErr) && netErr.Timeout(), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, CircuitOpenErr):
+		return "circuit_open"
+	case errors.Is(err, UnauthorizedErr):
+		return "unauthorized"
+	case errors.Is(err, RateLimitedErr):
+		return "rate_limited"
+	case errors.Is(err, BadRequestErr):
+		return "bad_request"
+	case errors.Is(err, ServerErr):
+		return "server_error"
+	default:
+		return "network_error"
+	}
+}
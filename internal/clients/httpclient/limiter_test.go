@@ -0,0 +1,36 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket_reserve(t *testing.T) {
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	b := newTokenBucket(2, 2)
+	b.now = func() time.Time { return now }
+
+	assert.Zero(t, b.reserve())
+	assert.Zero(t, b.reserve())
+	assert.Equal(t, 500*time.Millisecond, b.reserve())
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, b.reserve())
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Zero(t, b.reserve())
+
+	now = now.Add(time.Hour)
+	assert.Zero(t, b.reserve())
+	assert.Zero(t, b.reserve())
+	assert.NotZero(t, b.reserve(), "burst is not exceeded after long pause")
+}
+
+func TestTokenBucket_Disabled(t *testing.T) {
+	b := newTokenBucket(0, 1)
+	for i := 0; i < 10; i++ {
+		assert.Zero(t, b.reserve())
+	}
+}
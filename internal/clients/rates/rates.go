@@ -0,0 +1,28 @@
+package rates
+
+import (
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/abstract"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/cbr"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/clients/ecb"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/config"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/service"
+)
+
+// Providers builds rate providers in priority order from config
+func Providers(cfg *config.Service) ([]service.RateProvider, error) {
+	available := map[string]func() service.RateProvider{
+		abstract.ProviderName: func() service.RateProvider { return abstract.NewCurrencyClient(cfg) },
+		cbr.ProviderName:      func() service.RateProvider { return cbr.NewRatesClient() },
+		ecb.ProviderName:      func() service.RateProvider { return ecb.NewRatesClient(cbr.NewRatesClient()) },
+	}
+	providers := make([]service.RateProvider, 0, len(cfg.RateProviders()))
+	for _, name := range cfg.RateProviders() {
+		newProvider, ok := available[name]
+		if !ok {
+			return nil, errors.Errorf("unknown rate provider '%s'", name)
+		}
+		providers = append(providers, newProvider())
+	}
+	return providers, nil
+}
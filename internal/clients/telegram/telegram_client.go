@@ -2,40 +2,70 @@ package telegram
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/bot"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/config"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/workerpool"
 	"go.uber.org/zap"
 )
 
 type Client struct {
-	client *tgbotapi.BotAPI
+	client        *tgbotapi.BotAPI
+	webhookURL    *url.URL
+	webhookSecret string
+	updates       *workerpool.Pool
+	drainTimeout  time.Duration
 }
 
+var _ bot.Messenger = (*Client)(nil)
+
 type TokenGetter interface {
 	Token() string
 }
 
 func New(cfg *config.Service) (*Client, error) {
+	c := &Client{}
+	if cfg.TelegramMode() == WebhookMode {
+		webhookURL, err := parseWebhook(cfg.TelegramWebhookURL(), cfg.TelegramWebhookSecret())
+		if err != nil {
+			return nil, err
+		}
+		c.webhookURL, c.webhookSecret = webhookURL, cfg.TelegramWebhookSecret()
+	} else if cfg.TelegramMode() != PollingMode {
+		return nil, errors.Errorf("unknown telegram mode '%s'", cfg.TelegramMode())
+	}
+
 	client, err := tgbotapi.NewBotAPI(cfg.Token())
 	if err != nil {
 		return nil, errors.Wrap(err, "NewBotAPI")
 	}
 
-	if _, err = client.Request(initialCommands); err != nil {
+	if _, err = client.Request(initialCommands(i18n.DefaultLang, "")); err != nil {
 		return nil, errors.Wrap(err, "cannot set methods")
 	}
+	for _, lang := range i18n.Supported {
+		if _, err = client.Request(initialCommands(lang, string(lang))); err != nil {
+			return nil, errors.Wrapf(err, "cannot set methods for language '%s'", lang)
+		}
+	}
 
-	return &Client{
-		client: client,
-	}, nil
+	c.client = client
+	c.updates = workerpool.New("telegram_updates", cfg.TelegramWorkers(), cfg.TelegramQueueSize())
+	c.drainTimeout = cfg.TelegramDrainTimeout()
+	return c, nil
 }
 
 func (c *Client) SendMessage(text string, userID int64) error {
@@ -86,53 +116,241 @@ func (c *Client) SendEditMessage(text string, userID int64, messageID int) error
 	return nil
 }
 
-func (c *Client) ListenUpdates(ctx context.Context, msgModel *messages.Model, callbackModel *callbacks.Model) {
+// SendAttachment sends file attached by user earlier, it is referenced by id without uploading it again
+func (c *Client) SendAttachment(attachment model.Attachment, userID int64) error {
+	file := tgbotapi.FileID(attachment.FileID)
+	var msg tgbotapi.Chattable
+	switch attachment.Kind {
+	case model.PhotoAttachment:
+		msg = tgbotapi.NewPhoto(userID, file)
+	case model.VoiceAttachment:
+		msg = tgbotapi.NewVoice(userID, file)
+	default:
+		msg = tgbotapi.NewDocument(userID, file)
+	}
+	if _, err := c.client.Send(msg); err != nil {
+		return errors.Wrap(err, "cannot execute SendAttachment")
+	}
+	return nil
+}
+
+// maxDownloadSize is the largest file downloaded from Telegram, larger ones are not attached to messages by users
+const maxDownloadSize = 20 << 20
+
+// DownloadFile downloads file attached to message, errors never include link because it contains token of bot
+func (c *Client) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	file, err := c.client.GetFile(tgbotapi.FileConfig{FileID: fileID})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get file")
+	}
+	if file.FileSize > maxDownloadSize {
+		return nil, errors.Errorf("file of %d bytes is too large", file.FileSize)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.Link(c.client.Token), nil)
+	if err != nil {
+		return nil, errors.New("cannot make file request")
+	}
+	resp, err := c.client.Client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, errors.Wrap(err, "cannot download file")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("cannot download file: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read file")
+	}
+	if len(data) > maxDownloadSize {
+		return nil, errors.New("file is too large")
+	}
+	return data, nil
+}
+
+// UpdateHandler is bot logic which updates received from Telegram are passed to
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, update bot.Update) error
+}
+
+// ListenUpdates requests updates from Telegram by long polling until ctx is done,
+// then waits for received updates to be processed
+func (c *Client) ListenUpdates(ctx context.Context, handler UpdateHandler) {
+	// updates cannot be polled while webhook left by previous run is set
+	if err := c.deleteWebhook(); err != nil {
+		logger.Warn("cannot delete webhook before polling", zap.Error(err))
+	}
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := c.client.GetUpdatesChan(u)
 	logger.Info("start listening telegram server for new messages")
 
-	for update := range updates {
-		if update.CallbackQuery != nil {
-			err := callbackModel.HandleIncomingCallback(ctx, update.CallbackQuery)
-			if err != nil {
-				logger.Error("error occurred while processing callback", zap.Error(err))
-				continue
+	defer c.updates.Close(c.drainTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			c.client.StopReceivingUpdates()
+			c.drain(updates, handler)
+			return
+		case update := <-updates:
+			if err := c.dispatch(ctx, update, handler); err != nil {
+				logger.Warn("update is dropped", zap.Int("updateID", update.UpdateID), zap.Error(err))
 			}
 		}
-		if update.Message != nil {
-			err := msgModel.IncomingMessage(ctx, messages.Message{
-				Text:   update.Message.Text,
-				UserID: update.Message.From.ID,
-			})
-			if err != nil {
-				logger.Error("error occurred while processing message", zap.Error(err))
-				continue
+	}
+}
+
+// drain queues updates left in buffer of poller, Telegram confirmed them with the last poll and will not resend them,
+// updates of poll interrupted by shutdown are not confirmed and are received again by the next run
+func (c *Client) drain(updates tgbotapi.UpdatesChannel, handler UpdateHandler) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.drainTimeout)
+	defer cancel()
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := c.dispatch(ctx, update, handler); err != nil {
+				logger.Warn("update is dropped on shutdown", zap.Int("updateID", update.UpdateID), zap.Error(err))
 			}
+		default:
+			return
 		}
 	}
 }
 
-var initialCommands = tgbotapi.NewSetMyCommands(
-	tgbotapi.BotCommand{
-		Command:     constants.AddOperation,
-		Description: "добавить новую трату",
-	},
-	tgbotapi.BotCommand{
-		Command:     constants.ShowCategoryList,
-		Description: "показать список категорий",
-	},
-	tgbotapi.BotCommand{
-		Command:     constants.SetLimitation,
-		Description: "установить лимит трат (месяц)",
-	},
-	tgbotapi.BotCommand{
-		Command:     constants.ChangeCurrency,
-		Description: "сменить валюту",
-	},
-	tgbotapi.BotCommand{
-		Command:     constants.ShowReport,
-		Description: "показать отчет о тратах за период",
-	},
-)
+// dispatch queues update to worker of its user, so updates of one user are processed in order
+func (c *Client) dispatch(ctx context.Context, update tgbotapi.Update, handler UpdateHandler) error {
+	converted, ok := toUpdate(update)
+	if !ok {
+		return nil
+	}
+	return c.updates.Submit(ctx, converted.UserID(), func(ctx context.Context) {
+		if err := handler.HandleUpdate(ctx, converted); err != nil {
+			logger.Error("cannot handle update", zap.Int("updateID", update.UpdateID), zap.Error(err))
+		}
+	})
+}
+
+// toUpdate converts Telegram update to transport-neutral one, updates other than
+// messages of users with their photos, documents and voice messages and presses of buttons are not supported
+func toUpdate(update tgbotapi.Update) (bot.Update, bool) {
+	if query := update.CallbackQuery; query != nil && query.From != nil && query.Message != nil {
+		return bot.Update{Callback: &callbacks.Callback{
+			UserID:       query.From.ID,
+			MessageID:    query.Message.MessageID,
+			LanguageCode: query.From.LanguageCode,
+			Data:         query.Data,
+		}}, true
+	}
+	if msg := update.Message; msg != nil && msg.From != nil {
+		converted := &messages.Message{
+			Text:         msg.Text,
+			UserID:       msg.From.ID,
+			LanguageCode: msg.From.LanguageCode,
+			Attachment:   toAttachment(msg),
+		}
+		if converted.Attachment != nil {
+			converted.Text = msg.Caption
+		}
+		return bot.Update{Message: converted}, true
+	}
+	return bot.Update{}, false
+}
+
+// toAttachment takes photo, document or voice message of message, the largest size of photo is taken
+func toAttachment(msg *tgbotapi.Message) *model.Attachment {
+	switch {
+	case msg.Voice != nil:
+		return &model.Attachment{
+			Kind:     model.VoiceAttachment,
+			FileID:   msg.Voice.FileID,
+			MimeType: msg.Voice.MimeType,
+			Size:     int64(msg.Voice.FileSize),
+		}
+	case len(msg.Photo) > 0:
+		photo := msg.Photo[len(msg.Photo)-1]
+		return &model.Attachment{
+			Kind:     model.PhotoAttachment,
+			FileID:   photo.FileID,
+			MimeType: "image/jpeg",
+			Size:     int64(photo.FileSize),
+		}
+	case msg.Document != nil:
+		return &model.Attachment{
+			Kind:     model.DocumentAttachment,
+			FileID:   msg.Document.FileID,
+			FileName: msg.Document.FileName,
+			MimeType: msg.Document.MimeType,
+			Size:     int64(msg.Document.FileSize),
+		}
+	}
+	return nil
+}
+
+func initialCommands(lang i18n.Lang, languageCode string) tgbotapi.SetMyCommandsConfig {
+	return tgbotapi.SetMyCommandsConfig{
+		LanguageCode: languageCode,
+		Commands: []tgbotapi.BotCommand{
+			{
+				Command:     constants.AddOperation,
+				Description: i18n.T(lang, i18n.AddOperationCommand),
+			},
+			{
+				Command:     constants.Receipt,
+				Description: i18n.T(lang, i18n.ReceiptCommand),
+			},
+			{
+				Command:     constants.History,
+				Description: i18n.T(lang, i18n.HistoryCommand),
+			},
+			{
+				Command:     constants.ShowCategoryList,
+				Description: i18n.T(lang, i18n.ShowCategoryListCommand),
+			},
+			{
+				Command:     constants.SetLimitation,
+				Description: i18n.T(lang, i18n.SetLimitationCommand),
+			},
+			{
+				Command:     constants.ChangeCurrency,
+				Description: i18n.T(lang, i18n.ChangeCurrencyCommand),
+			},
+			{
+				Command:     constants.ShowReport,
+				Description: i18n.T(lang, i18n.ShowReportCommand),
+			},
+			{
+				Command:     constants.ChangeLanguage,
+				Description: i18n.T(lang, i18n.ChangeLanguageCommand),
+			},
+			{
+				Command:     constants.Convert,
+				Description: i18n.T(lang, i18n.ConvertCommand),
+			},
+			{
+				Command:     constants.Rates,
+				Description: i18n.T(lang, i18n.RatesCommand),
+			},
+			{
+				Command:     constants.Alerts,
+				Description: i18n.T(lang, i18n.AlertsCommand),
+			},
+			{
+				Command:     constants.Token,
+				Description: i18n.T(lang, i18n.TokenCommand),
+			},
+			{
+				Command:     constants.Webhooks,
+				Description: i18n.T(lang, i18n.WebhooksCommand),
+			},
+		},
+	}
+}
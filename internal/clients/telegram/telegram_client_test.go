@@ -0,0 +1,114 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/bot"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/workerpool"
+)
+
+func TestToUpdate(t *testing.T) {
+	user := &tgbotapi.User{ID: 123, LanguageCode: "ru"}
+	tests := []struct {
+		name   string
+		update tgbotapi.Update
+		want   bot.Update
+		wantOk bool
+	}{
+		{
+			name:   "message",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{Text: "/start", From: user}},
+			want:   bot.Update{Message: &messages.Message{Text: "/start", UserID: 123, LanguageCode: "ru"}},
+			wantOk: true,
+		},
+		{
+			name: "photo with caption",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{From: user, Caption: "/receipt", Photo: []tgbotapi.PhotoSize{
+				{FileID: "small", Width: 90, Height: 90, FileSize: 1000},
+				{FileID: "large", Width: 1280, Height: 1280, FileSize: 90000},
+			}}},
+			want: bot.Update{Message: &messages.Message{Text: "/receipt", UserID: 123, LanguageCode: "ru",
+				Attachment: &model.Attachment{Kind: model.PhotoAttachment, FileID: "large", MimeType: "image/jpeg", Size: 90000},
+			}},
+			wantOk: true,
+		},
+		{
+			name: "document",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{From: user, Document: &tgbotapi.Document{
+				FileID: "doc", FileName: "receipt.png", MimeType: "image/png", FileSize: 2048,
+			}}},
+			want: bot.Update{Message: &messages.Message{UserID: 123, LanguageCode: "ru", Attachment: &model.Attachment{
+				Kind: model.DocumentAttachment, FileID: "doc", FileName: "receipt.png", MimeType: "image/png", Size: 2048,
+			}}},
+			wantOk: true,
+		},
+		{
+			name: "voice",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{From: user, Voice: &tgbotapi.Voice{
+				FileID: "voice", MimeType: "audio/ogg", FileSize: 4096,
+			}}},
+			want: bot.Update{Message: &messages.Message{UserID: 123, LanguageCode: "ru", Attachment: &model.Attachment{
+				Kind: model.VoiceAttachment, FileID: "voice", MimeType: "audio/ogg", Size: 4096,
+			}}},
+			wantOk: true,
+		},
+		{
+			name: "callback",
+			update: tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+				From: user, Message: &tgbotapi.Message{MessageID: 7}, Data: "report:week",
+			}},
+			want:   bot.Update{Callback: &callbacks.Callback{UserID: 123, MessageID: 7, LanguageCode: "ru", Data: "report:week"}},
+			wantOk: true,
+		},
+		{
+			name:   "channel post without author",
+			update: tgbotapi.Update{Message: &tgbotapi.Message{Text: "news"}},
+		},
+		{
+			name:   "callback of inline message",
+			update: tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{From: user, Data: "report:week"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := toUpdate(tt.update)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type recordingHandler struct {
+	mu      sync.Mutex
+	handled []bot.Update
+}
+
+func (h *recordingHandler) HandleUpdate(_ context.Context, update bot.Update) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, update)
+	return nil
+}
+
+func TestDrain_QueuesBufferedUpdatesBeforePoolIsClosed(t *testing.T) {
+	c := &Client{updates: workerpool.New("test", 2, 10), drainTimeout: time.Second}
+	updates := make(chan tgbotapi.Update, 3)
+	for i := 1; i <= 3; i++ {
+		updates <- tgbotapi.Update{UpdateID: i, Message: &tgbotapi.Message{Text: "/start", From: &tgbotapi.User{ID: int64(i)}}}
+	}
+	handler := &recordingHandler{}
+
+	c.drain(updates, handler)
+	c.updates.Close(time.Second)
+
+	assert.Len(t, handler.handled, 3)
+	assert.Empty(t, updates)
+}
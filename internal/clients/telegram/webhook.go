@@ -0,0 +1,116 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	PollingMode = "polling"
+	WebhookMode = "webhook"
+)
+
+const (
+	// secretTokenHeader carries secret token passed to setWebhook in every request sent by Telegram
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+	maxUpdateSize     = 1 << 20
+	allowedUpdates    = `["message","callback_query"]`
+)
+
+// secretTokenPattern is set of secret tokens accepted by Telegram
+var secretTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+func parseWebhook(rawURL string, secret string) (*url.URL, error) {
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse webhook url")
+	}
+	if webhookURL.Scheme != "https" || webhookURL.Host == "" {
+		return nil, errors.Errorf("webhook url '%s' must be absolute https url", rawURL)
+	}
+	if webhookURL.Path == "" {
+		webhookURL.Path = "/"
+	}
+	if !secretTokenPattern.MatchString(secret) {
+		return nil, errors.New("webhook secret must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
+	}
+	return webhookURL, nil
+}
+
+// WebhookPath is path of webhook url which WebhookHandler has to be served on
+func (c *Client) WebhookPath() string {
+	return c.webhookURL.Path
+}
+
+// WebhookHandler accepts updates sent by Telegram and dispatches them same way as polled ones
+func (c *Client) WebhookHandler(handler UpdateHandler) http.Handler {
+	return newWebhookHandler(c.webhookSecret, func(r *http.Request, update tgbotapi.Update) error {
+		return c.dispatch(r.Context(), update, handler)
+	})
+}
+
+// ServeWebhook sets webhook and deletes it when ctx is done, updates are received by WebhookHandler meanwhile.
+// Received updates are processed before it returns
+func (c *Client) ServeWebhook(ctx context.Context) error {
+	if err := c.setWebhook(); err != nil {
+		return err
+	}
+	logger.Info("start receiving telegram updates by webhook", zap.String("path", c.webhookURL.Path))
+
+	<-ctx.Done()
+	err := c.deleteWebhook()
+	c.updates.Close(c.drainTimeout)
+	return err
+}
+
+func (c *Client) setWebhook() error {
+	// secret_token is not supported by WebhookConfig of tgbotapi
+	_, err := c.client.MakeRequest("setWebhook", tgbotapi.Params{
+		"url":             c.webhookURL.String(),
+		"secret_token":    c.webhookSecret,
+		"allowed_updates": allowedUpdates,
+	})
+	return errors.Wrap(err, "cannot set webhook")
+}
+
+func (c *Client) deleteWebhook() error {
+	_, err := c.client.Request(tgbotapi.DeleteWebhookConfig{})
+	return errors.Wrap(err, "cannot delete webhook")
+}
+
+// newWebhookHandler rejects requests without valid secret token, update is acknowledged as soon as it is queued,
+// Telegram resends it later when it cannot be queued
+func newWebhookHandler(secret string, dispatch func(r *http.Request, update tgbotapi.Update) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secret)) != 1 {
+			logger.Warn("webhook request with invalid secret token", zap.String("remoteAddr", r.RemoteAddr))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var update tgbotapi.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+			logger.Warn("cannot decode webhook update", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := dispatch(r, update); err != nil {
+			logger.Warn("cannot queue webhook update", zap.Int("updateID", update.UpdateID), zap.Error(err))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,94 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/workerpool"
+)
+
+const testSecret = "s3cr3t_token-1"
+
+func serveWebhook(method string, secret string, body string) (int, []tgbotapi.Update) {
+	var handled []tgbotapi.Update
+	handler := newWebhookHandler(testSecret, func(_ *http.Request, update tgbotapi.Update) error {
+		handled = append(handled, update)
+		return nil
+	})
+	r := httptest.NewRequest(method, "/telegram/webhook", strings.NewReader(body))
+	if secret != "" {
+		r.Header.Set(secretTokenHeader, secret)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Code, handled
+}
+
+func TestWebhookHandler_DispatchesUpdate(t *testing.T) {
+	code, handled := serveWebhook(http.MethodPost, testSecret,
+		`{"update_id":10,"message":{"message_id":1,"text":"/start","from":{"id":123,"language_code":"en"}}}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, handled, 1)
+	assert.Equal(t, 10, handled[0].UpdateID)
+	assert.Equal(t, "/start", handled[0].Message.Text)
+	assert.EqualValues(t, 123, handled[0].Message.From.ID)
+}
+
+func TestWebhookHandler_RejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		secret string
+		body   string
+		want   int
+	}{
+		{name: "missing secret", method: http.MethodPost, body: `{"update_id":1}`, want: http.StatusUnauthorized},
+		{name: "wrong secret", method: http.MethodPost, secret: "other", body: `{"update_id":1}`, want: http.StatusUnauthorized},
+		{name: "not post", method: http.MethodGet, secret: testSecret, want: http.StatusMethodNotAllowed},
+		{name: "malformed update", method: http.MethodPost, secret: testSecret, body: `{"update_id":`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, handled := serveWebhook(tt.method, tt.secret, tt.body)
+			assert.Equal(t, tt.want, code)
+			assert.Empty(t, handled)
+		})
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	u, err := parseWebhook("https://bot.example.com/telegram/webhook", testSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "/telegram/webhook", u.Path)
+
+	u, err = parseWebhook("https://bot.example.com", testSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, "/", u.Path)
+
+	_, err = parseWebhook("http://bot.example.com/hook", testSecret)
+	assert.Error(t, err)
+	_, err = parseWebhook("/hook", testSecret)
+	assert.Error(t, err)
+	_, err = parseWebhook("https://bot.example.com/hook", "")
+	assert.Error(t, err)
+	_, err = parseWebhook("https://bot.example.com/hook", "has spaces")
+	assert.Error(t, err)
+}
+
+func TestWebhookHandler_AsksToResendUpdateWhichCannotBeQueued(t *testing.T) {
+	handler := newWebhookHandler(testSecret, func(_ *http.Request, _ tgbotapi.Update) error {
+		return workerpool.ClosedErr
+	})
+	r := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(`{"update_id":1}`))
+	r.Header.Set(secretTokenHeader, testSecret)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
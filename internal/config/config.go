@@ -9,18 +9,92 @@ import (
 
 const configFile = "data/config.yaml"
 
+// defaultRateProviders keeps single abstract api source when priority is not configured
+var defaultRateProviders = []string{"abstract"}
+
+const (
+	defaultRateBackfillInterval         = time.Minute
+	defaultRateBackfillBatchSize        = 30
+	defaultRateBackfillRequestInterval  = time.Second // abstract api allows one request per second
+	defaultRateBackfillRetries          = 3
+	defaultRateMaxStaleness             = 7 * 24 * time.Hour // covers weekends and long holidays without quotes
+	defaultAbstractAPIRequestsPerSecond = 1                  // free abstract api plan allows one request per second
+	defaultAbstractAPIRetries           = 3
+	defaultAbstractAPIBreakerFailures   = 5
+	defaultAbstractAPIBreakerCooldown   = 30 * time.Second
+	defaultCacheBackend                 = "memcached"
+	defaultCacheLocalSize               = 10000
+	defaultCacheLocalTTL                = time.Minute
+	defaultTelegramMode                 = "polling"
+	defaultTelegramWorkers              = 8
+	defaultTelegramQueueSize            = 100
+	defaultTelegramDrainTimeout         = 30 * time.Second
+	defaultReportQueue                  = "memory"
+	defaultReportQueueSize              = 100
+	defaultReportTopic                  = "report-requests"
+	defaultReportConsumerGroup          = "report-worker"
+	defaultOutboxPollInterval           = time.Second
+	defaultOutboxBatchSize              = 100
+	defaultOutboxRetention              = 7 * 24 * time.Hour
+	defaultOutboxWebhookTimeout         = 10 * time.Second
+	defaultOutboxTopic                  = "domain-events"
+)
+
+// defaultOutboxSinks only log domain events when sinks are not configured
+var defaultOutboxSinks = []string{"log"}
+
+// defaultCurrencies are enabled on start when currency list is not configured
+var defaultCurrencies = []string{"USD", "EUR", "CNY"}
+
 type Config struct {
-	Token                       string        `yaml:"token"`
-	AbstractAPIKey              string        `yaml:"abstract_api_key"`
-	RatesCacheDefaultExpiration time.Duration `yaml:"rates_cache_default_expiration"`
-	CalcCacheDefaultExpiration  time.Duration `yaml:"calc_cache_default_expiration"`
-	RatesCacheCleanupInterval   time.Duration `yaml:"rates_cache_cleanup_interval"`
-	PostgresUser                string        `yaml:"postgres_user"`
-	PostgresPassword            string        `yaml:"postgres_password"`
-	PostgresDB                  string        `yaml:"postgres_db"`
-	PostgresHost                string        `yaml:"postgres_host"`
-	PostgresPort                string        `yaml:"postgres_port"`
-	CacheHost                   string        `yaml:"cache_host"`
+	Token                        string        `yaml:"token"`
+	TelegramMode                 string        `yaml:"telegram_mode"`
+	TelegramWebhookURL           string        `yaml:"telegram_webhook_url"`
+	TelegramWebhookSecret        string        `yaml:"telegram_webhook_secret"`
+	TelegramWorkers              int           `yaml:"telegram_workers"`
+	TelegramQueueSize            int           `yaml:"telegram_queue_size"`
+	TelegramDrainTimeout         time.Duration `yaml:"telegram_drain_timeout"`
+	AbstractAPIKey               string        `yaml:"abstract_api_key"`
+	RatesCacheDefaultExpiration  time.Duration `yaml:"rates_cache_default_expiration"`
+	CalcCacheDefaultExpiration   time.Duration `yaml:"calc_cache_default_expiration"`
+	RatesCacheCleanupInterval    time.Duration `yaml:"rates_cache_cleanup_interval"`
+	DialogStateExpiration        time.Duration `yaml:"dialog_state_expiration"`
+	PostgresUser                 string        `yaml:"postgres_user"`
+	PostgresPassword             string        `yaml:"postgres_password"`
+	PostgresDB                   string        `yaml:"postgres_db"`
+	PostgresHost                 string        `yaml:"postgres_host"`
+	PostgresPort                 string        `yaml:"postgres_port"`
+	CacheHost                    string        `yaml:"cache_host"`
+	CacheBackend                 string        `yaml:"cache_backend"`
+	CacheLocalSize               int           `yaml:"cache_local_size"`
+	CacheLocalTTL                time.Duration `yaml:"cache_local_ttl"`
+	RateProviders                []string      `yaml:"rate_providers"`
+	RateProviderFailures         int           `yaml:"rate_provider_failures"`
+	RateProviderCooldown         time.Duration `yaml:"rate_provider_cooldown"`
+	RateBackfillInterval         time.Duration `yaml:"rate_backfill_interval"`
+	RateBackfillBatchSize        int           `yaml:"rate_backfill_batch_size"`
+	RateBackfillRequestInterval  time.Duration `yaml:"rate_backfill_request_interval"`
+	RateBackfillRetries          int           `yaml:"rate_backfill_retries"`
+	RateMaxStaleness             time.Duration `yaml:"rate_max_staleness"`
+	AbstractAPIRequestsPerSecond float64       `yaml:"abstract_api_requests_per_second"`
+	AbstractAPIRetries           int           `yaml:"abstract_api_retries"`
+	AbstractAPIBreakerFailures   int           `yaml:"abstract_api_breaker_failures"`
+	AbstractAPIBreakerCooldown   time.Duration `yaml:"abstract_api_breaker_cooldown"`
+	Currencies                   []string      `yaml:"currencies"`
+	AdminIDs                     []int64       `yaml:"admin_ids"`
+	ReportQueue                  string        `yaml:"report_queue"`
+	ReportQueueSize              int           `yaml:"report_queue_size"`
+	ReportTopic                  string        `yaml:"report_topic"`
+	ReportConsumerGroup          string        `yaml:"report_consumer_group"`
+	KafkaBrokers                 []string      `yaml:"kafka_brokers"`
+	OutboxSinks                  []string      `yaml:"outbox_sinks"`
+	OutboxPollInterval           time.Duration `yaml:"outbox_poll_interval"`
+	OutboxBatchSize              int           `yaml:"outbox_batch_size"`
+	OutboxRetention              time.Duration `yaml:"outbox_retention"`
+	OutboxWebhookURL             string        `yaml:"outbox_webhook_url"`
+	OutboxWebhookTimeout         time.Duration `yaml:"outbox_webhook_timeout"`
+	OutboxTopic                  string        `yaml:"outbox_topic"`
+	GRPCServiceToken             string        `yaml:"grpc_service_token"`
 }
 
 type Service struct {
@@ -47,6 +121,48 @@ func (s *Service) Token() string {
 	return s.config.Token
 }
 
+// TelegramMode is "polling" to request updates from Telegram or "webhook" to receive them on http server
+func (s *Service) TelegramMode() string {
+	if s.config.TelegramMode == "" {
+		return defaultTelegramMode
+	}
+	return s.config.TelegramMode
+}
+
+// TelegramWebhookURL is public url Telegram sends updates to, its path is served by http server
+func (s *Service) TelegramWebhookURL() string {
+	return s.config.TelegramWebhookURL
+}
+
+// TelegramWebhookSecret is sent by Telegram in every webhook request to prove it is genuine
+func (s *Service) TelegramWebhookSecret() string {
+	return s.config.TelegramWebhookSecret
+}
+
+// TelegramWorkers is number of updates processed concurrently, updates of one user are processed in order
+func (s *Service) TelegramWorkers() int {
+	if s.config.TelegramWorkers <= 0 {
+		return defaultTelegramWorkers
+	}
+	return s.config.TelegramWorkers
+}
+
+// TelegramQueueSize bounds updates waiting for each worker, receiving is paused while queue is full
+func (s *Service) TelegramQueueSize() int {
+	if s.config.TelegramQueueSize <= 0 {
+		return defaultTelegramQueueSize
+	}
+	return s.config.TelegramQueueSize
+}
+
+// TelegramDrainTimeout limits how long received updates are processed after shutdown is requested
+func (s *Service) TelegramDrainTimeout() time.Duration {
+	if s.config.TelegramDrainTimeout <= 0 {
+		return defaultTelegramDrainTimeout
+	}
+	return s.config.TelegramDrainTimeout
+}
+
 func (s *Service) AbstractAPIKey() string {
 	return s.config.AbstractAPIKey
 }
@@ -63,6 +179,10 @@ func (s *Service) RatesCacheCleanupInterval() time.Duration {
 	return s.config.RatesCacheCleanupInterval
 }
 
+func (s *Service) DialogStateExpiration() time.Duration {
+	return s.config.DialogStateExpiration
+}
+
 func (s *Service) PostgresUser() string {
 	return s.config.PostgresUser
 }
@@ -86,3 +206,210 @@ func (s *Service) PostgresPort() string {
 func (s *Service) CacheHost() string {
 	return s.config.CacheHost
 }
+
+// CacheBackend is "memcached" for local cache in front of memcached or "local" to run without memcached
+func (s *Service) CacheBackend() string {
+	if s.config.CacheBackend == "" {
+		return defaultCacheBackend
+	}
+	return s.config.CacheBackend
+}
+
+func (s *Service) CacheLocalSize() int {
+	if s.config.CacheLocalSize <= 0 {
+		return defaultCacheLocalSize
+	}
+	return s.config.CacheLocalSize
+}
+
+// CacheLocalTTL limits how long values read from memcached are kept in process,
+// so changes made by other instances are seen after it passes
+func (s *Service) CacheLocalTTL() time.Duration {
+	if s.config.CacheLocalTTL <= 0 {
+		return defaultCacheLocalTTL
+	}
+	return s.config.CacheLocalTTL
+}
+
+// RateProviders returns names of rate providers in priority order
+func (s *Service) RateProviders() []string {
+	if len(s.config.RateProviders) == 0 {
+		return defaultRateProviders
+	}
+	return s.config.RateProviders
+}
+
+func (s *Service) RateProviderFailures() int {
+	return s.config.RateProviderFailures
+}
+
+func (s *Service) RateProviderCooldown() time.Duration {
+	return s.config.RateProviderCooldown
+}
+
+// RateMaxStaleness limits how old the nearest previous rate may be when the transaction date has no rate
+func (s *Service) RateMaxStaleness() time.Duration {
+	if s.config.RateMaxStaleness <= 0 {
+		return defaultRateMaxStaleness
+	}
+	return s.config.RateMaxStaleness
+}
+
+// Currencies returns ISO 4217 codes which are enabled on start, admins can enable more with bot commands
+func (s *Service) Currencies() []string {
+	if len(s.config.Currencies) == 0 {
+		return defaultCurrencies
+	}
+	return s.config.Currencies
+}
+
+func (s *Service) AdminIDs() []int64 {
+	return s.config.AdminIDs
+}
+
+func (s *Service) RateBackfillInterval() time.Duration {
+	if s.config.RateBackfillInterval <= 0 {
+		return defaultRateBackfillInterval
+	}
+	return s.config.RateBackfillInterval
+}
+
+func (s *Service) RateBackfillBatchSize() int {
+	if s.config.RateBackfillBatchSize <= 0 {
+		return defaultRateBackfillBatchSize
+	}
+	return s.config.RateBackfillBatchSize
+}
+
+func (s *Service) RateBackfillRequestInterval() time.Duration {
+	if s.config.RateBackfillRequestInterval <= 0 {
+		return defaultRateBackfillRequestInterval
+	}
+	return s.config.RateBackfillRequestInterval
+}
+
+func (s *Service) RateBackfillRetries() int {
+	if s.config.RateBackfillRetries <= 0 {
+		return defaultRateBackfillRetries
+	}
+	return s.config.RateBackfillRetries
+}
+
+func (s *Service) AbstractAPIRequestsPerSecond() float64 {
+	if s.config.AbstractAPIRequestsPerSecond <= 0 {
+		return defaultAbstractAPIRequestsPerSecond
+	}
+	return s.config.AbstractAPIRequestsPerSecond
+}
+
+func (s *Service) AbstractAPIRetries() int {
+	if s.config.AbstractAPIRetries <= 0 {
+		return defaultAbstractAPIRetries
+	}
+	return s.config.AbstractAPIRetries
+}
+
+// AbstractAPIBreakerFailures is the number of consecutive failed requests which stops calls to abstract api for cooldown
+func (s *Service) AbstractAPIBreakerFailures() int {
+	if s.config.AbstractAPIBreakerFailures <= 0 {
+		return defaultAbstractAPIBreakerFailures
+	}
+	return s.config.AbstractAPIBreakerFailures
+}
+
+func (s *Service) AbstractAPIBreakerCooldown() time.Duration {
+	if s.config.AbstractAPIBreakerCooldown <= 0 {
+		return defaultAbstractAPIBreakerCooldown
+	}
+	return s.config.AbstractAPIBreakerCooldown
+}
+
+// ReportQueue is "memory" to calculate reports in bot process or "kafka" to hand them over to report worker
+func (s *Service) ReportQueue() string {
+	if s.config.ReportQueue == "" {
+		return defaultReportQueue
+	}
+	return s.config.ReportQueue
+}
+
+// ReportQueueSize is the number of report requests in memory queue, bot rejects requests when it is full
+func (s *Service) ReportQueueSize() int {
+	if s.config.ReportQueueSize <= 0 {
+		return defaultReportQueueSize
+	}
+	return s.config.ReportQueueSize
+}
+
+func (s *Service) ReportTopic() string {
+	if s.config.ReportTopic == "" {
+		return defaultReportTopic
+	}
+	return s.config.ReportTopic
+}
+
+// ReportConsumerGroup is Kafka consumer group which instances of report worker share partitions in
+func (s *Service) ReportConsumerGroup() string {
+	if s.config.ReportConsumerGroup == "" {
+		return defaultReportConsumerGroup
+	}
+	return s.config.ReportConsumerGroup
+}
+
+func (s *Service) KafkaBrokers() []string {
+	return s.config.KafkaBrokers
+}
+
+// OutboxSinks are names of sinks domain events are delivered to: log, webhook or queue
+func (s *Service) OutboxSinks() []string {
+	if len(s.config.OutboxSinks) == 0 {
+		return defaultOutboxSinks
+	}
+	return s.config.OutboxSinks
+}
+
+func (s *Service) OutboxPollInterval() time.Duration {
+	if s.config.OutboxPollInterval <= 0 {
+		return defaultOutboxPollInterval
+	}
+	return s.config.OutboxPollInterval
+}
+
+func (s *Service) OutboxBatchSize() int {
+	if s.config.OutboxBatchSize <= 0 {
+		return defaultOutboxBatchSize
+	}
+	return s.config.OutboxBatchSize
+}
+
+// OutboxRetention is how long published events are kept in outbox
+func (s *Service) OutboxRetention() time.Duration {
+	if s.config.OutboxRetention <= 0 {
+		return defaultOutboxRetention
+	}
+	return s.config.OutboxRetention
+}
+
+// OutboxWebhookURL receives domain events as JSON when webhook sink is enabled
+func (s *Service) OutboxWebhookURL() string {
+	return s.config.OutboxWebhookURL
+}
+
+func (s *Service) OutboxWebhookTimeout() time.Duration {
+	if s.config.OutboxWebhookTimeout <= 0 {
+		return defaultOutboxWebhookTimeout
+	}
+	return s.config.OutboxWebhookTimeout
+}
+
+// OutboxTopic is Kafka topic domain events are published to when queue sink is enabled
+func (s *Service) OutboxTopic() string {
+	if s.config.OutboxTopic == "" {
+		return defaultOutboxTopic
+	}
+	return s.config.OutboxTopic
+}
+
+// GRPCServiceToken is shared secret internal services pass to gRPC API, the API is not served without it
+func (s *Service) GRPCServiceToken() string {
+	return s.config.GRPCServiceToken
+}
@@ -2,12 +2,15 @@ package constants
 
 import "github.com/pkg/errors"
 
+// period identifiers are used in callback data, so they must not depend on user language
 const (
-	WeekPeriod  = "Неделя"
-	MonthPeriod = "Месяц"
-	YearPeriod  = "Год"
+	WeekPeriod  = "week"
+	MonthPeriod = "month"
+	YearPeriod  = "year"
 )
 
+var Periods = []string{WeekPeriod, MonthPeriod, YearPeriod}
+
 const (
 	ServerCurrency = "RUB"
 )
@@ -19,26 +22,50 @@ const (
 	ShowCategoryList = "show_category_list"
 	ChangeCurrency   = "change_currency"
 	ShowReport       = "show_report"
+	ChangeLanguage   = "change_language"
+	Dialog           = "dialog"
+	EnableCurrency   = "enable_currency"
+	DisableCurrency  = "disable_currency"
+	Convert          = "convert"
+	Rates            = "rates"
+	Alert            = "alert"
+	Alerts           = "alerts"
+	DeleteAlert      = "delete_alert"
+	Token            = "token"
+	Webhook          = "webhook"
+	Webhooks         = "webhooks"
+	DeleteWebhook    = "delete_webhook"
+	WebhookLog       = "webhook_log"
+	Receipt          = "receipt"
+	History          = "history"
+	Attach           = "attach"
+	Attachment       = "attachment"
 )
 
-const (
-	IncorrectAmountClientMsg       = "не могу распознать введенную сумму, \n формат записи: 12345 (без пробелов и знаков препинания)"
-	TransactionAddedMsg            = "Трата в категории '%s' на сумму %s %s добавлена!"
-	LimitExceededMsg               = "Трата в категории '%s' на сумму %s %s добавлена, но лимит на текущий месяц превышен на %s %s !"
-	SpecifyAmountMsg               = "укажите сумму расхода (%s): "
-	SpecifyCategoryMsg             = "Выберите категорию:"
-	SpecifyPeriodMsg               = "Выберите желаемый период:"
-	SpecifyCurrencyMsg             = "Выберите валюту по умолчанию:"
-	UnrecognizedCommandMsg         = "Неизвестная команда"
-	SetLimitUntilDateMsg           = "Установлен лимит \nв категории '%s' \nна %s %s до даты: %s !"
-	InternalServerErrorMsg         = "Внутренняя ошибка сервера"
-	CannotShowCurrencyMenuMsg      = "Не могу отобразить список валют из-за внутренней ошибки :("
-	HelloMsg                       = "привет, друг!"
-	UndefinedCurrencyMsg           = "Бот не поддерживает выбранную вами валюту :("
-	CannotChangeCurrencyMsg        = "Не могу поменять валюту :("
-	CurrencyChangedSuccessfullyMsg = "Валюта успешно изменена на '%s'!"
-	CannotGetRateForYouMsg         = "не могу загрузить курс из-за внутренней ошибки \xF0\x9F\x98\x94\nПопробуйте позже или выберите дефолтную валюту: %s"
-	ServerProblemMsg               = "Проблемы на сервере, уже чиним \xF0\x9F\x99\x88\n\nПоказаны результаты в базовой валюте:\n\n"
-)
+var (
+	MissingCurrencyErr   = errors.New("missing currency")
+	UndefinedCurrencyErr = errors.New("undefined currency")
+	EmptyRatesErr        = errors.New("provider returned no rates")
+	MissingRateErr       = errors.New("no rate within allowed staleness")
+	FutureDateErr        = errors.New("date is in the future")
+	AlertNotFoundErr     = errors.New("rate alert not found")
+	TooManyAlertsErr     = errors.New("too many rate alerts")
+	TokenNotFoundErr     = errors.New("api token not found")
+	UnknownCategoryErr   = errors.New("unknown category")
+	NotPositiveAmountErr = errors.New("amount must be positive")
+	WebhookNotFoundErr   = errors.New("webhook not found")
+	TooManyWebhooksErr   = errors.New("too many webhooks")
+	InvalidWebhookURLErr = errors.New("webhook url must be public https url")
+	UnknownEventTypeErr  = errors.New("unknown event type")
+	InvalidReceiptErr    = errors.New("invalid receipt")
+	QRCodeNotFoundErr    = errors.New("qr code not found")
 
-var MissingCurrencyErr = errors.New("missing currency")
+	ReceiptAlreadyImportedErr = errors.New("receipt is already imported")
+	TransactionNotFoundErr    = errors.New("transaction not found")
+	AttachmentNotFoundErr     = errors.New("attachment not found")
+
+	InvalidCurrencyCodeErr   = errors.New("invalid ISO 4217 currency code")
+	UnknownCurrencySymbolErr = errors.New("symbol is required for currency outside of catalog")
+	ServerCurrencyErr        = errors.New("server currency cannot be disabled")
+	DisabledCurrencyErr      = errors.New("currency is not enabled")
+)
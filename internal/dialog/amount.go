@@ -0,0 +1,125 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// keys of numeric keypad which edit accumulated amount
+const (
+	BackspaceKey = "backspace"
+	ClearKey     = "clear"
+	PointKey     = "."
+)
+
+// keys of arithmetic operations, amount is kept in canonical ascii form, see arithmetic.Normalize
+const (
+	PlusKey     = "+"
+	MinusKey    = "-"
+	MultiplyKey = "*"
+	DivideKey   = "/"
+	OpenKey     = "("
+	CloseKey    = ")"
+)
+
+const (
+	maxNumberLength     = 12
+	maxExpressionLength = 40
+)
+
+var (
+	AmountTooLongErr        = errors.New("amount is too long")
+	SecondPointErr          = errors.New("amount already contains decimal point")
+	TooManyFractionErr      = errors.New("amount contains too many fractional digits")
+	MisplacedOperatorErr    = errors.New("operator or parenthesis is not allowed here")
+	UnsupportedAmountKeyErr = errors.New("unsupported amount key")
+)
+
+// ApplyKey returns amount after pressing keypad key or error if result would be invalid,
+// so state always contains number or prefix of arithmetic expression,
+// fractionDigits is precision of currency the amount is entered in
+func ApplyKey(amount, key string, fractionDigits int32) (string, error) {
+	last := lastChar(amount)
+	number := lastNumber(amount)
+
+	switch key {
+	case BackspaceKey:
+		if amount == "" {
+			return amount, nil
+		}
+		return amount[:len(amount)-1], nil
+	case ClearKey:
+		return "", nil
+	case PointKey:
+		if strings.Contains(number, PointKey) {
+			return amount, SecondPointErr
+		}
+		if last == ')' {
+			return amount, MisplacedOperatorErr
+		}
+		if number == "" {
+			amount += "0"
+		}
+		return checkLength(amount + PointKey)
+	case PlusKey, MinusKey, MultiplyKey, DivideKey:
+		switch {
+		case amount == "" || last == '(':
+			return amount, MisplacedOperatorErr
+		case isOperator(last): // pressing another operator replaces previous one
+			return amount[:len(amount)-1] + key, nil
+		}
+		return checkLength(amount + key)
+	case OpenKey:
+		if amount != "" && last != '(' && !isOperator(last) {
+			return amount, MisplacedOperatorErr
+		}
+		return checkLength(amount + key)
+	case CloseKey:
+		if strings.Count(amount, OpenKey) <= strings.Count(amount, CloseKey) || (number == "" && last != ')') {
+			return amount, MisplacedOperatorErr
+		}
+		return checkLength(amount + key)
+	}
+
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return amount, UnsupportedAmountKeyErr
+	}
+	if last == ')' {
+		return amount, MisplacedOperatorErr
+	}
+	if _, fraction, ok := strings.Cut(number, PointKey); ok && len(fraction) >= int(fractionDigits) {
+		return amount, TooManyFractionErr
+	}
+	if number == "0" { // avoid leading zeros
+		return amount[:len(amount)-1] + key, nil
+	}
+	return checkLength(amount + key)
+}
+
+func checkLength(amount string) (string, error) {
+	if len(lastNumber(amount)) > maxNumberLength || len(amount) > maxExpressionLength {
+		return amount[:len(amount)-1], AmountTooLongErr
+	}
+	return amount, nil
+}
+
+// lastNumber returns number which is being typed at the end of amount
+func lastNumber(amount string) string {
+	i := len(amount)
+	for i > 0 && (amount[i-1] == '.' || amount[i-1] >= '0' && amount[i-1] <= '9') {
+		i--
+	}
+	return amount[i:]
+}
+
+func lastChar(amount string) byte {
+	if amount == "" {
+		return 0
+	}
+	return amount[len(amount)-1]
+}
+
+func isOperator(c byte) bool {
+	return strings.IndexByte(PlusKey+MinusKey+MultiplyKey+DivideKey, c) >= 0
+}
@@ -0,0 +1,60 @@
+package dialog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{name: "digit", amount: "12", key: "3", want: "123"},
+		{name: "leading zero is replaced", amount: "0", key: "5", want: "5"},
+		{name: "point on empty amount", amount: "", key: ".", want: "0."},
+		{name: "second point", amount: "1.2", key: ".", want: "1.2", wantErr: SecondPointErr},
+		{name: "third fractional digit", amount: "1.25", key: "1", want: "1.25", wantErr: TooManyFractionErr},
+		{name: "second fractional digit", amount: "1.2", key: "1", want: "1.21"},
+		{name: "too long", amount: "123456789012", key: "3", want: "123456789012", wantErr: AmountTooLongErr},
+		{name: "backspace", amount: "1.2", key: BackspaceKey, want: "1."},
+		{name: "backspace on empty", amount: "", key: BackspaceKey, want: ""},
+		{name: "clear", amount: "1.2", key: ClearKey, want: ""},
+		{name: "point in second number", amount: "1.5+2", key: ".", want: "1.5+2."},
+		{name: "point after operator", amount: "1+", key: ".", want: "1+0."},
+		{name: "fraction of second number", amount: "1.25*1.5", key: "1", want: "1.25*1.51"},
+		{name: "leading zero of second number", amount: "10+0", key: "5", want: "10+5"},
+		{name: "operator", amount: "1200", key: DivideKey, want: "1200/"},
+		{name: "operator replaces operator", amount: "1200/", key: PlusKey, want: "1200+"},
+		{name: "operator on empty amount", amount: "", key: MinusKey, want: "", wantErr: MisplacedOperatorErr},
+		{name: "operator after open parenthesis", amount: "2*(", key: PlusKey, want: "2*(", wantErr: MisplacedOperatorErr},
+		{name: "open parenthesis", amount: "2*", key: OpenKey, want: "2*("},
+		{name: "open parenthesis after number", amount: "2", key: OpenKey, want: "2", wantErr: MisplacedOperatorErr},
+		{name: "close parenthesis", amount: "2*(1+3", key: CloseKey, want: "2*(1+3)"},
+		{name: "close parenthesis without open", amount: "2+3", key: CloseKey, want: "2+3", wantErr: MisplacedOperatorErr},
+		{name: "close parenthesis after operator", amount: "(1+", key: CloseKey, want: "(1+", wantErr: MisplacedOperatorErr},
+		{name: "digit after close parenthesis", amount: "(1+3)", key: "2", want: "(1+3)", wantErr: MisplacedOperatorErr},
+		{name: "unsupported key", amount: "1", key: "x", want: "1", wantErr: UnsupportedAmountKeyErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyKey(tt.amount, tt.key, 2)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyKey_CurrencyPrecision(t *testing.T) {
+	got, err := ApplyKey("0.0001234", "5", 8)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00012345", got)
+
+	got, err = ApplyKey("0.00012345", "6", 8)
+	assert.Equal(t, TooManyFractionErr, err)
+	assert.Equal(t, "0.00012345", got)
+}
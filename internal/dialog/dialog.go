@@ -0,0 +1,100 @@
+package dialog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	CategoryStep   = "category"
+	AmountStep     = "amount"
+	ConfirmStep    = "confirm"
+	AttachmentStep = "attachment" // waits for file to attach to transaction
+)
+
+// keys of inline keyboard which control dialog instead of entering amount
+const (
+	DoneKey    = "done"
+	BackKey    = "back"
+	CancelKey  = "cancel"
+	ConfirmKey = "confirm"
+)
+
+const defaultExpiration = 15 * time.Minute
+
+// State keeps progress of multi-step flow (add operation, set limit) on server side,
+// so callback data contains only the pressed key
+type State struct {
+	Flow       string `json:"flow"`
+	Step       string `json:"step"`
+	CategoryID string `json:"category_id,omitempty"`
+	Amount     string `json:"amount,omitempty"`
+	Result     string `json:"result,omitempty"` // evaluated expression waiting for confirmation
+	MessageID  int    `json:"message_id"`
+	// Receipt prefills amount and date of added operation, its amount is entered in server currency
+	Receipt *model.Receipt `json:"receipt,omitempty"`
+	// Attachment is photo of receipt which is attached to added operation
+	Attachment *model.Attachment `json:"attachment,omitempty"`
+	// TransactionID is transaction the next sent file is attached to
+	TransactionID int64 `json:"transaction_id,omitempty"`
+}
+
+type Cache interface {
+	Get(k string) (string, bool)
+	Add(k string, x string, d time.Duration) error
+	Delete(key string) error
+}
+
+type Store struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewStore(cache Cache, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = defaultExpiration
+	}
+	return &Store{
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func (s *Store) Get(userID int64) (*State, bool) {
+	v, ok := s.cache.Get(getDialogCacheKey(userID))
+	if !ok || v == "" {
+		return nil, false
+	}
+	var state State
+	if err := json.Unmarshal([]byte(v), &state); err != nil {
+		logger.Error("cannot unmarshal dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return nil, false
+	}
+	return &state, true
+}
+
+// Save stores state and prolongs its expiration
+func (s *Store) Save(userID int64, state *State) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal dialog state")
+	}
+	return s.cache.Add(getDialogCacheKey(userID), string(b), s.ttl)
+}
+
+func (s *Store) Reset(userID int64) error {
+	if _, ok := s.Get(userID); !ok {
+		return nil
+	}
+	return s.cache.Delete(getDialogCacheKey(userID))
+}
+
+func getDialogCacheKey(userID int64) string {
+	return fmt.Sprintf("DIALOG_%d", userID)
+}
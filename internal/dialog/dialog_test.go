@@ -0,0 +1,58 @@
+package dialog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapCache struct {
+	inner map[string]string
+	ttl   map[string]time.Duration
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{
+		inner: make(map[string]string),
+		ttl:   make(map[string]time.Duration),
+	}
+}
+
+func (c *mapCache) Get(k string) (string, bool) {
+	v, ok := c.inner[k]
+	return v, ok
+}
+
+func (c *mapCache) Add(k string, x string, d time.Duration) error {
+	c.inner[k] = x
+	c.ttl[k] = d
+	return nil
+}
+
+func (c *mapCache) Delete(k string) error {
+	delete(c.inner, k)
+	return nil
+}
+
+func TestStore(t *testing.T) {
+	cache := newMapCache()
+	store := NewStore(cache, 0)
+	userID := int64(123)
+
+	_, ok := store.Get(userID)
+	assert.False(t, ok)
+
+	state := &State{Flow: "add_operation", Step: AmountStep, CategoryID: "EDUCATION", Amount: "12", MessageID: 7}
+	assert.NoError(t, store.Save(userID, state))
+	assert.Equal(t, defaultExpiration, cache.ttl["DIALOG_123"])
+
+	got, ok := store.Get(userID)
+	assert.True(t, ok)
+	assert.Equal(t, state, got)
+
+	assert.NoError(t, store.Reset(userID))
+	_, ok = store.Get(userID)
+	assert.False(t, ok)
+	assert.NoError(t, store.Reset(userID))
+}
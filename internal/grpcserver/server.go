@@ -0,0 +1,253 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/tracing"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/pkg/financialbot"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// authorizationKey of call metadata carries service token as "Bearer <token>"
+	authorizationKey = "authorization"
+)
+
+// Operations adds expenses and limits given in any enabled currency
+type Operations interface {
+	AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string,
+		date time.Time) (model.Transaction, error)
+	SetLimit(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string) (model.Limit, error)
+	ResolveCurrency(ctx context.Context, userID int64, requested string) (string, error)
+}
+
+type TransactionStore interface {
+	ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error)
+}
+
+type Calculator interface {
+	CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+}
+
+// Server is gRPC API for internal services, callers holding service token are trusted to act on behalf of any user
+type Server struct {
+	financialbot.UnimplementedFinancialBotServer
+
+	serviceToken    string
+	operations      Operations
+	transactionRepo TransactionStore
+	calcService     Calculator
+}
+
+func New(serviceToken string, operations Operations, transactionRepo TransactionStore, calcService Calculator) *Server {
+	return &Server{
+		serviceToken:    serviceToken,
+		operations:      operations,
+		transactionRepo: transactionRepo,
+		calcService:     calcService,
+	}
+}
+
+// Serve handles calls from listener until ctx is done, calls in progress are finished before return
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metricsInterceptor,
+		s.authInterceptor))
+	financialbot.RegisterFinancialBotServer(server, s)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	logger.Info("starting grpc server", zap.String("address", listener.Addr().String()))
+	return errors.Wrap(server.Serve(listener), "grpc server failed")
+}
+
+func metricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	code := status.Code(err).String()
+	tookTime := time.Since(start).Seconds()
+	metrics.IncomingRequestsTotalCounter.WithLabelValues("grpc", info.FullMethod, code).Inc()
+	metrics.IncomingRequestsHistogramResponseTime.WithLabelValues("grpc", info.FullMethod, code).Observe(tookTime)
+	return resp, err
+}
+
+// authInterceptor rejects calls without service token, all calls are rejected when token is not configured
+func (s *Server) authInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	const scheme = "Bearer "
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(authorizationKey)) > 0 {
+		token = strings.TrimPrefix(md.Get(authorizationKey)[0], scheme)
+	}
+	if s.serviceToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.serviceToken)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "service token is required")
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) AddOperation(ctx context.Context, req *financialbot.AddOperationRequest) (*financialbot.AddOperationResponse, error) {
+	if err := checkUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	amount, err := parseAmount(req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	date := time.Now()
+	if req.GetDate() != nil {
+		if err = req.GetDate().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		date = req.GetDate().AsTime()
+	}
+
+	transaction, err := s.operations.AddOperation(ctx, req.GetUserId(), req.GetCategoryId(), amount, req.GetCurrency(), date)
+	if err != nil {
+		return nil, toStatus(ctx, "AddOperation", err)
+	}
+	return &financialbot.AddOperationResponse{Transaction: toTransaction(transaction)}, nil
+}
+
+func (s *Server) ListTransactions(ctx context.Context, req *financialbot.ListTransactionsRequest) (*financialbot.ListTransactionsResponse, error) {
+	if err := checkUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit < 0 || limit > maxPageSize {
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 1 and 100")
+	}
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	transactions, total, err := s.transactionRepo.ListTransactions(ctx, req.GetUserId(), limit, int(req.GetOffset()))
+	if err != nil {
+		return nil, toStatus(ctx, "ListTransactions", err)
+	}
+	resp := &financialbot.ListTransactionsResponse{
+		Transactions: make([]*financialbot.Transaction, 0, len(transactions)),
+		Total:        int32(total),
+	}
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, toTransaction(t))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetReport(ctx context.Context, req *financialbot.GetReportRequest) (*financialbot.GetReportResponse, error) {
+	if err := checkUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	calc, ok := map[financialbot.Period]func(ctx context.Context, userID int64, currency string) (model.ReportData, error){
+		financialbot.Period_PERIOD_WEEK:  s.calcService.CalcByCurrentWeek,
+		financialbot.Period_PERIOD_MONTH: s.calcService.CalcByCurrentMonth,
+		financialbot.Period_PERIOD_YEAR:  s.calcService.CalcByCurrentYear,
+	}[req.GetPeriod()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "period is required")
+	}
+	currency, err := s.operations.ResolveCurrency(ctx, req.GetUserId(), req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(ctx, "GetReport", err)
+	}
+
+	report, err := calc(ctx, req.GetUserId(), currency)
+	if err != nil {
+		return nil, toStatus(ctx, "GetReport", err)
+	}
+	resp := &financialbot.GetReportResponse{
+		Currency:     currency,
+		Expenses:     make(map[string]string, len(report.Expenses)),
+		PendingRates: int32(report.PendingRates),
+		StaleRates:   int32(report.StaleRates),
+	}
+	for categoryID, amount := range report.Expenses {
+		resp.Expenses[categoryID] = amount.String()
+	}
+	return resp, nil
+}
+
+func (s *Server) SetLimit(ctx context.Context, req *financialbot.SetLimitRequest) (*financialbot.SetLimitResponse, error) {
+	if err := checkUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	amount, err := parseAmount(req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := s.operations.SetLimit(ctx, req.GetUserId(), req.GetCategoryId(), amount, req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(ctx, "SetLimit", err)
+	}
+	return &financialbot.SetLimitResponse{
+		CategoryId: limit.CategoryID,
+		Amount:     limit.UpperBorder.String(),
+		Currency:   constants.ServerCurrency,
+		UntilDate:  timestamppb.New(limit.UntilDate),
+	}, nil
+}
+
+func checkUser(userID int64) error {
+	if userID <= 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return nil
+}
+
+func parseAmount(raw string) (decimal.Decimal, error) {
+	amount, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "cannot parse amount %q", raw)
+	}
+	return amount, nil
+}
+
+func toTransaction(t model.Transaction) *financialbot.Transaction {
+	return &financialbot.Transaction{
+		Id:         t.ID,
+		CategoryId: t.CategoryID,
+		Amount:     t.Amount.String(),
+		Currency:   constants.ServerCurrency,
+		Date:       timestamppb.New(t.Date),
+	}
+}
+
+// toStatus exposes validation errors of services to caller, other errors are logged and hidden
+func toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, constants.UnknownCategoryErr), errors.Is(err, constants.DisabledCurrencyErr),
+		errors.Is(err, constants.NotPositiveAmountErr), errors.Is(err, constants.FutureDateErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, constants.MissingRateErr):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	logger.Error("cannot serve grpc call", zap.String("method", method), zap.Error(err))
+	return status.Error(codes.Internal, "internal error")
+}
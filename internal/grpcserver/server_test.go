@@ -0,0 +1,225 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	grpcMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/grpcserver"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/tracing"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/pkg/financialbot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type testServer struct {
+	client          financialbot.FinancialBotClient
+	operations      *grpcMocks.MockOperations
+	transactionRepo *grpcMocks.MockTransactionStore
+	calcService     *grpcMocks.MockCalculator
+}
+
+const testServiceToken = "service-token"
+
+// newTestServer serves calls over in-memory connection with the same interceptors as in production
+func newTestServer(t *testing.T) *testServer {
+	return newTestServerWithToken(t, testServiceToken, testServiceToken)
+}
+
+// newTestServerWithToken connects client passing clientToken to server expecting serverToken
+func newTestServerWithToken(t *testing.T, serverToken, clientToken string) *testServer {
+	ctrl := gomock.NewController(t)
+	s := &testServer{
+		operations:      grpcMocks.NewMockOperations(ctrl),
+		transactionRepo: grpcMocks.NewMockTransactionStore(ctrl),
+		calcService:     grpcMocks.NewMockCalculator(ctrl),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	listener := bufconn.Listen(1 << 20)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = New(serverToken, s.operations, s.transactionRepo, s.calcService).Serve(ctx, listener)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor(), func(ctx context.Context, method string,
+			req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if clientToken != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+clientToken)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		<-done
+	})
+	s.client = financialbot.NewFinancialBotClient(conn)
+	return s
+}
+
+func TestServer_AddOperation(t *testing.T) {
+	s := newTestServer(t)
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s.operations.EXPECT().AddOperation(gomock.Any(), int64(1), "FOOD", decimal.RequireFromString("12.5"), "USD", date).
+		Return(model.Transaction{ID: 42, CategoryID: "FOOD", Amount: decimal.NewFromInt(1250), Date: date}, nil)
+
+	resp, err := s.client.AddOperation(context.Background(), &financialbot.AddOperationRequest{
+		UserId: 1, CategoryId: "FOOD", Amount: "12.5", Currency: "USD", Date: timestamppb.New(date),
+	})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, resp.GetTransaction().GetId())
+	assert.Equal(t, "1250", resp.GetTransaction().GetAmount())
+	assert.Equal(t, "RUB", resp.GetTransaction().GetCurrency())
+	assert.True(t, date.Equal(resp.GetTransaction().GetDate().AsTime()))
+}
+
+func TestServer_RequiresServiceToken(t *testing.T) {
+	ctx := context.Background()
+	req := &financialbot.ListTransactionsRequest{UserId: 1}
+
+	for name, tokens := range map[string][2]string{
+		"missing token":        {testServiceToken, ""},
+		"wrong token":          {testServiceToken, "guess"},
+		"token not configured": {"", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServerWithToken(t, tokens[0], tokens[1])
+
+			_, err := s.client.ListTransactions(ctx, req)
+
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestServer_RejectsInvalidArguments(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	_, err := s.client.AddOperation(ctx, &financialbot.AddOperationRequest{CategoryId: "FOOD", Amount: "1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.client.AddOperation(ctx, &financialbot.AddOperationRequest{UserId: 1, CategoryId: "FOOD", Amount: "ten"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.client.ListTransactions(ctx, &financialbot.ListTransactionsRequest{UserId: 1, Limit: 101})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.client.GetReport(ctx, &financialbot.GetReportRequest{UserId: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	s.operations.EXPECT().SetLimit(gomock.Any(), int64(1), "TOYS", gomock.Any(), "").
+		Return(model.Limit{}, errors.Wrap(constants.UnknownCategoryErr, "TOYS"))
+	_, err = s.client.SetLimit(ctx, &financialbot.SetLimitRequest{UserId: 1, CategoryId: "TOYS", Amount: "100"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "TOYS")
+}
+
+func TestServer_HidesInternalErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	s.transactionRepo.EXPECT().ListTransactions(gomock.Any(), int64(1), 20, 0).
+		Return(nil, 0, errors.New("pq: password is wrong"))
+
+	_, err := s.client.ListTransactions(context.Background(), &financialbot.ListTransactionsRequest{UserId: 1})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "password")
+}
+
+func TestServer_ListTransactions(t *testing.T) {
+	s := newTestServer(t)
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s.transactionRepo.EXPECT().ListTransactions(gomock.Any(), int64(1), 2, 4).Return([]model.Transaction{
+		{ID: 7, CategoryID: "FOOD", Amount: decimal.RequireFromString("150.5"), Date: date},
+	}, 5, nil)
+
+	resp, err := s.client.ListTransactions(context.Background(),
+		&financialbot.ListTransactionsRequest{UserId: 1, Limit: 2, Offset: 4})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, resp.GetTotal())
+	require.Len(t, resp.GetTransactions(), 1)
+	assert.Equal(t, "150.5", resp.GetTransactions()[0].GetAmount())
+}
+
+func TestServer_GetReport(t *testing.T) {
+	s := newTestServer(t)
+
+	s.operations.EXPECT().ResolveCurrency(gomock.Any(), int64(1), "").Return("USD", nil)
+	s.calcService.EXPECT().CalcByCurrentYear(gomock.Any(), int64(1), "USD").Return(model.ReportData{
+		Expenses:   map[string]decimal.Decimal{"FOOD": decimal.RequireFromString("12.5")},
+		StaleRates: 2,
+	}, nil)
+
+	resp, err := s.client.GetReport(context.Background(),
+		&financialbot.GetReportRequest{UserId: 1, Period: financialbot.Period_PERIOD_YEAR})
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", resp.GetCurrency())
+	assert.Equal(t, map[string]string{"FOOD": "12.5"}, resp.GetExpenses())
+	assert.EqualValues(t, 2, resp.GetStaleRates())
+}
+
+func TestServer_ContinuesTraceOfCaller(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	s := newTestServer(t)
+
+	s.operations.EXPECT().SetLimit(gomock.Any(), int64(1), "FOOD", gomock.Any(), "").DoAndReturn(
+		func(ctx context.Context, _ int64, _ string, _ decimal.Decimal, _ string) (model.Limit, error) {
+			span, _ := opentracing.StartSpanFromContext(ctx, "SetLimit")
+			span.Finish()
+			return model.Limit{CategoryID: "FOOD", UpperBorder: decimal.NewFromInt(100)}, nil
+		})
+
+	_, err := s.client.SetLimit(context.Background(), &financialbot.SetLimitRequest{UserId: 1, CategoryId: "FOOD", Amount: "100"})
+	require.NoError(t, err)
+
+	var client, server, service *mocktracer.MockSpan
+	for _, span := range tracer.FinishedSpans() {
+		switch {
+		case span.OperationName == "SetLimit":
+			service = span
+		case span.Tag(string(ext.SpanKind)) == ext.SpanKindRPCServerEnum:
+			server = span
+		default:
+			client = span
+		}
+	}
+	require.NotNil(t, service)
+	require.NotNil(t, server)
+	require.NotNil(t, client)
+	assert.Equal(t, "grpc:SetLimit", server.OperationName)
+	assert.Equal(t, server.SpanContext.SpanID, service.ParentID)
+	assert.Equal(t, client.SpanContext.SpanID, server.ParentID)
+	assert.Equal(t, client.SpanContext.TraceID, service.SpanContext.TraceID)
+}
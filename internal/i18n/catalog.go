@@ -0,0 +1,415 @@
+package i18n
+
+const (
+	IncorrectAmount             Key = "incorrect_amount"
+	TransactionAdded            Key = "transaction_added"
+	LimitExceeded               Key = "limit_exceeded"
+	SpecifyAmount               Key = "specify_amount"
+	SpecifyCategory             Key = "specify_category"
+	SpecifyPeriod               Key = "specify_period"
+	SpecifyCurrency             Key = "specify_currency"
+	SpecifyLanguage             Key = "specify_language"
+	UnrecognizedCommand         Key = "unrecognized_command"
+	SetLimitUntilDate           Key = "set_limit_until_date"
+	InternalServerError         Key = "internal_server_error"
+	CannotShowCurrencyMenu      Key = "cannot_show_currency_menu"
+	Hello                       Key = "hello"
+	UndefinedCurrency           Key = "undefined_currency"
+	CannotChangeCurrency        Key = "cannot_change_currency"
+	CurrencyChangedSuccessfully Key = "currency_changed_successfully"
+	CannotChangeLanguage        Key = "cannot_change_language"
+	LanguageChangedSuccessfully Key = "language_changed_successfully"
+	CannotGetRateForYou         Key = "cannot_get_rate_for_you"
+	ServerProblem               Key = "server_problem"
+	ReportHeader                Key = "report_header"
+	NoExpenses                  Key = "no_expenses"
+	DoneButton                  Key = "done_button"
+	BackButton                  Key = "back_button"
+	CancelButton                Key = "cancel_button"
+	DialogExpired               Key = "dialog_expired"
+	DialogCancelled             Key = "dialog_cancelled"
+	AmountTooLong               Key = "amount_too_long"
+	AmountSecondPoint           Key = "amount_second_point"
+	AmountTooManyFraction       Key = "amount_too_many_fraction"
+	AmountMisplacedOperator     Key = "amount_misplaced_operator"
+	AmountNotPositive           Key = "amount_not_positive"
+	DivisionByZero              Key = "division_by_zero"
+	ConfirmAmount               Key = "confirm_amount"
+	ConfirmButton               Key = "confirm_button"
+	LanguageName                Key = "language_name"
+	SpecifyCurrencyCode         Key = "specify_currency_code"
+	CurrencySymbolRequired      Key = "currency_symbol_required"
+	CurrencyEnabled             Key = "currency_enabled"
+	CurrencyDisabled            Key = "currency_disabled"
+	CannotDisableServerCurrency Key = "cannot_disable_server_currency"
+	CannotChangeCurrencyList    Key = "cannot_change_currency_list"
+	RatesStillLoading           Key = "rates_still_loading"
+	StaleRatesUsed              Key = "stale_rates_used"
+	MissingRate                 Key = "missing_rate"
+	ConvertUsage                Key = "convert_usage"
+	ConvertResult               Key = "convert_result"
+	ConvertSource               Key = "convert_source"
+	ConvertFutureDate           Key = "convert_future_date"
+	ConvertUnknownCurrency      Key = "convert_unknown_currency"
+	CannotConvert               Key = "cannot_convert"
+	DateLayout                  Key = "date_layout"
+	RatesHeader                 Key = "rates_header"
+	RatesLine                   Key = "rates_line"
+	NoRates                     Key = "no_rates"
+	RatesUsage                  Key = "rates_usage"
+	NoChange                    Key = "no_change"
+	RatesHistory                Key = "rates_history"
+	CannotShowRates             Key = "cannot_show_rates"
+	AlertUsage                  Key = "alert_usage"
+	AlertAdded                  Key = "alert_added"
+	AlertsHeader                Key = "alerts_header"
+	AlertLine                   Key = "alert_line"
+	NoAlerts                    Key = "no_alerts"
+	DeleteAlertUsage            Key = "delete_alert_usage"
+	AlertDeleted                Key = "alert_deleted"
+	AlertNotFound               Key = "alert_not_found"
+	TooManyAlerts               Key = "too_many_alerts"
+	AlertTriggered              Key = "alert_triggered"
+	CannotChangeAlerts          Key = "cannot_change_alerts"
+	TokenIssued                 Key = "token_issued"
+	TokensRevoked               Key = "tokens_revoked"
+	TokenUsage                  Key = "token_usage"
+	CannotChangeTokens          Key = "cannot_change_tokens"
+	WebhookUsage                Key = "webhook_usage"
+	WebhookAdded                Key = "webhook_added"
+	WebhooksHeader              Key = "webhooks_header"
+	WebhookLine                 Key = "webhook_line"
+	NoWebhooks                  Key = "no_webhooks"
+	DeleteWebhookUsage          Key = "delete_webhook_usage"
+	WebhookLogUsage             Key = "webhook_log_usage"
+	WebhookDeleted              Key = "webhook_deleted"
+	WebhookNotFound             Key = "webhook_not_found"
+	TooManyWebhooks             Key = "too_many_webhooks"
+	InvalidWebhookURL           Key = "invalid_webhook_url"
+	UnknownEventType            Key = "unknown_event_type"
+	AllEvents                   Key = "all_events"
+	CannotChangeWebhooks        Key = "cannot_change_webhooks"
+	WebhookLogHeader            Key = "webhook_log_header"
+	WebhookLogLine              Key = "webhook_log_line"
+	NoWebhookDeliveries         Key = "no_webhook_deliveries"
+	ReceiptRecognized           Key = "receipt_recognized"
+	ReceiptAlreadyImported      Key = "receipt_already_imported"
+	InvalidReceipt              Key = "invalid_receipt"
+	QRCodeNotFound              Key = "qr_code_not_found"
+	UnsupportedAttachment       Key = "unsupported_attachment"
+	ReceiptHint                 Key = "receipt_hint"
+	HistoryHeader               Key = "history_header"
+	HistoryLine                 Key = "history_line"
+	NoTransactions              Key = "no_transactions"
+	AttachUsage                 Key = "attach_usage"
+	AwaitingAttachment          Key = "awaiting_attachment"
+	AttachmentSaved             Key = "attachment_saved"
+	TransactionNotFound         Key = "transaction_not_found"
+	AttachmentNotFound          Key = "attachment_not_found"
+)
+
+const (
+	AddOperationCommand     Key = "command.add_operation"
+	ShowCategoryListCommand Key = "command.show_category_list"
+	SetLimitationCommand    Key = "command.set_category_limitation"
+	ChangeCurrencyCommand   Key = "command.change_currency"
+	ShowReportCommand       Key = "command.show_report"
+	ChangeLanguageCommand   Key = "command.change_language"
+	ConvertCommand          Key = "command.convert"
+	RatesCommand            Key = "command.rates"
+	AlertsCommand           Key = "command.alerts"
+	TokenCommand            Key = "command.token"
+	WebhooksCommand         Key = "command.webhooks"
+	ReceiptCommand          Key = "command.receipt"
+	HistoryCommand          Key = "command.history"
+)
+
+const (
+	WeekPeriod  Key = "period.week"
+	MonthPeriod Key = "period.month"
+	YearPeriod  Key = "period.year"
+)
+
+const (
+	CBRRateProvider       Key = "rate_provider.cbr"
+	ECBRateProvider       Key = "rate_provider.ecb"
+	AbstractRateProvider  Key = "rate_provider.abstract"
+	CoinGeckoRateProvider Key = "rate_provider.coingecko"
+	UnknownRateProvider   Key = "rate_provider.unknown"
+)
+
+const (
+	AlertBelowDirection Key = "alert_direction.below"
+	AlertAboveDirection Key = "alert_direction.above"
+)
+
+var catalog = map[Lang]map[Key]string{
+	RU: {
+		IncorrectAmount:             "не могу распознать введенную сумму, \n примеры: 1500, 99.90, 1200/3, 2*(450+50)",
+		TransactionAdded:            "Трата в категории '%s' на сумму %s добавлена!",
+		LimitExceeded:               "Трата в категории '%s' на сумму %s добавлена, но лимит на текущий месяц превышен на %s !",
+		SpecifyAmount:               "укажите сумму расхода (%s):\n\n",
+		SpecifyCategory:             "Выберите категорию:",
+		SpecifyPeriod:               "Выберите желаемый период:",
+		SpecifyCurrency:             "Выберите валюту по умолчанию:",
+		SpecifyLanguage:             "Выберите язык:",
+		UnrecognizedCommand:         "Неизвестная команда",
+		SetLimitUntilDate:           "Установлен лимит \nв категории '%s' \nна %s до даты: %s !",
+		InternalServerError:         "Внутренняя ошибка сервера",
+		CannotShowCurrencyMenu:      "Не могу отобразить список валют из-за внутренней ошибки :(",
+		Hello:                       "привет, друг!",
+		UndefinedCurrency:           "Бот не поддерживает выбранную вами валюту :(",
+		CannotChangeCurrency:        "Не могу поменять валюту :(",
+		CurrencyChangedSuccessfully: "Валюта успешно изменена на '%s'!",
+		CannotChangeLanguage:        "Не могу поменять язык :(",
+		LanguageChangedSuccessfully: "Язык успешно изменен на '%s'!",
+		CannotGetRateForYou:         "не могу загрузить курс из-за внутренней ошибки \xF0\x9F\x98\x94\nПопробуйте позже или выберите дефолтную валюту: %s",
+		ServerProblem:               "Проблемы на сервере, уже чиним \xF0\x9F\x99\x88\n\nПоказаны результаты в базовой валюте:\n\n",
+		ReportHeader:                "Расходы за период '%s':\n\n",
+		NoExpenses:                  "Нет трат",
+		DoneButton:                  "готово",
+		BackButton:                  "⬅ назад",
+		CancelButton:                "✖ отмена",
+		DialogExpired:               "Время ввода истекло, начните заново",
+		DialogCancelled:             "Действие отменено",
+		AmountTooLong:               "Слишком длинная сумма",
+		AmountSecondPoint:           "Сумма уже содержит десятичную точку",
+		AmountTooManyFraction:       "Допускается не более %d знаков после точки",
+		AmountMisplacedOperator:     "Здесь нельзя поставить этот знак",
+		AmountNotPositive:           "Сумма должна быть больше нуля",
+		DivisionByZero:              "На ноль делить нельзя",
+		ConfirmAmount:               "%s = %s\n\nПодтвердите сумму:",
+		ConfirmButton:               "✔ подтвердить",
+		LanguageName:                "Русский",
+		SpecifyCurrencyCode:         "Укажите код валюты ISO 4217, например: %s GEL",
+		CurrencySymbolRequired:      "Валюты %s нет в справочнике, укажите символ: /enable_currency %s <символ>",
+		CurrencyEnabled:             "Валюта %s (%s) доступна пользователям!",
+		CurrencyDisabled:            "Валюта %s отключена",
+		CannotDisableServerCurrency: "Базовую валюту нельзя отключить",
+		CannotChangeCurrencyList:    "Не могу изменить список валют :(",
+		RatesStillLoading:           "⏳ Курсы валют ещё загружаются (дней без курса: %d), суммы приблизительные. Запросите отчет чуть позже",
+		StaleRatesUsed:              "ℹ️ Для операций без курса на их дату использован последний известный курс (операций: %d)",
+		MissingRate:                 "Не найден курс валюты для части операций, отчет в выбранной валюте пока недоступен. Попробуйте позже или выберите другую валюту",
+		ConvertUsage:                "Использование: /convert 120 EUR RUB [2026-08-15]",
+		ConvertResult:               "%s = %s\n1 %s = %s %s",
+		ConvertSource:               "Курс %s: %s на %s",
+		ConvertFutureDate:           "Курсов на будущие даты нет, укажите сегодняшнюю или прошедшую дату",
+		ConvertUnknownCurrency:      "Конвертация доступна только между подключенными валютами, их список: /change_currency",
+		CannotConvert:               "Не могу получить курс для конвертации, попробуйте позже :(",
+		DateLayout:                  "02.01.2006",
+		RatesHeader:                 "Курсы на %s:\n\n",
+		RatesLine:                   "%s: %s   7 дн.: %s   30 дн.: %s\n",
+		NoRates:                     "Курсов пока нет, попробуйте позже",
+		RatesUsage:                  "Использование: /rates или /rates USD [дней]",
+		NoChange:                    "—",
+		RatesHistory:                "%s за %d дн.:\n%s\nмин. %s · макс. %s · сейчас %s",
+		CannotShowRates:             "Не могу показать курсы :(",
+		AlertUsage:                  "Использование: /alert USD < 85 или /alert USD > 95",
+		AlertAdded:                  "Уведомление №%d: сообщу, когда %s будет %s %s",
+		AlertsHeader:                "Ваши уведомления о курсах:\n\n",
+		AlertLine:                   "№%d: %s %s %s\n",
+		NoAlerts:                    "Уведомлений нет, добавьте: /alert USD < 85",
+		DeleteAlertUsage:            "Удалить уведомление: /delete_alert <номер>",
+		AlertDeleted:                "Уведомление №%d удалено",
+		AlertNotFound:               "Уведомление не найдено, список: /alerts",
+		TooManyAlerts:               "Слишком много уведомлений, удалите ненужные: /alerts",
+		AlertTriggered:              "🔔 %s %s %s: сейчас %s",
+		CannotChangeAlerts:          "Не могу изменить уведомления :(",
+		TokenIssued:                 "Токен для API (показываю один раз, храните в секрете):\n\n%s\n\nПередавайте в заголовке Authorization: Bearer <токен>. Отозвать все токены: /token revoke",
+		TokensRevoked:               "Отозвано токенов: %d",
+		TokenUsage:                  "Использование: /token — выпустить токен для API, /token revoke — отозвать все токены",
+		CannotChangeTokens:          "Не могу изменить токены :(",
+		WebhookUsage:                "Использование: /webhook https://example.com/hook [типы событий], типы: %s",
+		WebhookAdded:                "Вебхук №%d добавлен: %s\nСобытия: %s\n\nСекрет подписи (показываю один раз, храните в секрете):\n\n%s\n\nЗаголовок X-Webhook-Signature содержит sha256=HMAC-SHA256(секрет, X-Webhook-Timestamp + \".\" + тело запроса)",
+		WebhooksHeader:              "Ваши вебхуки:\n\n",
+		WebhookLine:                 "№%d: %s\nСобытия: %s\n",
+		NoWebhooks:                  "Вебхуков нет, добавьте: /webhook https://example.com/hook LimitExceeded",
+		DeleteWebhookUsage:          "Удалить вебхук: /delete_webhook <номер>, журнал доставок: /webhook_log [номер]",
+		WebhookLogUsage:             "Журнал доставок всех вебхуков: /webhook_log, одного вебхука: /webhook_log <номер>",
+		WebhookDeleted:              "Вебхук №%d удален",
+		WebhookNotFound:             "Вебхук не найден, список: /webhooks",
+		TooManyWebhooks:             "Слишком много вебхуков, удалите ненужные: /webhooks",
+		InvalidWebhookURL:           "Адрес вебхука должен начинаться с https:// и указывать на публичный сервер",
+		UnknownEventType:            "Неизвестный тип события, доступны: %s",
+		AllEvents:                   "все",
+		CannotChangeWebhooks:        "Не могу изменить вебхуки :(",
+		WebhookLogHeader:            "Последние доставки вебхуков:\n\n",
+		WebhookLogLine:              "%s %s №%d %s, попытка %d: %s\n",
+		NoWebhookDeliveries:         "Доставок пока не было",
+		ReceiptRecognized:           "Чек от %s на сумму %s\n\n",
+		ReceiptAlreadyImported:      "Этот чек уже добавлен",
+		InvalidReceipt:              "Это не похоже на QR-код кассового чека покупки, ожидается текст вида t=20261019T1230&s=1234.56&fn=...&i=...&fp=...&n=1",
+		QRCodeNotFound:              "Не нашел QR-код чека на фото, пришлите более четкое фото или текст QR-кода",
+		UnsupportedAttachment:       "Пришлите фото QR-кода чека",
+		ReceiptHint:                 "Пришлите фото QR-кода чека или его текст, например:\n/receipt t=20261019T1230&s=1234.56&fn=9960440300000000&i=12345&fp=1234567890&n=1",
+		HistoryHeader:               "Последние траты, 📎 — есть вложение:\n\n",
+		HistoryLine:                 "%d. %s %s %s%s\n",
+		NoTransactions:              "Трат пока нет",
+		AttachUsage:                 "Чтобы прикрепить фото, документ или голосовое сообщение к трате, отправьте /attach <номер из /history>, а затем файл, или отправьте файл с подписью /attach <номер>",
+		AwaitingAttachment:          "Пришлите фото, документ или голосовое сообщение для траты:\n%s",
+		AttachmentSaved:             "Файл прикреплен к трате, он доступен в /history",
+		TransactionNotFound:         "Нет траты с таким номером, номера показаны в /history",
+		AttachmentNotFound:          "К этой трате ничего не прикреплено",
+
+		AddOperationCommand:     "добавить новую трату",
+		ShowCategoryListCommand: "показать список категорий",
+		SetLimitationCommand:    "установить лимит трат (месяц)",
+		ChangeCurrencyCommand:   "сменить валюту",
+		ShowReportCommand:       "показать отчет о тратах за период",
+		ChangeLanguageCommand:   "сменить язык",
+		ConvertCommand:          "конвертер валют",
+		RatesCommand:            "курсы валют",
+		AlertsCommand:           "уведомления о курсах",
+		TokenCommand:            "токен для API",
+		WebhooksCommand:         "вебхуки для интеграций",
+		ReceiptCommand:          "добавить трату по чеку",
+		HistoryCommand:          "история трат и вложения",
+
+		WeekPeriod:  "Неделя",
+		MonthPeriod: "Месяц",
+		YearPeriod:  "Год",
+
+		CBRRateProvider:       "ЦБ РФ",
+		ECBRateProvider:       "ЕЦБ",
+		AbstractRateProvider:  "Abstract API",
+		CoinGeckoRateProvider: "CoinGecko",
+		UnknownRateProvider:   "источник не сохранен",
+
+		AlertBelowDirection: "ниже",
+		AlertAboveDirection: "выше",
+	},
+	EN: {
+		IncorrectAmount:             "cannot recognize the entered amount, \n examples: 1500, 99.90, 1200/3, 2*(450+50)",
+		TransactionAdded:            "Expense in category '%s' of %s added!",
+		LimitExceeded:               "Expense in category '%s' of %s added, but the limit for the current month is exceeded by %s !",
+		SpecifyAmount:               "enter the expense amount (%s):\n\n",
+		SpecifyCategory:             "Choose a category:",
+		SpecifyPeriod:               "Choose a period:",
+		SpecifyCurrency:             "Choose the default currency:",
+		SpecifyLanguage:             "Choose a language:",
+		UnrecognizedCommand:         "Unknown command",
+		SetLimitUntilDate:           "Limit set \nin category '%s' \nto %s until: %s !",
+		InternalServerError:         "Internal server error",
+		CannotShowCurrencyMenu:      "Cannot show the currency list because of an internal error :(",
+		Hello:                       "hello, friend!",
+		UndefinedCurrency:           "The bot does not support the selected currency :(",
+		CannotChangeCurrency:        "Cannot change the currency :(",
+		CurrencyChangedSuccessfully: "Currency successfully changed to '%s'!",
+		CannotChangeLanguage:        "Cannot change the language :(",
+		LanguageChangedSuccessfully: "Language successfully changed to '%s'!",
+		CannotGetRateForYou:         "cannot load the exchange rate because of an internal error \xF0\x9F\x98\x94\nTry again later or choose the default currency: %s",
+		ServerProblem:               "Server problems, we are fixing them \xF0\x9F\x99\x88\n\nResults are shown in the base currency:\n\n",
+		ReportHeader:                "Expenses for the period '%s':\n\n",
+		NoExpenses:                  "No expenses",
+		DoneButton:                  "done",
+		BackButton:                  "⬅ back",
+		CancelButton:                "✖ cancel",
+		DialogExpired:               "Input has expired, please start over",
+		DialogCancelled:             "Action cancelled",
+		AmountTooLong:               "The amount is too long",
+		AmountSecondPoint:           "The amount already contains a decimal point",
+		AmountTooManyFraction:       "No more than %d digits after the point are allowed",
+		AmountMisplacedOperator:     "This sign cannot be placed here",
+		AmountNotPositive:           "The amount must be greater than zero",
+		DivisionByZero:              "Division by zero is not allowed",
+		ConfirmAmount:               "%s = %s\n\nConfirm the amount:",
+		ConfirmButton:               "✔ confirm",
+		LanguageName:                "English",
+		SpecifyCurrencyCode:         "Specify an ISO 4217 currency code, e.g.: %s GEL",
+		CurrencySymbolRequired:      "Currency %s is not in the catalog, specify its symbol: /enable_currency %s <symbol>",
+		CurrencyEnabled:             "Currency %s (%s) is available to users!",
+		CurrencyDisabled:            "Currency %s is disabled",
+		CannotDisableServerCurrency: "The base currency cannot be disabled",
+		CannotChangeCurrencyList:    "Cannot change the currency list :(",
+		RatesStillLoading:           "⏳ Exchange rates are still loading (days without rate: %d), amounts are approximate. Request the report a bit later",
+		StaleRatesUsed:              "ℹ️ The last known rate was used for operations without a rate on their date (operations: %d)",
+		MissingRate:                 "Exchange rate is missing for some operations, the report in the selected currency is not available yet. Try later or choose another currency",
+		ConvertUsage:                "Usage: /convert 120 EUR RUB [2026-08-15]",
+		ConvertResult:               "%s = %s\n1 %s = %s %s",
+		ConvertSource:               "%s rate: %s as of %s",
+		ConvertFutureDate:           "There are no rates for future dates, specify today or a past date",
+		ConvertUnknownCurrency:      "Conversion is available only between enabled currencies, see the list: /change_currency",
+		CannotConvert:               "Cannot get the rate for conversion, try later :(",
+		DateLayout:                  "2006-01-02",
+		RatesHeader:                 "Rates on %s:\n\n",
+		RatesLine:                   "%s: %s   7 d: %s   30 d: %s\n",
+		NoRates:                     "There are no rates yet, try later",
+		RatesUsage:                  "Usage: /rates or /rates USD [days]",
+		NoChange:                    "—",
+		RatesHistory:                "%s for %d days:\n%s\nmin %s · max %s · now %s",
+		CannotShowRates:             "Cannot show rates :(",
+		AlertUsage:                  "Usage: /alert USD < 85 or /alert USD > 95",
+		AlertAdded:                  "Alert #%d: I will notify you when %s is %s %s",
+		AlertsHeader:                "Your rate alerts:\n\n",
+		AlertLine:                   "#%d: %s %s %s\n",
+		NoAlerts:                    "No alerts, add one: /alert USD < 85",
+		DeleteAlertUsage:            "Delete an alert: /delete_alert <number>",
+		AlertDeleted:                "Alert #%d deleted",
+		AlertNotFound:               "Alert not found, see the list: /alerts",
+		TooManyAlerts:               "Too many alerts, delete unused ones: /alerts",
+		AlertTriggered:              "🔔 %s is %s %s: now %s",
+		CannotChangeAlerts:          "Cannot change alerts :(",
+		TokenIssued:                 "API token (shown only once, keep it secret):\n\n%s\n\nPass it in the header Authorization: Bearer <token>. Revoke all tokens: /token revoke",
+		TokensRevoked:               "Tokens revoked: %d",
+		TokenUsage:                  "Usage: /token to issue an API token, /token revoke to revoke all tokens",
+		CannotChangeTokens:          "Cannot change tokens :(",
+		WebhookUsage:                "Usage: /webhook https://example.com/hook [event types], types: %s",
+		WebhookAdded:                "Webhook #%d added: %s\nEvents: %s\n\nSigning secret (shown only once, keep it secret):\n\n%s\n\nHeader X-Webhook-Signature holds sha256=HMAC-SHA256(secret, X-Webhook-Timestamp + \".\" + request body)",
+		WebhooksHeader:              "Your webhooks:\n\n",
+		WebhookLine:                 "#%d: %s\nEvents: %s\n",
+		NoWebhooks:                  "No webhooks, add one: /webhook https://example.com/hook LimitExceeded",
+		DeleteWebhookUsage:          "Delete a webhook: /delete_webhook <number>, delivery log: /webhook_log [number]",
+		WebhookLogUsage:             "Delivery log of all webhooks: /webhook_log, of one webhook: /webhook_log <number>",
+		WebhookDeleted:              "Webhook #%d deleted",
+		WebhookNotFound:             "Webhook not found, see the list: /webhooks",
+		TooManyWebhooks:             "Too many webhooks, delete unused ones: /webhooks",
+		InvalidWebhookURL:           "Webhook url must start with https:// and point to a public host",
+		UnknownEventType:            "Unknown event type, available: %s",
+		AllEvents:                   "all",
+		CannotChangeWebhooks:        "Cannot change webhooks :(",
+		WebhookLogHeader:            "Latest webhook deliveries:\n\n",
+		WebhookLogLine:              "%s %s #%d %s, attempt %d: %s\n",
+		NoWebhookDeliveries:         "No deliveries yet",
+		ReceiptRecognized:           "Receipt of %s for %s\n\n",
+		ReceiptAlreadyImported:      "This receipt is already added",
+		InvalidReceipt:              "It does not look like QR code of purchase receipt, expected text like t=20261019T1230&s=1234.56&fn=...&i=...&fp=...&n=1",
+		QRCodeNotFound:              "Cannot find receipt QR code on the photo, send a sharper photo or the text of the QR code",
+		UnsupportedAttachment:       "Send a photo of receipt QR code",
+		ReceiptHint:                 "Send a photo of receipt QR code or its text, e.g.:\n/receipt t=20261019T1230&s=1234.56&fn=9960440300000000&i=12345&fp=1234567890&n=1",
+		HistoryHeader:               "Latest expenses, 📎 marks attached files:\n\n",
+		HistoryLine:                 "%d. %s %s %s%s\n",
+		NoTransactions:              "No expenses yet",
+		AttachUsage:                 "To attach a photo, document or voice message to an expense send /attach <number from /history> and then the file, or send the file with caption /attach <number>",
+		AwaitingAttachment:          "Send a photo, document or voice message for the expense:\n%s",
+		AttachmentSaved:             "The file is attached to the expense, it is available in /history",
+		TransactionNotFound:         "There is no expense with this number, numbers are shown in /history",
+		AttachmentNotFound:          "Nothing is attached to this expense",
+
+		AddOperationCommand:     "add a new expense",
+		ShowCategoryListCommand: "show the category list",
+		SetLimitationCommand:    "set a spending limit (month)",
+		ChangeCurrencyCommand:   "change currency",
+		ShowReportCommand:       "show an expense report for a period",
+		ChangeLanguageCommand:   "change language",
+		ConvertCommand:          "currency converter",
+		RatesCommand:            "exchange rates",
+		AlertsCommand:           "rate alerts",
+		TokenCommand:            "API token",
+		WebhooksCommand:         "webhooks for integrations",
+		ReceiptCommand:          "add an expense from a receipt",
+		HistoryCommand:          "expense history and attachments",
+
+		WeekPeriod:  "Week",
+		MonthPeriod: "Month",
+		YearPeriod:  "Year",
+
+		CBRRateProvider:       "Bank of Russia",
+		ECBRateProvider:       "ECB",
+		AbstractRateProvider:  "Abstract API",
+		CoinGeckoRateProvider: "CoinGecko",
+		UnknownRateProvider:   "source not recorded",
+
+		AlertBelowDirection: "below",
+		AlertAboveDirection: "above",
+	},
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+)
+
+// DefaultLang is used when neither user preference nor telegram language code is known
+const DefaultLang = RU
+
+// fallbackLang is used for telegram language codes which are not supported by the bot
+const fallbackLang = EN
+
+var Supported = []Lang{RU, EN}
+
+type Key string
+
+// Parse validates stored or received language identifier
+func Parse(code string) (Lang, bool) {
+	for _, lang := range Supported {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// FromLanguageCode maps IETF language tag sent by telegram (e.g. "en-US") to supported language
+func FromLanguageCode(code string) Lang {
+	if code == "" {
+		return DefaultLang
+	}
+	primary := strings.ToLower(strings.SplitN(code, "-", 2)[0])
+	if lang, ok := Parse(primary); ok {
+		return lang
+	}
+	return fallbackLang
+}
+
+// Resolve returns user preference if it is set, otherwise language derived from telegram language code
+func Resolve(preferred, languageCode string) Lang {
+	if lang, ok := Parse(preferred); ok {
+		return lang
+	}
+	return FromLanguageCode(languageCode)
+}
+
+func T(lang Lang, key Key, args ...interface{}) string {
+	text, ok := catalog[lang][key]
+	if !ok {
+		if text, ok = catalog[DefaultLang][key]; !ok {
+			return string(key)
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+func Period(lang Lang, period string) string {
+	return T(lang, Key("period."+period))
+}
+
+// RateProvider names provider which published exchange rate, rates stored before providers were recorded have none
+func RateProvider(lang Lang, provider string) string {
+	if provider == "" {
+		return T(lang, UnknownRateProvider)
+	}
+	return T(lang, Key("rate_provider."+provider))
+}
+
+// AlertDirection names direction in which price should cross alert threshold
+func AlertDirection(lang Lang, direction string) string {
+	return T(lang, Key("alert_direction."+direction))
+}
+
+func Name(lang Lang) string {
+	return T(lang, LanguageName)
+}
@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_AllLanguagesHaveSameKeys(t *testing.T) {
+	for _, lang := range Supported {
+		for key := range catalog[DefaultLang] {
+			_, ok := catalog[lang][key]
+			assert.True(t, ok, "missing key '%s' for language '%s'", key, lang)
+		}
+		assert.Equal(t, len(catalog[DefaultLang]), len(catalog[lang]), "unexpected keys for language '%s'", lang)
+	}
+}
+
+func TestFromLanguageCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want Lang
+	}{
+		{code: "", want: DefaultLang},
+		{code: "ru", want: RU},
+		{code: "en", want: EN},
+		{code: "en-US", want: EN},
+		{code: "EN-gb", want: EN},
+		{code: "de", want: EN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, FromLanguageCode(tt.code))
+		})
+	}
+}
+
+func TestResolve_PreferenceOverridesLanguageCode(t *testing.T) {
+	assert.Equal(t, RU, Resolve("ru", "en-US"))
+	assert.Equal(t, EN, Resolve("", "en-US"))
+	assert.Equal(t, EN, Resolve("unknown", "de"))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Week", Period(EN, "week"))
+	assert.Equal(t, "Неделя", Period(RU, "week"))
+	assert.Equal(t, "Currency successfully changed to 'USD'!", T(EN, CurrencyChangedSuccessfully, "USD"))
+	assert.Equal(t, "missing", T(EN, Key("missing")))
+}
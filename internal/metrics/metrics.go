@@ -31,6 +31,21 @@ var (
 		},
 		[]string{"status"},
 	)
+	CacheTierHitCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "cache_tier_hit_counter",
+		},
+		[]string{"tier"},
+	)
+	CacheRemoteAvailableGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "cache_remote_available_gauge",
+		},
+	)
 	RatesSourceCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "ozon",
@@ -39,6 +54,29 @@ var (
 		},
 		[]string{"source"},
 	)
+	RatesProviderHealthGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "rates_provider_health_gauge",
+		},
+		[]string{"provider"},
+	)
+	RatesBackfillPendingGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "rates_backfill_pending_gauge",
+		},
+	)
+	RatesBackfillCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "rates_backfill_counter",
+		},
+		[]string{"status"},
+	)
 	RatesAPICallCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "ozon",
@@ -47,6 +85,74 @@ var (
 		},
 		[]string{"type", "status"},
 	)
+	OutgoingRequestsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "outgoing_requests_counter",
+		},
+		[]string{"client", "outcome"},
+	)
+	OutgoingRequestsHistogramResponseTime = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "outgoing_requests_histogram_response_time_seconds",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5},
+		},
+		[]string{"client"},
+	)
+	// CircuitBreakerStateGauge is 0 when closed, 1 when open and 2 when half-open
+	CircuitBreakerStateGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "circuit_breaker_state",
+		},
+		[]string{"client"},
+	)
+	WorkerPoolQueueGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "worker_pool_queue_gauge",
+		},
+		[]string{"pool"},
+	)
+	// WorkerPoolBlockedCounter counts submissions which waited for free place in full queue
+	WorkerPoolBlockedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "worker_pool_blocked_counter",
+		},
+		[]string{"pool"},
+	)
+	WorkerPoolWaitHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "worker_pool_wait_seconds",
+			Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 5},
+		},
+		[]string{"pool"},
+	)
+	WorkerPoolPanicCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "worker_pool_panic_counter",
+		},
+		[]string{"pool"},
+	)
+	OutboxDeliveryCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ozon",
+			Subsystem: "http",
+			Name:      "outbox_delivery_counter",
+		},
+		[]string{"sink", "event_type", "status"},
+	)
 )
 
 var (
@@ -54,10 +160,10 @@ var (
 	MissLabel = "miss"
 )
 
+// sources of RatesSourceCounter, rates loaded from external api are labeled with provider name
 var (
 	CacheLabel = "cache"
 	DBLabel    = "database"
-	APILabel   = "api"
 )
 
 var (
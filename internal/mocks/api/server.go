@@ -0,0 +1,384 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/api/server.go
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockTokenAuthenticator is a mock of TokenAuthenticator interface.
+type MockTokenAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenAuthenticatorMockRecorder
+}
+
+// MockTokenAuthenticatorMockRecorder is the mock recorder for MockTokenAuthenticator.
+type MockTokenAuthenticatorMockRecorder struct {
+	mock *MockTokenAuthenticator
+}
+
+// NewMockTokenAuthenticator creates a new mock instance.
+func NewMockTokenAuthenticator(ctrl *gomock.Controller) *MockTokenAuthenticator {
+	mock := &MockTokenAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockTokenAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenAuthenticator) EXPECT() *MockTokenAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockTokenAuthenticator) Authenticate(ctx context.Context, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockTokenAuthenticatorMockRecorder) Authenticate(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockTokenAuthenticator)(nil).Authenticate), ctx, token)
+}
+
+// MockOperations is a mock of Operations interface.
+type MockOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationsMockRecorder
+}
+
+// MockOperationsMockRecorder is the mock recorder for MockOperations.
+type MockOperationsMockRecorder struct {
+	mock *MockOperations
+}
+
+// NewMockOperations creates a new mock instance.
+func NewMockOperations(ctrl *gomock.Controller) *MockOperations {
+	mock := &MockOperations{ctrl: ctrl}
+	mock.recorder = &MockOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperations) EXPECT() *MockOperationsMockRecorder {
+	return m.recorder
+}
+
+// AddOperation mocks base method.
+func (m *MockOperations) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string, date time.Time) (model.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOperation", ctx, userID, categoryID, amount, currency, date)
+	ret0, _ := ret[0].(model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOperation indicates an expected call of AddOperation.
+func (mr *MockOperationsMockRecorder) AddOperation(ctx, userID, categoryID, amount, currency, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOperation", reflect.TypeOf((*MockOperations)(nil).AddOperation), ctx, userID, categoryID, amount, currency, date)
+}
+
+// ResolveCurrency mocks base method.
+func (m *MockOperations) ResolveCurrency(ctx context.Context, userID int64, requested string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCurrency", ctx, userID, requested)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCurrency indicates an expected call of ResolveCurrency.
+func (mr *MockOperationsMockRecorder) ResolveCurrency(ctx, userID, requested interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCurrency", reflect.TypeOf((*MockOperations)(nil).ResolveCurrency), ctx, userID, requested)
+}
+
+// SetLimit mocks base method.
+func (m *MockOperations) SetLimit(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string) (model.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimit", ctx, userID, categoryID, amount, currency)
+	ret0, _ := ret[0].(model.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLimit indicates an expected call of SetLimit.
+func (mr *MockOperationsMockRecorder) SetLimit(ctx, userID, categoryID, amount, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimit", reflect.TypeOf((*MockOperations)(nil).SetLimit), ctx, userID, categoryID, amount, currency)
+}
+
+// MockTransactionStore is a mock of TransactionStore interface.
+type MockTransactionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionStoreMockRecorder
+}
+
+// MockTransactionStoreMockRecorder is the mock recorder for MockTransactionStore.
+type MockTransactionStoreMockRecorder struct {
+	mock *MockTransactionStore
+}
+
+// NewMockTransactionStore creates a new mock instance.
+func NewMockTransactionStore(ctrl *gomock.Controller) *MockTransactionStore {
+	mock := &MockTransactionStore{ctrl: ctrl}
+	mock.recorder = &MockTransactionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionStore) EXPECT() *MockTransactionStoreMockRecorder {
+	return m.recorder
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionStore) ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]model.Transaction)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionStoreMockRecorder) ListTransactions(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionStore)(nil).ListTransactions), ctx, userID, limit, offset)
+}
+
+// MockCategoryStore is a mock of CategoryStore interface.
+type MockCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryStoreMockRecorder
+}
+
+// MockCategoryStoreMockRecorder is the mock recorder for MockCategoryStore.
+type MockCategoryStoreMockRecorder struct {
+	mock *MockCategoryStore
+}
+
+// NewMockCategoryStore creates a new mock instance.
+func NewMockCategoryStore(ctrl *gomock.Controller) *MockCategoryStore {
+	mock := &MockCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAllCategories mocks base method.
+func (m *MockCategoryStore) GetAllCategories(ctx context.Context, lang string) ([]model.CategoryData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx, lang)
+	ret0, _ := ret[0].([]model.CategoryData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockCategoryStoreMockRecorder) GetAllCategories(ctx, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryStore)(nil).GetAllCategories), ctx, lang)
+}
+
+// MockLimitationStore is a mock of LimitationStore interface.
+type MockLimitationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitationStoreMockRecorder
+}
+
+// MockLimitationStoreMockRecorder is the mock recorder for MockLimitationStore.
+type MockLimitationStoreMockRecorder struct {
+	mock *MockLimitationStore
+}
+
+// NewMockLimitationStore creates a new mock instance.
+func NewMockLimitationStore(ctrl *gomock.Controller) *MockLimitationStore {
+	mock := &MockLimitationStore{ctrl: ctrl}
+	mock.recorder = &MockLimitationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitationStore) EXPECT() *MockLimitationStoreMockRecorder {
+	return m.recorder
+}
+
+// GetLimits mocks base method.
+func (m *MockLimitationStore) GetLimits(ctx context.Context, userID int64) ([]model.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimits", ctx, userID)
+	ret0, _ := ret[0].([]model.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimits indicates an expected call of GetLimits.
+func (mr *MockLimitationStoreMockRecorder) GetLimits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimits", reflect.TypeOf((*MockLimitationStore)(nil).GetLimits), ctx, userID)
+}
+
+// MockUserStore is a mock of UserStore interface.
+type MockUserStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStoreMockRecorder
+}
+
+// MockUserStoreMockRecorder is the mock recorder for MockUserStore.
+type MockUserStoreMockRecorder struct {
+	mock *MockUserStore
+}
+
+// NewMockUserStore creates a new mock instance.
+func NewMockUserStore(ctrl *gomock.Controller) *MockUserStore {
+	mock := &MockUserStore{ctrl: ctrl}
+	mock.recorder = &MockUserStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
+	return m.recorder
+}
+
+// GetUserLanguage mocks base method.
+func (m *MockUserStore) GetUserLanguage(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLanguage", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLanguage indicates an expected call of GetUserLanguage.
+func (mr *MockUserStoreMockRecorder) GetUserLanguage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLanguage", reflect.TypeOf((*MockUserStore)(nil).GetUserLanguage), ctx, userID)
+}
+
+// SetUserCurrency mocks base method.
+func (m *MockUserStore) SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserCurrency", ctx, userID, newCurrency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserCurrency indicates an expected call of SetUserCurrency.
+func (mr *MockUserStoreMockRecorder) SetUserCurrency(ctx, userID, newCurrency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCurrency", reflect.TypeOf((*MockUserStore)(nil).SetUserCurrency), ctx, userID, newCurrency)
+}
+
+// SetUserLanguage mocks base method.
+func (m *MockUserStore) SetUserLanguage(ctx context.Context, userID int64, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLanguage", ctx, userID, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLanguage indicates an expected call of SetUserLanguage.
+func (mr *MockUserStoreMockRecorder) SetUserLanguage(ctx, userID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLanguage", reflect.TypeOf((*MockUserStore)(nil).SetUserLanguage), ctx, userID, lang)
+}
+
+// MockCalculator is a mock of Calculator interface.
+type MockCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalculatorMockRecorder
+}
+
+// MockCalculatorMockRecorder is the mock recorder for MockCalculator.
+type MockCalculatorMockRecorder struct {
+	mock *MockCalculator
+}
+
+// NewMockCalculator creates a new mock instance.
+func NewMockCalculator(ctrl *gomock.Controller) *MockCalculator {
+	mock := &MockCalculator{ctrl: ctrl}
+	mock.recorder = &MockCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalculator) EXPECT() *MockCalculatorMockRecorder {
+	return m.recorder
+}
+
+// CalcByCurrentMonth mocks base method.
+func (m *MockCalculator) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentMonth", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentMonth indicates an expected call of CalcByCurrentMonth.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentMonth(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentMonth", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentMonth), ctx, userID, currency)
+}
+
+// CalcByCurrentWeek mocks base method.
+func (m *MockCalculator) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentWeek", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentWeek indicates an expected call of CalcByCurrentWeek.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentWeek(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentWeek", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentWeek), ctx, userID, currency)
+}
+
+// CalcByCurrentYear mocks base method.
+func (m *MockCalculator) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentYear", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentYear indicates an expected call of CalcByCurrentYear.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentYear(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentYear", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentYear), ctx, userID, currency)
+}
+
+// InvalidateReports mocks base method.
+func (m *MockCalculator) InvalidateReports(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateReports", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateReports indicates an expected call of InvalidateReports.
+func (mr *MockCalculatorMockRecorder) InvalidateReports(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateReports", reflect.TypeOf((*MockCalculator)(nil).InvalidateReports), userID)
+}
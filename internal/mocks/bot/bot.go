@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/bot/bot.go
+
+// Package mock_bot is a generated GoMock package.
+package mock_bot
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	callbacks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/callbacks"
+	messages "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/messages"
+)
+
+// MockMessenger is a mock of Messenger interface.
+type MockMessenger struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessengerMockRecorder
+}
+
+// MockMessengerMockRecorder is the mock recorder for MockMessenger.
+type MockMessengerMockRecorder struct {
+	mock *MockMessenger
+}
+
+// NewMockMessenger creates a new mock instance.
+func NewMockMessenger(ctrl *gomock.Controller) *MockMessenger {
+	mock := &MockMessenger{ctrl: ctrl}
+	mock.recorder = &MockMessengerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessenger) EXPECT() *MockMessengerMockRecorder {
+	return m.recorder
+}
+
+// DownloadFile mocks base method.
+func (m *MockMessenger) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", ctx, fileID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockMessengerMockRecorder) DownloadFile(ctx, fileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockMessenger)(nil).DownloadFile), ctx, fileID)
+}
+
+// SendAttachment mocks base method.
+func (m *MockMessenger) SendAttachment(attachment model.Attachment, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAttachment", attachment, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAttachment indicates an expected call of SendAttachment.
+func (mr *MockMessengerMockRecorder) SendAttachment(attachment, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAttachment", reflect.TypeOf((*MockMessenger)(nil).SendAttachment), attachment, userID)
+}
+
+// SendEditMessage mocks base method.
+func (m *MockMessenger) SendEditMessage(text string, userID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEditMessage", text, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEditMessage indicates an expected call of SendEditMessage.
+func (mr *MockMessengerMockRecorder) SendEditMessage(text, userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEditMessage", reflect.TypeOf((*MockMessenger)(nil).SendEditMessage), text, userID, messageID)
+}
+
+// SendEditMessageWithMarkupAndText mocks base method.
+func (m *MockMessenger) SendEditMessageWithMarkupAndText(text string, markup [][]model.MarkupData, userID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEditMessageWithMarkupAndText", text, markup, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEditMessageWithMarkupAndText indicates an expected call of SendEditMessageWithMarkupAndText.
+func (mr *MockMessengerMockRecorder) SendEditMessageWithMarkupAndText(text, markup, userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEditMessageWithMarkupAndText", reflect.TypeOf((*MockMessenger)(nil).SendEditMessageWithMarkupAndText), text, markup, userID, messageID)
+}
+
+// SendMessage mocks base method.
+func (m *MockMessenger) SendMessage(text string, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", text, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockMessengerMockRecorder) SendMessage(text, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockMessenger)(nil).SendMessage), text, userID)
+}
+
+// SendMessageWithMarkup mocks base method.
+func (m *MockMessenger) SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageWithMarkup", text, markup, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessageWithMarkup indicates an expected call of SendMessageWithMarkup.
+func (mr *MockMessengerMockRecorder) SendMessageWithMarkup(text, markup, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageWithMarkup", reflect.TypeOf((*MockMessenger)(nil).SendMessageWithMarkup), text, markup, userID)
+}
+
+// MockMessageHandler is a mock of MessageHandler interface.
+type MockMessageHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageHandlerMockRecorder
+}
+
+// MockMessageHandlerMockRecorder is the mock recorder for MockMessageHandler.
+type MockMessageHandlerMockRecorder struct {
+	mock *MockMessageHandler
+}
+
+// NewMockMessageHandler creates a new mock instance.
+func NewMockMessageHandler(ctrl *gomock.Controller) *MockMessageHandler {
+	mock := &MockMessageHandler{ctrl: ctrl}
+	mock.recorder = &MockMessageHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageHandler) EXPECT() *MockMessageHandlerMockRecorder {
+	return m.recorder
+}
+
+// IncomingMessage mocks base method.
+func (m *MockMessageHandler) IncomingMessage(ctx context.Context, msg messages.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncomingMessage", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncomingMessage indicates an expected call of IncomingMessage.
+func (mr *MockMessageHandlerMockRecorder) IncomingMessage(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncomingMessage", reflect.TypeOf((*MockMessageHandler)(nil).IncomingMessage), ctx, msg)
+}
+
+// MockCallbackHandler is a mock of CallbackHandler interface.
+type MockCallbackHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallbackHandlerMockRecorder
+}
+
+// MockCallbackHandlerMockRecorder is the mock recorder for MockCallbackHandler.
+type MockCallbackHandlerMockRecorder struct {
+	mock *MockCallbackHandler
+}
+
+// NewMockCallbackHandler creates a new mock instance.
+func NewMockCallbackHandler(ctrl *gomock.Controller) *MockCallbackHandler {
+	mock := &MockCallbackHandler{ctrl: ctrl}
+	mock.recorder = &MockCallbackHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallbackHandler) EXPECT() *MockCallbackHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleIncomingCallback mocks base method.
+func (m *MockCallbackHandler) HandleIncomingCallback(ctx context.Context, callback callbacks.Callback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleIncomingCallback", ctx, callback)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleIncomingCallback indicates an expected call of HandleIncomingCallback.
+func (mr *MockCallbackHandlerMockRecorder) HandleIncomingCallback(ctx, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleIncomingCallback", reflect.TypeOf((*MockCallbackHandler)(nil).HandleIncomingCallback), ctx, callback)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/callbacks/callback_sender.go
+
+// Package mock_callbacks is a generated GoMock package.
+package mock_callbacks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockCallbackSender is a mock of CallbackSender interface.
+type MockCallbackSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallbackSenderMockRecorder
+}
+
+// MockCallbackSenderMockRecorder is the mock recorder for MockCallbackSender.
+type MockCallbackSenderMockRecorder struct {
+	mock *MockCallbackSender
+}
+
+// NewMockCallbackSender creates a new mock instance.
+func NewMockCallbackSender(ctrl *gomock.Controller) *MockCallbackSender {
+	mock := &MockCallbackSender{ctrl: ctrl}
+	mock.recorder = &MockCallbackSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallbackSender) EXPECT() *MockCallbackSenderMockRecorder {
+	return m.recorder
+}
+
+// SendAttachment mocks base method.
+func (m *MockCallbackSender) SendAttachment(attachment model.Attachment, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAttachment", attachment, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAttachment indicates an expected call of SendAttachment.
+func (mr *MockCallbackSenderMockRecorder) SendAttachment(attachment, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAttachment", reflect.TypeOf((*MockCallbackSender)(nil).SendAttachment), attachment, userID)
+}
+
+// SendEditMessage mocks base method.
+func (m *MockCallbackSender) SendEditMessage(text string, userID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEditMessage", text, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEditMessage indicates an expected call of SendEditMessage.
+func (mr *MockCallbackSenderMockRecorder) SendEditMessage(text, userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEditMessage", reflect.TypeOf((*MockCallbackSender)(nil).SendEditMessage), text, userID, messageID)
+}
+
+// SendEditMessageWithMarkupAndText mocks base method.
+func (m *MockCallbackSender) SendEditMessageWithMarkupAndText(text string, markup [][]model.MarkupData, userID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEditMessageWithMarkupAndText", text, markup, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEditMessageWithMarkupAndText indicates an expected call of SendEditMessageWithMarkupAndText.
+func (mr *MockCallbackSenderMockRecorder) SendEditMessageWithMarkupAndText(text, markup, userID, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEditMessageWithMarkupAndText", reflect.TypeOf((*MockCallbackSender)(nil).SendEditMessageWithMarkupAndText), text, markup, userID, messageID)
+}
+
+// SendMessage mocks base method.
+func (m *MockCallbackSender) SendMessage(text string, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", text, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockCallbackSenderMockRecorder) SendMessage(text, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockCallbackSender)(nil).SendMessage), text, userID)
+}
+
+// SendMessageWithMarkup mocks base method.
+func (m *MockCallbackSender) SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageWithMarkup", text, markup, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessageWithMarkup indicates an expected call of SendMessageWithMarkup.
+func (mr *MockCallbackSenderMockRecorder) SendMessageWithMarkup(text, markup, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageWithMarkup", reflect.TypeOf((*MockCallbackSender)(nil).SendMessageWithMarkup), text, markup, userID)
+}
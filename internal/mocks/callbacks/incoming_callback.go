@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	decimal "github.com/shopspring/decimal"
+	dialog "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
 	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
@@ -52,6 +53,21 @@ func (mr *MockUserStoreMockRecorder) GetUserCurrency(ctx, userID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCurrency", reflect.TypeOf((*MockUserStore)(nil).GetUserCurrency), ctx, userID)
 }
 
+// GetUserLanguage mocks base method.
+func (m *MockUserStore) GetUserLanguage(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLanguage", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLanguage indicates an expected call of GetUserLanguage.
+func (mr *MockUserStoreMockRecorder) GetUserLanguage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLanguage", reflect.TypeOf((*MockUserStore)(nil).GetUserLanguage), ctx, userID)
+}
+
 // SetUserCurrency mocks base method.
 func (m *MockUserStore) SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error {
 	m.ctrl.T.Helper()
@@ -66,6 +82,20 @@ func (mr *MockUserStoreMockRecorder) SetUserCurrency(ctx, userID, newCurrency in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCurrency", reflect.TypeOf((*MockUserStore)(nil).SetUserCurrency), ctx, userID, newCurrency)
 }
 
+// SetUserLanguage mocks base method.
+func (m *MockUserStore) SetUserLanguage(ctx context.Context, userID int64, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLanguage", ctx, userID, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLanguage indicates an expected call of SetUserLanguage.
+func (mr *MockUserStoreMockRecorder) SetUserLanguage(ctx, userID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLanguage", reflect.TypeOf((*MockUserStore)(nil).SetUserLanguage), ctx, userID, lang)
+}
+
 // MockCategoryStore is a mock of CategoryStore interface.
 type MockCategoryStore struct {
 	ctrl     *gomock.Controller
@@ -89,19 +119,72 @@ func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
 	return m.recorder
 }
 
+// GetAllCategories mocks base method.
+func (m *MockCategoryStore) GetAllCategories(ctx context.Context, lang string) ([]model.CategoryData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx, lang)
+	ret0, _ := ret[0].([]model.CategoryData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockCategoryStoreMockRecorder) GetAllCategories(ctx, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryStore)(nil).GetAllCategories), ctx, lang)
+}
+
 // ResolveCategories mocks base method.
-func (m *MockCategoryStore) ResolveCategories(ctx context.Context, IDs []string) (map[string]model.CategoryData, error) {
+func (m *MockCategoryStore) ResolveCategories(ctx context.Context, lang string, IDs []string) (map[string]model.CategoryData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveCategories", ctx, IDs)
+	ret := m.ctrl.Call(m, "ResolveCategories", ctx, lang, IDs)
 	ret0, _ := ret[0].(map[string]model.CategoryData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ResolveCategories indicates an expected call of ResolveCategories.
-func (mr *MockCategoryStoreMockRecorder) ResolveCategories(ctx, IDs interface{}) *gomock.Call {
+func (mr *MockCategoryStoreMockRecorder) ResolveCategories(ctx, lang, IDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCategories", reflect.TypeOf((*MockCategoryStore)(nil).ResolveCategories), ctx, IDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCategories", reflect.TypeOf((*MockCategoryStore)(nil).ResolveCategories), ctx, lang, IDs)
+}
+
+// MockCurrencyStore is a mock of CurrencyStore interface.
+type MockCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyStoreMockRecorder
+}
+
+// MockCurrencyStoreMockRecorder is the mock recorder for MockCurrencyStore.
+type MockCurrencyStoreMockRecorder struct {
+	mock *MockCurrencyStore
+}
+
+// NewMockCurrencyStore creates a new mock instance.
+func NewMockCurrencyStore(ctrl *gomock.Controller) *MockCurrencyStore {
+	mock := &MockCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyStore) EXPECT() *MockCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetCurrency mocks base method.
+func (m *MockCurrencyStore) GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, currencyID)
+	ret0, _ := ret[0].(model.CurrencyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockCurrencyStoreMockRecorder) GetCurrency(ctx, currencyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockCurrencyStore)(nil).GetCurrency), ctx, currencyID)
 }
 
 // MockTransactionStore is a mock of TransactionStore interface.
@@ -128,11 +211,12 @@ func (m *MockTransactionStore) EXPECT() *MockTransactionStoreMockRecorder {
 }
 
 // AddOperation mocks base method.
-func (m *MockTransactionStore) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) error {
+func (m *MockTransactionStore) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddOperation", ctx, userID, categoryID, amount, createdAt)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddOperation indicates an expected call of AddOperation.
@@ -141,6 +225,65 @@ func (mr *MockTransactionStoreMockRecorder) AddOperation(ctx, userID, categoryID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOperation", reflect.TypeOf((*MockTransactionStore)(nil).AddOperation), ctx, userID, categoryID, amount, createdAt)
 }
 
+// AddReceiptOperation mocks base method.
+func (m *MockTransactionStore) AddReceiptOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, receipt model.Receipt) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReceiptOperation", ctx, userID, categoryID, amount, receipt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReceiptOperation indicates an expected call of AddReceiptOperation.
+func (mr *MockTransactionStoreMockRecorder) AddReceiptOperation(ctx, userID, categoryID, amount, receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReceiptOperation", reflect.TypeOf((*MockTransactionStore)(nil).AddReceiptOperation), ctx, userID, categoryID, amount, receipt)
+}
+
+// GetAttachment mocks base method.
+func (m *MockTransactionStore) GetAttachment(ctx context.Context, userID, transactionID int64) (model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, userID, transactionID)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockTransactionStoreMockRecorder) GetAttachment(ctx, userID, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockTransactionStore)(nil).GetAttachment), ctx, userID, transactionID)
+}
+
+// ReceiptImported mocks base method.
+func (m *MockTransactionStore) ReceiptImported(ctx context.Context, userID int64, receipt model.Receipt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiptImported", ctx, userID, receipt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiptImported indicates an expected call of ReceiptImported.
+func (mr *MockTransactionStoreMockRecorder) ReceiptImported(ctx, userID, receipt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptImported", reflect.TypeOf((*MockTransactionStore)(nil).ReceiptImported), ctx, userID, receipt)
+}
+
+// SetAttachment mocks base method.
+func (m *MockTransactionStore) SetAttachment(ctx context.Context, userID, transactionID int64, attachment model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttachment", ctx, userID, transactionID, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttachment indicates an expected call of SetAttachment.
+func (mr *MockTransactionStoreMockRecorder) SetAttachment(ctx, userID, transactionID, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttachment", reflect.TypeOf((*MockTransactionStore)(nil).SetAttachment), ctx, userID, transactionID, attachment)
+}
+
 // MockLimitationRepo is a mock of LimitationRepo interface.
 type MockLimitationRepo struct {
 	ctrl     *gomock.Controller
@@ -255,62 +398,134 @@ func (m *MockCalculator) EXPECT() *MockCalculatorMockRecorder {
 	return m.recorder
 }
 
-// CalcByCurrentMonth mocks base method.
-func (m *MockCalculator) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+// CalcSinceStartOfMonth mocks base method.
+func (m *MockCalculator) CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (model.ReportData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalcByCurrentMonth", ctx, userID, currency)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret := m.ctrl.Call(m, "CalcSinceStartOfMonth", ctx, userID, currency, days)
+	ret0, _ := ret[0].(model.ReportData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CalcByCurrentMonth indicates an expected call of CalcByCurrentMonth.
-func (mr *MockCalculatorMockRecorder) CalcByCurrentMonth(ctx, userID, currency interface{}) *gomock.Call {
+// CalcSinceStartOfMonth indicates an expected call of CalcSinceStartOfMonth.
+func (mr *MockCalculatorMockRecorder) CalcSinceStartOfMonth(ctx, userID, currency, days interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentMonth", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentMonth), ctx, userID, currency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcSinceStartOfMonth", reflect.TypeOf((*MockCalculator)(nil).CalcSinceStartOfMonth), ctx, userID, currency, days)
 }
 
-// CalcByCurrentWeek mocks base method.
-func (m *MockCalculator) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+// InvalidateReports mocks base method.
+func (m *MockCalculator) InvalidateReports(userID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalcByCurrentWeek", ctx, userID, currency)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InvalidateReports", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CalcByCurrentWeek indicates an expected call of CalcByCurrentWeek.
-func (mr *MockCalculatorMockRecorder) CalcByCurrentWeek(ctx, userID, currency interface{}) *gomock.Call {
+// InvalidateReports indicates an expected call of InvalidateReports.
+func (mr *MockCalculatorMockRecorder) InvalidateReports(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentWeek", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentWeek), ctx, userID, currency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateReports", reflect.TypeOf((*MockCalculator)(nil).InvalidateReports), userID)
+}
+
+// MockReportRequester is a mock of ReportRequester interface.
+type MockReportRequester struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRequesterMockRecorder
+}
+
+// MockReportRequesterMockRecorder is the mock recorder for MockReportRequester.
+type MockReportRequesterMockRecorder struct {
+	mock *MockReportRequester
+}
+
+// NewMockReportRequester creates a new mock instance.
+func NewMockReportRequester(ctrl *gomock.Controller) *MockReportRequester {
+	mock := &MockReportRequester{ctrl: ctrl}
+	mock.recorder = &MockReportRequesterMockRecorder{mock}
+	return mock
 }
 
-// CalcByCurrentYear mocks base method.
-func (m *MockCalculator) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRequester) EXPECT() *MockReportRequesterMockRecorder {
+	return m.recorder
+}
+
+// RequestReport mocks base method.
+func (m *MockReportRequester) RequestReport(ctx context.Context, request model.ReportRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalcByCurrentYear", ctx, userID, currency)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RequestReport", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CalcByCurrentYear indicates an expected call of CalcByCurrentYear.
-func (mr *MockCalculatorMockRecorder) CalcByCurrentYear(ctx, userID, currency interface{}) *gomock.Call {
+// RequestReport indicates an expected call of RequestReport.
+func (mr *MockReportRequesterMockRecorder) RequestReport(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentYear", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentYear), ctx, userID, currency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReport", reflect.TypeOf((*MockReportRequester)(nil).RequestReport), ctx, request)
 }
 
-// CalcSinceStartOfMonth mocks base method.
-func (m *MockCalculator) CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (map[string]decimal.Decimal, error) {
+// MockDialogStore is a mock of DialogStore interface.
+type MockDialogStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDialogStoreMockRecorder
+}
+
+// MockDialogStoreMockRecorder is the mock recorder for MockDialogStore.
+type MockDialogStoreMockRecorder struct {
+	mock *MockDialogStore
+}
+
+// NewMockDialogStore creates a new mock instance.
+func NewMockDialogStore(ctrl *gomock.Controller) *MockDialogStore {
+	mock := &MockDialogStore{ctrl: ctrl}
+	mock.recorder = &MockDialogStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDialogStore) EXPECT() *MockDialogStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDialogStore) Get(userID int64) (*dialog.State, bool) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalcSinceStartOfMonth", ctx, userID, currency, days)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
-	ret1, _ := ret[1].(error)
+	ret := m.ctrl.Call(m, "Get", userID)
+	ret0, _ := ret[0].(*dialog.State)
+	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
 
-// CalcSinceStartOfMonth indicates an expected call of CalcSinceStartOfMonth.
-func (mr *MockCalculatorMockRecorder) CalcSinceStartOfMonth(ctx, userID, currency, days interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockDialogStoreMockRecorder) Get(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcSinceStartOfMonth", reflect.TypeOf((*MockCalculator)(nil).CalcSinceStartOfMonth), ctx, userID, currency, days)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDialogStore)(nil).Get), userID)
+}
+
+// Reset mocks base method.
+func (m *MockDialogStore) Reset(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockDialogStoreMockRecorder) Reset(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockDialogStore)(nil).Reset), userID)
+}
+
+// Save mocks base method.
+func (m *MockDialogStore) Save(userID int64, state *dialog.State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", userID, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDialogStoreMockRecorder) Save(userID, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDialogStore)(nil).Save), userID, state)
 }
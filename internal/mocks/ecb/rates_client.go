@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/clients/ecb/rates_client.go
+
+// Package mock_ecb is a generated GoMock package.
+package mock_ecb
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockEuroRateSource is a mock of EuroRateSource interface.
+type MockEuroRateSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockEuroRateSourceMockRecorder
+}
+
+// MockEuroRateSourceMockRecorder is the mock recorder for MockEuroRateSource.
+type MockEuroRateSourceMockRecorder struct {
+	mock *MockEuroRateSource
+}
+
+// NewMockEuroRateSource creates a new mock instance.
+func NewMockEuroRateSource(ctrl *gomock.Controller) *MockEuroRateSource {
+	mock := &MockEuroRateSource{ctrl: ctrl}
+	mock.recorder = &MockEuroRateSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEuroRateSource) EXPECT() *MockEuroRateSourceMockRecorder {
+	return m.recorder
+}
+
+// GetHistoricalCurrency mocks base method.
+func (m *MockEuroRateSource) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalCurrency", ctx, day, currencies)
+	ret0, _ := ret[0].(map[string]model.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalCurrency indicates an expected call of GetHistoricalCurrency.
+func (mr *MockEuroRateSourceMockRecorder) GetHistoricalCurrency(ctx, day, currencies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCurrency", reflect.TypeOf((*MockEuroRateSource)(nil).GetHistoricalCurrency), ctx, day, currencies)
+}
+
+// GetLiveCurrency mocks base method.
+func (m *MockEuroRateSource) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveCurrency", ctx, currencies)
+	ret0, _ := ret[0].(map[string]model.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveCurrency indicates an expected call of GetLiveCurrency.
+func (mr *MockEuroRateSourceMockRecorder) GetLiveCurrency(ctx, currencies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveCurrency", reflect.TypeOf((*MockEuroRateSource)(nil).GetLiveCurrency), ctx, currencies)
+}
@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/grpcserver/server.go
+
+// Package mock_grpcserver is a generated GoMock package.
+package mock_grpcserver
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockOperations is a mock of Operations interface.
+type MockOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationsMockRecorder
+}
+
+// MockOperationsMockRecorder is the mock recorder for MockOperations.
+type MockOperationsMockRecorder struct {
+	mock *MockOperations
+}
+
+// NewMockOperations creates a new mock instance.
+func NewMockOperations(ctrl *gomock.Controller) *MockOperations {
+	mock := &MockOperations{ctrl: ctrl}
+	mock.recorder = &MockOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperations) EXPECT() *MockOperationsMockRecorder {
+	return m.recorder
+}
+
+// AddOperation mocks base method.
+func (m *MockOperations) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string, date time.Time) (model.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOperation", ctx, userID, categoryID, amount, currency, date)
+	ret0, _ := ret[0].(model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOperation indicates an expected call of AddOperation.
+func (mr *MockOperationsMockRecorder) AddOperation(ctx, userID, categoryID, amount, currency, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOperation", reflect.TypeOf((*MockOperations)(nil).AddOperation), ctx, userID, categoryID, amount, currency, date)
+}
+
+// ResolveCurrency mocks base method.
+func (m *MockOperations) ResolveCurrency(ctx context.Context, userID int64, requested string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCurrency", ctx, userID, requested)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCurrency indicates an expected call of ResolveCurrency.
+func (mr *MockOperationsMockRecorder) ResolveCurrency(ctx, userID, requested interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCurrency", reflect.TypeOf((*MockOperations)(nil).ResolveCurrency), ctx, userID, requested)
+}
+
+// SetLimit mocks base method.
+func (m *MockOperations) SetLimit(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, currency string) (model.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimit", ctx, userID, categoryID, amount, currency)
+	ret0, _ := ret[0].(model.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLimit indicates an expected call of SetLimit.
+func (mr *MockOperationsMockRecorder) SetLimit(ctx, userID, categoryID, amount, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimit", reflect.TypeOf((*MockOperations)(nil).SetLimit), ctx, userID, categoryID, amount, currency)
+}
+
+// MockTransactionStore is a mock of TransactionStore interface.
+type MockTransactionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionStoreMockRecorder
+}
+
+// MockTransactionStoreMockRecorder is the mock recorder for MockTransactionStore.
+type MockTransactionStoreMockRecorder struct {
+	mock *MockTransactionStore
+}
+
+// NewMockTransactionStore creates a new mock instance.
+func NewMockTransactionStore(ctrl *gomock.Controller) *MockTransactionStore {
+	mock := &MockTransactionStore{ctrl: ctrl}
+	mock.recorder = &MockTransactionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionStore) EXPECT() *MockTransactionStoreMockRecorder {
+	return m.recorder
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionStore) ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]model.Transaction)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionStoreMockRecorder) ListTransactions(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionStore)(nil).ListTransactions), ctx, userID, limit, offset)
+}
+
+// MockCalculator is a mock of Calculator interface.
+type MockCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalculatorMockRecorder
+}
+
+// MockCalculatorMockRecorder is the mock recorder for MockCalculator.
+type MockCalculatorMockRecorder struct {
+	mock *MockCalculator
+}
+
+// NewMockCalculator creates a new mock instance.
+func NewMockCalculator(ctrl *gomock.Controller) *MockCalculator {
+	mock := &MockCalculator{ctrl: ctrl}
+	mock.recorder = &MockCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalculator) EXPECT() *MockCalculatorMockRecorder {
+	return m.recorder
+}
+
+// CalcByCurrentMonth mocks base method.
+func (m *MockCalculator) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentMonth", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentMonth indicates an expected call of CalcByCurrentMonth.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentMonth(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentMonth", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentMonth), ctx, userID, currency)
+}
+
+// CalcByCurrentWeek mocks base method.
+func (m *MockCalculator) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentWeek", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentWeek indicates an expected call of CalcByCurrentWeek.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentWeek(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentWeek", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentWeek), ctx, userID, currency)
+}
+
+// CalcByCurrentYear mocks base method.
+func (m *MockCalculator) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentYear", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentYear indicates an expected call of CalcByCurrentYear.
+func (mr *MockCalculatorMockRecorder) CalcByCurrentYear(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentYear", reflect.TypeOf((*MockCalculator)(nil).CalcByCurrentYear), ctx, userID, currency)
+}
@@ -7,9 +7,13 @@ package mock_messages
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	i18n "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	iso4217 "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
 )
 
 // MockUserStore is a mock of UserStore interface.
@@ -50,6 +54,21 @@ func (mr *MockUserStoreMockRecorder) GetCurrenciesFilteredByUser(ctx, userID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrenciesFilteredByUser", reflect.TypeOf((*MockUserStore)(nil).GetCurrenciesFilteredByUser), ctx, userID)
 }
 
+// GetUserLanguage mocks base method.
+func (m *MockUserStore) GetUserLanguage(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLanguage", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLanguage indicates an expected call of GetUserLanguage.
+func (mr *MockUserStoreMockRecorder) GetUserLanguage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLanguage", reflect.TypeOf((*MockUserStore)(nil).GetUserLanguage), ctx, userID)
+}
+
 // SetUserCurrency mocks base method.
 func (m *MockUserStore) SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error {
 	m.ctrl.T.Helper()
@@ -88,18 +107,18 @@ func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
 }
 
 // GetAllCategories mocks base method.
-func (m *MockCategoryStore) GetAllCategories(ctx context.Context) ([]model.CategoryData, error) {
+func (m *MockCategoryStore) GetAllCategories(ctx context.Context, lang string) ([]model.CategoryData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCategories", ctx)
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx, lang)
 	ret0, _ := ret[0].([]model.CategoryData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllCategories indicates an expected call of GetAllCategories.
-func (mr *MockCategoryStoreMockRecorder) GetAllCategories(ctx interface{}) *gomock.Call {
+func (mr *MockCategoryStoreMockRecorder) GetAllCategories(ctx, lang interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryStore)(nil).GetAllCategories), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockCategoryStore)(nil).GetAllCategories), ctx, lang)
 }
 
 // MockMessageSender is a mock of MessageSender interface.
@@ -152,3 +171,571 @@ func (mr *MockMessageSenderMockRecorder) SendMessageWithMarkup(text, markup, use
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageWithMarkup", reflect.TypeOf((*MockMessageSender)(nil).SendMessageWithMarkup), text, markup, userID)
 }
+
+// MockDialogHandler is a mock of DialogHandler interface.
+type MockDialogHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDialogHandlerMockRecorder
+}
+
+// MockDialogHandlerMockRecorder is the mock recorder for MockDialogHandler.
+type MockDialogHandlerMockRecorder struct {
+	mock *MockDialogHandler
+}
+
+// NewMockDialogHandler creates a new mock instance.
+func NewMockDialogHandler(ctrl *gomock.Controller) *MockDialogHandler {
+	mock := &MockDialogHandler{ctrl: ctrl}
+	mock.recorder = &MockDialogHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDialogHandler) EXPECT() *MockDialogHandlerMockRecorder {
+	return m.recorder
+}
+
+// AwaitAttachment mocks base method.
+func (m *MockDialogHandler) AwaitAttachment(ctx context.Context, userID, transactionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwaitAttachment", ctx, userID, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AwaitAttachment indicates an expected call of AwaitAttachment.
+func (mr *MockDialogHandlerMockRecorder) AwaitAttachment(ctx, userID, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwaitAttachment", reflect.TypeOf((*MockDialogHandler)(nil).AwaitAttachment), ctx, userID, transactionID)
+}
+
+// HandleTextInput mocks base method.
+func (m *MockDialogHandler) HandleTextInput(ctx context.Context, userID int64, lang i18n.Lang, text string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleTextInput", ctx, userID, lang, text)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleTextInput indicates an expected call of HandleTextInput.
+func (mr *MockDialogHandlerMockRecorder) HandleTextInput(ctx, userID, lang, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTextInput", reflect.TypeOf((*MockDialogHandler)(nil).HandleTextInput), ctx, userID, lang, text)
+}
+
+// PendingAttachment mocks base method.
+func (m *MockDialogHandler) PendingAttachment(ctx context.Context, userID int64) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingAttachment", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// PendingAttachment indicates an expected call of PendingAttachment.
+func (mr *MockDialogHandlerMockRecorder) PendingAttachment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingAttachment", reflect.TypeOf((*MockDialogHandler)(nil).PendingAttachment), ctx, userID)
+}
+
+// ResetDialog mocks base method.
+func (m *MockDialogHandler) ResetDialog(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDialog", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetDialog indicates an expected call of ResetDialog.
+func (mr *MockDialogHandlerMockRecorder) ResetDialog(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDialog", reflect.TypeOf((*MockDialogHandler)(nil).ResetDialog), ctx, userID)
+}
+
+// MockCurrencyAdmin is a mock of CurrencyAdmin interface.
+type MockCurrencyAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyAdminMockRecorder
+}
+
+// MockCurrencyAdminMockRecorder is the mock recorder for MockCurrencyAdmin.
+type MockCurrencyAdminMockRecorder struct {
+	mock *MockCurrencyAdmin
+}
+
+// NewMockCurrencyAdmin creates a new mock instance.
+func NewMockCurrencyAdmin(ctrl *gomock.Controller) *MockCurrencyAdmin {
+	mock := &MockCurrencyAdmin{ctrl: ctrl}
+	mock.recorder = &MockCurrencyAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyAdmin) EXPECT() *MockCurrencyAdminMockRecorder {
+	return m.recorder
+}
+
+// DisableCurrency mocks base method.
+func (m *MockCurrencyAdmin) DisableCurrency(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableCurrency", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableCurrency indicates an expected call of DisableCurrency.
+func (mr *MockCurrencyAdminMockRecorder) DisableCurrency(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableCurrency", reflect.TypeOf((*MockCurrencyAdmin)(nil).DisableCurrency), ctx, code)
+}
+
+// EnableCurrency mocks base method.
+func (m *MockCurrencyAdmin) EnableCurrency(ctx context.Context, code, symbol string) (iso4217.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableCurrency", ctx, code, symbol)
+	ret0, _ := ret[0].(iso4217.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableCurrency indicates an expected call of EnableCurrency.
+func (mr *MockCurrencyAdminMockRecorder) EnableCurrency(ctx, code, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableCurrency", reflect.TypeOf((*MockCurrencyAdmin)(nil).EnableCurrency), ctx, code, symbol)
+}
+
+// IsAdmin mocks base method.
+func (m *MockCurrencyAdmin) IsAdmin(userID int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", userID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdmin indicates an expected call of IsAdmin.
+func (mr *MockCurrencyAdminMockRecorder) IsAdmin(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockCurrencyAdmin)(nil).IsAdmin), userID)
+}
+
+// MockCurrencyConverter is a mock of CurrencyConverter interface.
+type MockCurrencyConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyConverterMockRecorder
+}
+
+// MockCurrencyConverterMockRecorder is the mock recorder for MockCurrencyConverter.
+type MockCurrencyConverterMockRecorder struct {
+	mock *MockCurrencyConverter
+}
+
+// NewMockCurrencyConverter creates a new mock instance.
+func NewMockCurrencyConverter(ctrl *gomock.Controller) *MockCurrencyConverter {
+	mock := &MockCurrencyConverter{ctrl: ctrl}
+	mock.recorder = &MockCurrencyConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyConverter) EXPECT() *MockCurrencyConverterMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockCurrencyConverter) Convert(ctx context.Context, amount decimal.Decimal, from, to string, date time.Time) (model.Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, amount, from, to, date)
+	ret0, _ := ret[0].(model.Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockCurrencyConverterMockRecorder) Convert(ctx, amount, from, to, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockCurrencyConverter)(nil).Convert), ctx, amount, from, to, date)
+}
+
+// MockRateHistory is a mock of RateHistory interface.
+type MockRateHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateHistoryMockRecorder
+}
+
+// MockRateHistoryMockRecorder is the mock recorder for MockRateHistory.
+type MockRateHistoryMockRecorder struct {
+	mock *MockRateHistory
+}
+
+// NewMockRateHistory creates a new mock instance.
+func NewMockRateHistory(ctrl *gomock.Controller) *MockRateHistory {
+	mock := &MockRateHistory{ctrl: ctrl}
+	mock.recorder = &MockRateHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateHistory) EXPECT() *MockRateHistoryMockRecorder {
+	return m.recorder
+}
+
+// History mocks base method.
+func (m *MockRateHistory) History(ctx context.Context, currency string, days int) ([]model.RatePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, currency, days)
+	ret0, _ := ret[0].([]model.RatePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockRateHistoryMockRecorder) History(ctx, currency, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockRateHistory)(nil).History), ctx, currency, days)
+}
+
+// Overview mocks base method.
+func (m *MockRateHistory) Overview(ctx context.Context) ([]model.RateChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Overview", ctx)
+	ret0, _ := ret[0].([]model.RateChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Overview indicates an expected call of Overview.
+func (mr *MockRateHistoryMockRecorder) Overview(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Overview", reflect.TypeOf((*MockRateHistory)(nil).Overview), ctx)
+}
+
+// MockRateAlerts is a mock of RateAlerts interface.
+type MockRateAlerts struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateAlertsMockRecorder
+}
+
+// MockRateAlertsMockRecorder is the mock recorder for MockRateAlerts.
+type MockRateAlertsMockRecorder struct {
+	mock *MockRateAlerts
+}
+
+// NewMockRateAlerts creates a new mock instance.
+func NewMockRateAlerts(ctrl *gomock.Controller) *MockRateAlerts {
+	mock := &MockRateAlerts{ctrl: ctrl}
+	mock.recorder = &MockRateAlertsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateAlerts) EXPECT() *MockRateAlertsMockRecorder {
+	return m.recorder
+}
+
+// AddAlert mocks base method.
+func (m *MockRateAlerts) AddAlert(ctx context.Context, alert model.RateAlert) (model.RateAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlert", ctx, alert)
+	ret0, _ := ret[0].(model.RateAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAlert indicates an expected call of AddAlert.
+func (mr *MockRateAlertsMockRecorder) AddAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlert", reflect.TypeOf((*MockRateAlerts)(nil).AddAlert), ctx, alert)
+}
+
+// DeleteAlert mocks base method.
+func (m *MockRateAlerts) DeleteAlert(ctx context.Context, userID, alertID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlert", ctx, userID, alertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlert indicates an expected call of DeleteAlert.
+func (mr *MockRateAlertsMockRecorder) DeleteAlert(ctx, userID, alertID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlert", reflect.TypeOf((*MockRateAlerts)(nil).DeleteAlert), ctx, userID, alertID)
+}
+
+// GetAlerts mocks base method.
+func (m *MockRateAlerts) GetAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx, userID)
+	ret0, _ := ret[0].([]model.RateAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockRateAlertsMockRecorder) GetAlerts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockRateAlerts)(nil).GetAlerts), ctx, userID)
+}
+
+// MockAPITokens is a mock of APITokens interface.
+type MockAPITokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokensMockRecorder
+}
+
+// MockAPITokensMockRecorder is the mock recorder for MockAPITokens.
+type MockAPITokensMockRecorder struct {
+	mock *MockAPITokens
+}
+
+// NewMockAPITokens creates a new mock instance.
+func NewMockAPITokens(ctrl *gomock.Controller) *MockAPITokens {
+	mock := &MockAPITokens{ctrl: ctrl}
+	mock.recorder = &MockAPITokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokens) EXPECT() *MockAPITokensMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockAPITokens) Issue(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockAPITokensMockRecorder) Issue(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockAPITokens)(nil).Issue), ctx, userID)
+}
+
+// RevokeAll mocks base method.
+func (m *MockAPITokens) RevokeAll(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockAPITokensMockRecorder) RevokeAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockAPITokens)(nil).RevokeAll), ctx, userID)
+}
+
+// MockWebhooks is a mock of Webhooks interface.
+type MockWebhooks struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksMockRecorder
+}
+
+// MockWebhooksMockRecorder is the mock recorder for MockWebhooks.
+type MockWebhooksMockRecorder struct {
+	mock *MockWebhooks
+}
+
+// NewMockWebhooks creates a new mock instance.
+func NewMockWebhooks(ctrl *gomock.Controller) *MockWebhooks {
+	mock := &MockWebhooks{ctrl: ctrl}
+	mock.recorder = &MockWebhooksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooks) EXPECT() *MockWebhooksMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockWebhooks) AddWebhook(ctx context.Context, userID int64, url string, eventTypes []string) (model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", ctx, userID, url, eventTypes)
+	ret0, _ := ret[0].(model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockWebhooksMockRecorder) AddWebhook(ctx, userID, url, eventTypes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockWebhooks)(nil).AddWebhook), ctx, userID, url, eventTypes)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhooks) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhooksMockRecorder) DeleteWebhook(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhooks)(nil).DeleteWebhook), ctx, userID, webhookID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhooks) GetDeliveries(ctx context.Context, userID, webhookID int64) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, userID, webhookID)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhooksMockRecorder) GetDeliveries(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhooks)(nil).GetDeliveries), ctx, userID, webhookID)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhooks) GetWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhooksMockRecorder) GetWebhooks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhooks)(nil).GetWebhooks), ctx, userID)
+}
+
+// MockFileDownloader is a mock of FileDownloader interface.
+type MockFileDownloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileDownloaderMockRecorder
+}
+
+// MockFileDownloaderMockRecorder is the mock recorder for MockFileDownloader.
+type MockFileDownloaderMockRecorder struct {
+	mock *MockFileDownloader
+}
+
+// NewMockFileDownloader creates a new mock instance.
+func NewMockFileDownloader(ctrl *gomock.Controller) *MockFileDownloader {
+	mock := &MockFileDownloader{ctrl: ctrl}
+	mock.recorder = &MockFileDownloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileDownloader) EXPECT() *MockFileDownloaderMockRecorder {
+	return m.recorder
+}
+
+// DownloadFile mocks base method.
+func (m *MockFileDownloader) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", ctx, fileID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockFileDownloaderMockRecorder) DownloadFile(ctx, fileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockFileDownloader)(nil).DownloadFile), ctx, fileID)
+}
+
+// MockReceiptImporter is a mock of ReceiptImporter interface.
+type MockReceiptImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptImporterMockRecorder
+}
+
+// MockReceiptImporterMockRecorder is the mock recorder for MockReceiptImporter.
+type MockReceiptImporterMockRecorder struct {
+	mock *MockReceiptImporter
+}
+
+// NewMockReceiptImporter creates a new mock instance.
+func NewMockReceiptImporter(ctrl *gomock.Controller) *MockReceiptImporter {
+	mock := &MockReceiptImporter{ctrl: ctrl}
+	mock.recorder = &MockReceiptImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptImporter) EXPECT() *MockReceiptImporterMockRecorder {
+	return m.recorder
+}
+
+// StartReceipt mocks base method.
+func (m *MockReceiptImporter) StartReceipt(ctx context.Context, userID int64, lang i18n.Lang, receipt model.Receipt, photo *model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartReceipt", ctx, userID, lang, receipt, photo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartReceipt indicates an expected call of StartReceipt.
+func (mr *MockReceiptImporterMockRecorder) StartReceipt(ctx, userID, lang, receipt, photo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartReceipt", reflect.TypeOf((*MockReceiptImporter)(nil).StartReceipt), ctx, userID, lang, receipt, photo)
+}
+
+// MockTransactionHistory is a mock of TransactionHistory interface.
+type MockTransactionHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionHistoryMockRecorder
+}
+
+// MockTransactionHistoryMockRecorder is the mock recorder for MockTransactionHistory.
+type MockTransactionHistoryMockRecorder struct {
+	mock *MockTransactionHistory
+}
+
+// NewMockTransactionHistory creates a new mock instance.
+func NewMockTransactionHistory(ctrl *gomock.Controller) *MockTransactionHistory {
+	mock := &MockTransactionHistory{ctrl: ctrl}
+	mock.recorder = &MockTransactionHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionHistory) EXPECT() *MockTransactionHistoryMockRecorder {
+	return m.recorder
+}
+
+// ListTransactions mocks base method.
+func (m *MockTransactionHistory) ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]model.Transaction)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockTransactionHistoryMockRecorder) ListTransactions(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockTransactionHistory)(nil).ListTransactions), ctx, userID, limit, offset)
+}
+
+// SetAttachment mocks base method.
+func (m *MockTransactionHistory) SetAttachment(ctx context.Context, userID, transactionID int64, attachment model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttachment", ctx, userID, transactionID, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttachment indicates an expected call of SetAttachment.
+func (mr *MockTransactionHistoryMockRecorder) SetAttachment(ctx, userID, transactionID, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttachment", reflect.TypeOf((*MockTransactionHistory)(nil).SetAttachment), ctx, userID, transactionID, attachment)
+}
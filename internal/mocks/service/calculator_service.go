@@ -10,7 +10,7 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 // MockTransactionStore is a mock of TransactionStore interface.
@@ -37,54 +37,153 @@ func (m *MockTransactionStore) EXPECT() *MockTransactionStoreMockRecorder {
 }
 
 // CalcAmountByPeriod mocks base method.
-func (m *MockTransactionStore) CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string) (map[string]decimal.Decimal, error) {
+func (m *MockTransactionStore) CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string, maxStaleness time.Duration) (model.ReportData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalcAmountByPeriod", ctx, userID, moment, currencyID)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret := m.ctrl.Call(m, "CalcAmountByPeriod", ctx, userID, moment, currencyID, maxStaleness)
+	ret0, _ := ret[0].(model.ReportData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CalcAmountByPeriod indicates an expected call of CalcAmountByPeriod.
-func (mr *MockTransactionStoreMockRecorder) CalcAmountByPeriod(ctx, userID, moment, currencyID interface{}) *gomock.Call {
+func (mr *MockTransactionStoreMockRecorder) CalcAmountByPeriod(ctx, userID, moment, currencyID, maxStaleness interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcAmountByPeriod", reflect.TypeOf((*MockTransactionStore)(nil).CalcAmountByPeriod), ctx, userID, moment, currencyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcAmountByPeriod", reflect.TypeOf((*MockTransactionStore)(nil).CalcAmountByPeriod), ctx, userID, moment, currencyID, maxStaleness)
 }
 
-// MockCurrencyExchanger is a mock of CurrencyExchanger interface.
-type MockCurrencyExchanger struct {
+// MockRateBackfiller is a mock of RateBackfiller interface.
+type MockRateBackfiller struct {
 	ctrl     *gomock.Controller
-	recorder *MockCurrencyExchangerMockRecorder
+	recorder *MockRateBackfillerMockRecorder
 }
 
-// MockCurrencyExchangerMockRecorder is the mock recorder for MockCurrencyExchanger.
-type MockCurrencyExchangerMockRecorder struct {
-	mock *MockCurrencyExchanger
+// MockRateBackfillerMockRecorder is the mock recorder for MockRateBackfiller.
+type MockRateBackfillerMockRecorder struct {
+	mock *MockRateBackfiller
 }
 
-// NewMockCurrencyExchanger creates a new mock instance.
-func NewMockCurrencyExchanger(ctrl *gomock.Controller) *MockCurrencyExchanger {
-	mock := &MockCurrencyExchanger{ctrl: ctrl}
-	mock.recorder = &MockCurrencyExchangerMockRecorder{mock}
+// NewMockRateBackfiller creates a new mock instance.
+func NewMockRateBackfiller(ctrl *gomock.Controller) *MockRateBackfiller {
+	mock := &MockRateBackfiller{ctrl: ctrl}
+	mock.recorder = &MockRateBackfillerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCurrencyExchanger) EXPECT() *MockCurrencyExchangerMockRecorder {
+func (m *MockRateBackfiller) EXPECT() *MockRateBackfillerMockRecorder {
 	return m.recorder
 }
 
-// GetMultiplier mocks base method.
-func (m *MockCurrencyExchanger) GetMultiplier(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error) {
+// Trigger mocks base method.
+func (m *MockRateBackfiller) Trigger() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMultiplier", ctx, currency, date)
-	ret0, _ := ret[0].(decimal.Decimal)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	m.ctrl.Call(m, "Trigger")
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockRateBackfillerMockRecorder) Trigger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockRateBackfiller)(nil).Trigger))
+}
+
+// MockReportGenerations is a mock of ReportGenerations interface.
+type MockReportGenerations struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportGenerationsMockRecorder
+}
+
+// MockReportGenerationsMockRecorder is the mock recorder for MockReportGenerations.
+type MockReportGenerationsMockRecorder struct {
+	mock *MockReportGenerations
+}
+
+// NewMockReportGenerations creates a new mock instance.
+func NewMockReportGenerations(ctrl *gomock.Controller) *MockReportGenerations {
+	mock := &MockReportGenerations{ctrl: ctrl}
+	mock.recorder = &MockReportGenerationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportGenerations) EXPECT() *MockReportGenerationsMockRecorder {
+	return m.recorder
+}
+
+// Bump mocks base method.
+func (m *MockReportGenerations) Bump(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bump", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bump indicates an expected call of Bump.
+func (mr *MockReportGenerationsMockRecorder) Bump(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bump", reflect.TypeOf((*MockReportGenerations)(nil).Bump), userID)
+}
+
+// Get mocks base method.
+func (m *MockReportGenerations) Get(userID int64) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReportGenerationsMockRecorder) Get(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReportGenerations)(nil).Get), userID)
+}
+
+// MockCalculatorConfig is a mock of CalculatorConfig interface.
+type MockCalculatorConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalculatorConfigMockRecorder
+}
+
+// MockCalculatorConfigMockRecorder is the mock recorder for MockCalculatorConfig.
+type MockCalculatorConfigMockRecorder struct {
+	mock *MockCalculatorConfig
+}
+
+// NewMockCalculatorConfig creates a new mock instance.
+func NewMockCalculatorConfig(ctrl *gomock.Controller) *MockCalculatorConfig {
+	mock := &MockCalculatorConfig{ctrl: ctrl}
+	mock.recorder = &MockCalculatorConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalculatorConfig) EXPECT() *MockCalculatorConfigMockRecorder {
+	return m.recorder
+}
+
+// CalcCacheDefaultExpiration mocks base method.
+func (m *MockCalculatorConfig) CalcCacheDefaultExpiration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcCacheDefaultExpiration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// CalcCacheDefaultExpiration indicates an expected call of CalcCacheDefaultExpiration.
+func (mr *MockCalculatorConfigMockRecorder) CalcCacheDefaultExpiration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcCacheDefaultExpiration", reflect.TypeOf((*MockCalculatorConfig)(nil).CalcCacheDefaultExpiration))
+}
+
+// RateMaxStaleness mocks base method.
+func (m *MockCalculatorConfig) RateMaxStaleness() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateMaxStaleness")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
 }
 
-// GetMultiplier indicates an expected call of GetMultiplier.
-func (mr *MockCurrencyExchangerMockRecorder) GetMultiplier(ctx, currency, date interface{}) *gomock.Call {
+// RateMaxStaleness indicates an expected call of RateMaxStaleness.
+func (mr *MockCalculatorConfigMockRecorder) RateMaxStaleness() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiplier", reflect.TypeOf((*MockCurrencyExchanger)(nil).GetMultiplier), ctx, currency, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateMaxStaleness", reflect.TypeOf((*MockCalculatorConfig)(nil).RateMaxStaleness))
 }
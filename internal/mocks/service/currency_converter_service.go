@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/currency_converter_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockRateGetter is a mock of RateGetter interface.
+type MockRateGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateGetterMockRecorder
+}
+
+// MockRateGetterMockRecorder is the mock recorder for MockRateGetter.
+type MockRateGetterMockRecorder struct {
+	mock *MockRateGetter
+}
+
+// NewMockRateGetter creates a new mock instance.
+func NewMockRateGetter(ctrl *gomock.Controller) *MockRateGetter {
+	mock := &MockRateGetter{ctrl: ctrl}
+	mock.recorder = &MockRateGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateGetter) EXPECT() *MockRateGetterMockRecorder {
+	return m.recorder
+}
+
+// GetRate mocks base method.
+func (m *MockRateGetter) GetRate(ctx context.Context, currency string, date time.Time) (model.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, currency, date)
+	ret0, _ := ret[0].(model.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockRateGetterMockRecorder) GetRate(ctx, currency, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockRateGetter)(nil).GetRate), ctx, currency, date)
+}
+
+// MockConversionCurrencyStore is a mock of ConversionCurrencyStore interface.
+type MockConversionCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversionCurrencyStoreMockRecorder
+}
+
+// MockConversionCurrencyStoreMockRecorder is the mock recorder for MockConversionCurrencyStore.
+type MockConversionCurrencyStoreMockRecorder struct {
+	mock *MockConversionCurrencyStore
+}
+
+// NewMockConversionCurrencyStore creates a new mock instance.
+func NewMockConversionCurrencyStore(ctrl *gomock.Controller) *MockConversionCurrencyStore {
+	mock := &MockConversionCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockConversionCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversionCurrencyStore) EXPECT() *MockConversionCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetCurrency mocks base method.
+func (m *MockConversionCurrencyStore) GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, currencyID)
+	ret0, _ := ret[0].(model.CurrencyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockConversionCurrencyStoreMockRecorder) GetCurrency(ctx, currencyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockConversionCurrencyStore)(nil).GetCurrency), ctx, currencyID)
+}
+
+// GetEnabledCurrencies mocks base method.
+func (m *MockConversionCurrencyStore) GetEnabledCurrencies(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledCurrencies", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledCurrencies indicates an expected call of GetEnabledCurrencies.
+func (mr *MockConversionCurrencyStoreMockRecorder) GetEnabledCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledCurrencies", reflect.TypeOf((*MockConversionCurrencyStore)(nil).GetEnabledCurrencies), ctx)
+}
@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 // MockRateStore is a mock of RateStore interface.
@@ -37,10 +38,10 @@ func (m *MockRateStore) EXPECT() *MockRateStoreMockRecorder {
 }
 
 // GetBatch mocks base method.
-func (m *MockRateStore) GetBatch(ctx context.Context, dates []time.Time, currencies []string) (map[string]map[string]decimal.Decimal, error) {
+func (m *MockRateStore) GetBatch(ctx context.Context, dates []time.Time, currencies []string) (map[string]map[string]model.Quote, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBatch", ctx, dates, currencies)
-	ret0, _ := ret[0].(map[string]map[string]decimal.Decimal)
+	ret0, _ := ret[0].(map[string]map[string]model.Quote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -67,7 +68,7 @@ func (mr *MockRateStoreMockRecorder) GetDatesWithoutRate(ctx, id, startedFrom in
 }
 
 // SaveAll mocks base method.
-func (m *MockRateStore) SaveAll(ctx context.Context, rates map[string]decimal.Decimal, date time.Time) error {
+func (m *MockRateStore) SaveAll(ctx context.Context, rates map[string]model.Quote, date time.Time) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveAll", ctx, rates, date)
 	ret0, _ := ret[0].(error)
@@ -104,33 +105,106 @@ func (m *MockCurrencyExtractor) EXPECT() *MockCurrencyExtractorMockRecorder {
 }
 
 // GetHistoricalCurrency mocks base method.
-func (m *MockCurrencyExtractor) GetHistoricalCurrency(ctx context.Context, day time.Time) (map[string]decimal.Decimal, error) {
+func (m *MockCurrencyExtractor) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHistoricalCurrency", ctx, day)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret := m.ctrl.Call(m, "GetHistoricalCurrency", ctx, day, currencies)
+	ret0, _ := ret[0].(map[string]model.Quote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetHistoricalCurrency indicates an expected call of GetHistoricalCurrency.
-func (mr *MockCurrencyExtractorMockRecorder) GetHistoricalCurrency(ctx, day interface{}) *gomock.Call {
+func (mr *MockCurrencyExtractorMockRecorder) GetHistoricalCurrency(ctx, day, currencies interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCurrency", reflect.TypeOf((*MockCurrencyExtractor)(nil).GetHistoricalCurrency), ctx, day)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalCurrency", reflect.TypeOf((*MockCurrencyExtractor)(nil).GetHistoricalCurrency), ctx, day, currencies)
 }
 
 // GetLiveCurrency mocks base method.
-func (m *MockCurrencyExtractor) GetLiveCurrency(ctx context.Context) (map[string]decimal.Decimal, error) {
+func (m *MockCurrencyExtractor) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLiveCurrency", ctx)
-	ret0, _ := ret[0].(map[string]decimal.Decimal)
+	ret := m.ctrl.Call(m, "GetLiveCurrency", ctx, currencies)
+	ret0, _ := ret[0].(map[string]model.Quote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLiveCurrency indicates an expected call of GetLiveCurrency.
-func (mr *MockCurrencyExtractorMockRecorder) GetLiveCurrency(ctx interface{}) *gomock.Call {
+func (mr *MockCurrencyExtractorMockRecorder) GetLiveCurrency(ctx, currencies interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveCurrency", reflect.TypeOf((*MockCurrencyExtractor)(nil).GetLiveCurrency), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveCurrency", reflect.TypeOf((*MockCurrencyExtractor)(nil).GetLiveCurrency), ctx, currencies)
+}
+
+// MockRateListener is a mock of RateListener interface.
+type MockRateListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateListenerMockRecorder
+}
+
+// MockRateListenerMockRecorder is the mock recorder for MockRateListener.
+type MockRateListenerMockRecorder struct {
+	mock *MockRateListener
+}
+
+// NewMockRateListener creates a new mock instance.
+func NewMockRateListener(ctrl *gomock.Controller) *MockRateListener {
+	mock := &MockRateListener{ctrl: ctrl}
+	mock.recorder = &MockRateListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateListener) EXPECT() *MockRateListenerMockRecorder {
+	return m.recorder
+}
+
+// OnLiveRates mocks base method.
+func (m *MockRateListener) OnLiveRates(ctx context.Context, rates map[string]decimal.Decimal) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnLiveRates", ctx, rates)
+}
+
+// OnLiveRates indicates an expected call of OnLiveRates.
+func (mr *MockRateListenerMockRecorder) OnLiveRates(ctx, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnLiveRates", reflect.TypeOf((*MockRateListener)(nil).OnLiveRates), ctx, rates)
+}
+
+// MockCurrencyRegistry is a mock of CurrencyRegistry interface.
+type MockCurrencyRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyRegistryMockRecorder
+}
+
+// MockCurrencyRegistryMockRecorder is the mock recorder for MockCurrencyRegistry.
+type MockCurrencyRegistryMockRecorder struct {
+	mock *MockCurrencyRegistry
+}
+
+// NewMockCurrencyRegistry creates a new mock instance.
+func NewMockCurrencyRegistry(ctrl *gomock.Controller) *MockCurrencyRegistry {
+	mock := &MockCurrencyRegistry{ctrl: ctrl}
+	mock.recorder = &MockCurrencyRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyRegistry) EXPECT() *MockCurrencyRegistryMockRecorder {
+	return m.recorder
+}
+
+// GetEnabledCurrencies mocks base method.
+func (m *MockCurrencyRegistry) GetEnabledCurrencies(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledCurrencies", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledCurrencies indicates an expected call of GetEnabledCurrencies.
+func (mr *MockCurrencyRegistryMockRecorder) GetEnabledCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledCurrencies", reflect.TypeOf((*MockCurrencyRegistry)(nil).GetEnabledCurrencies), ctx)
 }
 
 // MockCache is a mock of Cache interface.
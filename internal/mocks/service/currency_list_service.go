@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/currency_list_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	iso4217 "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+)
+
+// MockCurrencyListStore is a mock of CurrencyListStore interface.
+type MockCurrencyListStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyListStoreMockRecorder
+}
+
+// MockCurrencyListStoreMockRecorder is the mock recorder for MockCurrencyListStore.
+type MockCurrencyListStoreMockRecorder struct {
+	mock *MockCurrencyListStore
+}
+
+// NewMockCurrencyListStore creates a new mock instance.
+func NewMockCurrencyListStore(ctrl *gomock.Controller) *MockCurrencyListStore {
+	mock := &MockCurrencyListStore{ctrl: ctrl}
+	mock.recorder = &MockCurrencyListStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyListStore) EXPECT() *MockCurrencyListStoreMockRecorder {
+	return m.recorder
+}
+
+// DisableCurrency mocks base method.
+func (m *MockCurrencyListStore) DisableCurrency(ctx context.Context, currencyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableCurrency", ctx, currencyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableCurrency indicates an expected call of DisableCurrency.
+func (mr *MockCurrencyListStoreMockRecorder) DisableCurrency(ctx, currencyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableCurrency", reflect.TypeOf((*MockCurrencyListStore)(nil).DisableCurrency), ctx, currencyID)
+}
+
+// EnableCurrency mocks base method.
+func (m *MockCurrencyListStore) EnableCurrency(ctx context.Context, currency iso4217.Currency, symbol string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableCurrency", ctx, currency, symbol)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableCurrency indicates an expected call of EnableCurrency.
+func (mr *MockCurrencyListStoreMockRecorder) EnableCurrency(ctx, currency, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableCurrency", reflect.TypeOf((*MockCurrencyListStore)(nil).EnableCurrency), ctx, currency, symbol)
+}
@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/operation_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockOperationStore is a mock of OperationStore interface.
+type MockOperationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationStoreMockRecorder
+}
+
+// MockOperationStoreMockRecorder is the mock recorder for MockOperationStore.
+type MockOperationStoreMockRecorder struct {
+	mock *MockOperationStore
+}
+
+// NewMockOperationStore creates a new mock instance.
+func NewMockOperationStore(ctrl *gomock.Controller) *MockOperationStore {
+	mock := &MockOperationStore{ctrl: ctrl}
+	mock.recorder = &MockOperationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationStore) EXPECT() *MockOperationStoreMockRecorder {
+	return m.recorder
+}
+
+// AddOperation mocks base method.
+func (m *MockOperationStore) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOperation", ctx, userID, categoryID, amount, createdAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOperation indicates an expected call of AddOperation.
+func (mr *MockOperationStoreMockRecorder) AddOperation(ctx, userID, categoryID, amount, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOperation", reflect.TypeOf((*MockOperationStore)(nil).AddOperation), ctx, userID, categoryID, amount, createdAt)
+}
+
+// MockOperationCategoryStore is a mock of OperationCategoryStore interface.
+type MockOperationCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationCategoryStoreMockRecorder
+}
+
+// MockOperationCategoryStoreMockRecorder is the mock recorder for MockOperationCategoryStore.
+type MockOperationCategoryStoreMockRecorder struct {
+	mock *MockOperationCategoryStore
+}
+
+// NewMockOperationCategoryStore creates a new mock instance.
+func NewMockOperationCategoryStore(ctrl *gomock.Controller) *MockOperationCategoryStore {
+	mock := &MockOperationCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockOperationCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationCategoryStore) EXPECT() *MockOperationCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// ResolveCategories mocks base method.
+func (m *MockOperationCategoryStore) ResolveCategories(ctx context.Context, lang string, IDs []string) (map[string]model.CategoryData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCategories", ctx, lang, IDs)
+	ret0, _ := ret[0].(map[string]model.CategoryData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCategories indicates an expected call of ResolveCategories.
+func (mr *MockOperationCategoryStoreMockRecorder) ResolveCategories(ctx, lang, IDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCategories", reflect.TypeOf((*MockOperationCategoryStore)(nil).ResolveCategories), ctx, lang, IDs)
+}
+
+// MockOperationLimitStore is a mock of OperationLimitStore interface.
+type MockOperationLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationLimitStoreMockRecorder
+}
+
+// MockOperationLimitStoreMockRecorder is the mock recorder for MockOperationLimitStore.
+type MockOperationLimitStoreMockRecorder struct {
+	mock *MockOperationLimitStore
+}
+
+// NewMockOperationLimitStore creates a new mock instance.
+func NewMockOperationLimitStore(ctrl *gomock.Controller) *MockOperationLimitStore {
+	mock := &MockOperationLimitStore{ctrl: ctrl}
+	mock.recorder = &MockOperationLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationLimitStore) EXPECT() *MockOperationLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// AddLimit mocks base method.
+func (m *MockOperationLimitStore) AddLimit(ctx context.Context, userID int64, categoryID string, upperBorder decimal.Decimal, untilDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLimit", ctx, userID, categoryID, upperBorder, untilDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLimit indicates an expected call of AddLimit.
+func (mr *MockOperationLimitStoreMockRecorder) AddLimit(ctx, userID, categoryID, upperBorder, untilDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLimit", reflect.TypeOf((*MockOperationLimitStore)(nil).AddLimit), ctx, userID, categoryID, upperBorder, untilDate)
+}
+
+// MockOperationUserStore is a mock of OperationUserStore interface.
+type MockOperationUserStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationUserStoreMockRecorder
+}
+
+// MockOperationUserStoreMockRecorder is the mock recorder for MockOperationUserStore.
+type MockOperationUserStoreMockRecorder struct {
+	mock *MockOperationUserStore
+}
+
+// NewMockOperationUserStore creates a new mock instance.
+func NewMockOperationUserStore(ctrl *gomock.Controller) *MockOperationUserStore {
+	mock := &MockOperationUserStore{ctrl: ctrl}
+	mock.recorder = &MockOperationUserStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationUserStore) EXPECT() *MockOperationUserStoreMockRecorder {
+	return m.recorder
+}
+
+// GetUserCurrency mocks base method.
+func (m *MockOperationUserStore) GetUserCurrency(ctx context.Context, userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCurrency", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCurrency indicates an expected call of GetUserCurrency.
+func (mr *MockOperationUserStoreMockRecorder) GetUserCurrency(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCurrency", reflect.TypeOf((*MockOperationUserStore)(nil).GetUserCurrency), ctx, userID)
+}
+
+// MockEnabledCurrencyStore is a mock of EnabledCurrencyStore interface.
+type MockEnabledCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnabledCurrencyStoreMockRecorder
+}
+
+// MockEnabledCurrencyStoreMockRecorder is the mock recorder for MockEnabledCurrencyStore.
+type MockEnabledCurrencyStoreMockRecorder struct {
+	mock *MockEnabledCurrencyStore
+}
+
+// NewMockEnabledCurrencyStore creates a new mock instance.
+func NewMockEnabledCurrencyStore(ctrl *gomock.Controller) *MockEnabledCurrencyStore {
+	mock := &MockEnabledCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockEnabledCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnabledCurrencyStore) EXPECT() *MockEnabledCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetEnabledCurrencies mocks base method.
+func (m *MockEnabledCurrencyStore) GetEnabledCurrencies(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledCurrencies", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledCurrencies indicates an expected call of GetEnabledCurrencies.
+func (mr *MockEnabledCurrencyStoreMockRecorder) GetEnabledCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledCurrencies", reflect.TypeOf((*MockEnabledCurrencyStore)(nil).GetEnabledCurrencies), ctx)
+}
+
+// MockMultiplierGetter is a mock of MultiplierGetter interface.
+type MockMultiplierGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultiplierGetterMockRecorder
+}
+
+// MockMultiplierGetterMockRecorder is the mock recorder for MockMultiplierGetter.
+type MockMultiplierGetterMockRecorder struct {
+	mock *MockMultiplierGetter
+}
+
+// NewMockMultiplierGetter creates a new mock instance.
+func NewMockMultiplierGetter(ctrl *gomock.Controller) *MockMultiplierGetter {
+	mock := &MockMultiplierGetter{ctrl: ctrl}
+	mock.recorder = &MockMultiplierGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultiplierGetter) EXPECT() *MockMultiplierGetterMockRecorder {
+	return m.recorder
+}
+
+// GetMultiplier mocks base method.
+func (m *MockMultiplierGetter) GetMultiplier(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiplier", ctx, currency, date)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiplier indicates an expected call of GetMultiplier.
+func (mr *MockMultiplierGetterMockRecorder) GetMultiplier(ctx, currency, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiplier", reflect.TypeOf((*MockMultiplierGetter)(nil).GetMultiplier), ctx, currency, date)
+}
+
+// MockReportInvalidator is a mock of ReportInvalidator interface.
+type MockReportInvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportInvalidatorMockRecorder
+}
+
+// MockReportInvalidatorMockRecorder is the mock recorder for MockReportInvalidator.
+type MockReportInvalidatorMockRecorder struct {
+	mock *MockReportInvalidator
+}
+
+// NewMockReportInvalidator creates a new mock instance.
+func NewMockReportInvalidator(ctrl *gomock.Controller) *MockReportInvalidator {
+	mock := &MockReportInvalidator{ctrl: ctrl}
+	mock.recorder = &MockReportInvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportInvalidator) EXPECT() *MockReportInvalidatorMockRecorder {
+	return m.recorder
+}
+
+// InvalidateReports mocks base method.
+func (m *MockReportInvalidator) InvalidateReports(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateReports", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateReports indicates an expected call of InvalidateReports.
+func (mr *MockReportInvalidatorMockRecorder) InvalidateReports(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateReports", reflect.TypeOf((*MockReportInvalidator)(nil).InvalidateReports), userID)
+}
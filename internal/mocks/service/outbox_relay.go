@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/outbox_relay.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockOutboxStore is a mock of OutboxStore interface.
+type MockOutboxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxStoreMockRecorder
+}
+
+// MockOutboxStoreMockRecorder is the mock recorder for MockOutboxStore.
+type MockOutboxStoreMockRecorder struct {
+	mock *MockOutboxStore
+}
+
+// NewMockOutboxStore creates a new mock instance.
+func NewMockOutboxStore(ctrl *gomock.Controller) *MockOutboxStore {
+	mock := &MockOutboxStore{ctrl: ctrl}
+	mock.recorder = &MockOutboxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxStore) EXPECT() *MockOutboxStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimEvents mocks base method.
+func (m *MockOutboxStore) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvents", ctx, limit, lease)
+	ret0, _ := ret[0].([]model.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvents indicates an expected call of ClaimEvents.
+func (mr *MockOutboxStoreMockRecorder) ClaimEvents(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvents", reflect.TypeOf((*MockOutboxStore)(nil).ClaimEvents), ctx, limit, lease)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxStore) MarkFailed(ctx context.Context, eventID int64, reason string, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, eventID, reason, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxStoreMockRecorder) MarkFailed(ctx, eventID, reason, delay interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxStore)(nil).MarkFailed), ctx, eventID, reason, delay)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxStore) MarkPublished(ctx context.Context, eventID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxStoreMockRecorder) MarkPublished(ctx, eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxStore)(nil).MarkPublished), ctx, eventID)
+}
+
+// PurgePublished mocks base method.
+func (m *MockOutboxStore) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublished", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePublished indicates an expected call of PurgePublished.
+func (mr *MockOutboxStoreMockRecorder) PurgePublished(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublished", reflect.TypeOf((*MockOutboxStore)(nil).PurgePublished), ctx, before)
+}
+
+// MockEventSink is a mock of EventSink interface.
+type MockEventSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventSinkMockRecorder
+}
+
+// MockEventSinkMockRecorder is the mock recorder for MockEventSink.
+type MockEventSinkMockRecorder struct {
+	mock *MockEventSink
+}
+
+// NewMockEventSink creates a new mock instance.
+func NewMockEventSink(ctrl *gomock.Controller) *MockEventSink {
+	mock := &MockEventSink{ctrl: ctrl}
+	mock.recorder = &MockEventSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventSink) EXPECT() *MockEventSinkMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockEventSink) Deliver(ctx context.Context, event model.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockEventSinkMockRecorder) Deliver(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockEventSink)(nil).Deliver), ctx, event)
+}
+
+// Name mocks base method.
+func (m *MockEventSink) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockEventSinkMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockEventSink)(nil).Name))
+}
+
+// MockOutboxConfig is a mock of OutboxConfig interface.
+type MockOutboxConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxConfigMockRecorder
+}
+
+// MockOutboxConfigMockRecorder is the mock recorder for MockOutboxConfig.
+type MockOutboxConfigMockRecorder struct {
+	mock *MockOutboxConfig
+}
+
+// NewMockOutboxConfig creates a new mock instance.
+func NewMockOutboxConfig(ctrl *gomock.Controller) *MockOutboxConfig {
+	mock := &MockOutboxConfig{ctrl: ctrl}
+	mock.recorder = &MockOutboxConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxConfig) EXPECT() *MockOutboxConfigMockRecorder {
+	return m.recorder
+}
+
+// OutboxBatchSize mocks base method.
+func (m *MockOutboxConfig) OutboxBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// OutboxBatchSize indicates an expected call of OutboxBatchSize.
+func (mr *MockOutboxConfigMockRecorder) OutboxBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxBatchSize", reflect.TypeOf((*MockOutboxConfig)(nil).OutboxBatchSize))
+}
+
+// OutboxPollInterval mocks base method.
+func (m *MockOutboxConfig) OutboxPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// OutboxPollInterval indicates an expected call of OutboxPollInterval.
+func (mr *MockOutboxConfigMockRecorder) OutboxPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxPollInterval", reflect.TypeOf((*MockOutboxConfig)(nil).OutboxPollInterval))
+}
+
+// OutboxRetention mocks base method.
+func (m *MockOutboxConfig) OutboxRetention() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxRetention")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// OutboxRetention indicates an expected call of OutboxRetention.
+func (mr *MockOutboxConfigMockRecorder) OutboxRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxRetention", reflect.TypeOf((*MockOutboxConfig)(nil).OutboxRetention))
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/rate_alert_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockRateAlertStore is a mock of RateAlertStore interface.
+type MockRateAlertStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateAlertStoreMockRecorder
+}
+
+// MockRateAlertStoreMockRecorder is the mock recorder for MockRateAlertStore.
+type MockRateAlertStoreMockRecorder struct {
+	mock *MockRateAlertStore
+}
+
+// NewMockRateAlertStore creates a new mock instance.
+func NewMockRateAlertStore(ctrl *gomock.Controller) *MockRateAlertStore {
+	mock := &MockRateAlertStore{ctrl: ctrl}
+	mock.recorder = &MockRateAlertStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateAlertStore) EXPECT() *MockRateAlertStoreMockRecorder {
+	return m.recorder
+}
+
+// AddAlert mocks base method.
+func (m *MockRateAlertStore) AddAlert(ctx context.Context, alert model.RateAlert) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlert", ctx, alert)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAlert indicates an expected call of AddAlert.
+func (mr *MockRateAlertStoreMockRecorder) AddAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlert", reflect.TypeOf((*MockRateAlertStore)(nil).AddAlert), ctx, alert)
+}
+
+// DeleteAlert mocks base method.
+func (m *MockRateAlertStore) DeleteAlert(ctx context.Context, userID, alertID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlert", ctx, userID, alertID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlert indicates an expected call of DeleteAlert.
+func (mr *MockRateAlertStoreMockRecorder) DeleteAlert(ctx, userID, alertID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlert", reflect.TypeOf((*MockRateAlertStore)(nil).DeleteAlert), ctx, userID, alertID)
+}
+
+// GetAlertsByCurrencies mocks base method.
+func (m *MockRateAlertStore) GetAlertsByCurrencies(ctx context.Context, currencies []string) ([]model.RateAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertsByCurrencies", ctx, currencies)
+	ret0, _ := ret[0].([]model.RateAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertsByCurrencies indicates an expected call of GetAlertsByCurrencies.
+func (mr *MockRateAlertStoreMockRecorder) GetAlertsByCurrencies(ctx, currencies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertsByCurrencies", reflect.TypeOf((*MockRateAlertStore)(nil).GetAlertsByCurrencies), ctx, currencies)
+}
+
+// GetUserAlerts mocks base method.
+func (m *MockRateAlertStore) GetUserAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAlerts", ctx, userID)
+	ret0, _ := ret[0].([]model.RateAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAlerts indicates an expected call of GetUserAlerts.
+func (mr *MockRateAlertStoreMockRecorder) GetUserAlerts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAlerts", reflect.TypeOf((*MockRateAlertStore)(nil).GetUserAlerts), ctx, userID)
+}
+
+// MockRateAlertNotifier is a mock of RateAlertNotifier interface.
+type MockRateAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateAlertNotifierMockRecorder
+}
+
+// MockRateAlertNotifierMockRecorder is the mock recorder for MockRateAlertNotifier.
+type MockRateAlertNotifierMockRecorder struct {
+	mock *MockRateAlertNotifier
+}
+
+// NewMockRateAlertNotifier creates a new mock instance.
+func NewMockRateAlertNotifier(ctrl *gomock.Controller) *MockRateAlertNotifier {
+	mock := &MockRateAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockRateAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateAlertNotifier) EXPECT() *MockRateAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyRateAlert mocks base method.
+func (m *MockRateAlertNotifier) NotifyRateAlert(ctx context.Context, alert model.RateAlert, price decimal.Decimal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyRateAlert", ctx, alert, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyRateAlert indicates an expected call of NotifyRateAlert.
+func (mr *MockRateAlertNotifierMockRecorder) NotifyRateAlert(ctx, alert, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyRateAlert", reflect.TypeOf((*MockRateAlertNotifier)(nil).NotifyRateAlert), ctx, alert, price)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/rate_backfill_worker.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockRateGapStore is a mock of RateGapStore interface.
+type MockRateGapStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateGapStoreMockRecorder
+}
+
+// MockRateGapStoreMockRecorder is the mock recorder for MockRateGapStore.
+type MockRateGapStoreMockRecorder struct {
+	mock *MockRateGapStore
+}
+
+// NewMockRateGapStore creates a new mock instance.
+func NewMockRateGapStore(ctrl *gomock.Controller) *MockRateGapStore {
+	mock := &MockRateGapStore{ctrl: ctrl}
+	mock.recorder = &MockRateGapStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateGapStore) EXPECT() *MockRateGapStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAllDatesWithoutRates mocks base method.
+func (m *MockRateGapStore) GetAllDatesWithoutRates(ctx context.Context, startedFrom time.Time, serverCurrency string) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllDatesWithoutRates", ctx, startedFrom, serverCurrency)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllDatesWithoutRates indicates an expected call of GetAllDatesWithoutRates.
+func (mr *MockRateGapStoreMockRecorder) GetAllDatesWithoutRates(ctx, startedFrom, serverCurrency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDatesWithoutRates", reflect.TypeOf((*MockRateGapStore)(nil).GetAllDatesWithoutRates), ctx, startedFrom, serverCurrency)
+}
+
+// SaveAll mocks base method.
+func (m *MockRateGapStore) SaveAll(ctx context.Context, rates map[string]model.Quote, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAll", ctx, rates, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAll indicates an expected call of SaveAll.
+func (mr *MockRateGapStoreMockRecorder) SaveAll(ctx, rates, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAll", reflect.TypeOf((*MockRateGapStore)(nil).SaveAll), ctx, rates, date)
+}
+
+// MockBackfillConfig is a mock of BackfillConfig interface.
+type MockBackfillConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackfillConfigMockRecorder
+}
+
+// MockBackfillConfigMockRecorder is the mock recorder for MockBackfillConfig.
+type MockBackfillConfigMockRecorder struct {
+	mock *MockBackfillConfig
+}
+
+// NewMockBackfillConfig creates a new mock instance.
+func NewMockBackfillConfig(ctrl *gomock.Controller) *MockBackfillConfig {
+	mock := &MockBackfillConfig{ctrl: ctrl}
+	mock.recorder = &MockBackfillConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackfillConfig) EXPECT() *MockBackfillConfigMockRecorder {
+	return m.recorder
+}
+
+// RateBackfillBatchSize mocks base method.
+func (m *MockBackfillConfig) RateBackfillBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateBackfillBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// RateBackfillBatchSize indicates an expected call of RateBackfillBatchSize.
+func (mr *MockBackfillConfigMockRecorder) RateBackfillBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateBackfillBatchSize", reflect.TypeOf((*MockBackfillConfig)(nil).RateBackfillBatchSize))
+}
+
+// RateBackfillInterval mocks base method.
+func (m *MockBackfillConfig) RateBackfillInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateBackfillInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RateBackfillInterval indicates an expected call of RateBackfillInterval.
+func (mr *MockBackfillConfigMockRecorder) RateBackfillInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateBackfillInterval", reflect.TypeOf((*MockBackfillConfig)(nil).RateBackfillInterval))
+}
+
+// RateBackfillRequestInterval mocks base method.
+func (m *MockBackfillConfig) RateBackfillRequestInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateBackfillRequestInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RateBackfillRequestInterval indicates an expected call of RateBackfillRequestInterval.
+func (mr *MockBackfillConfigMockRecorder) RateBackfillRequestInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateBackfillRequestInterval", reflect.TypeOf((*MockBackfillConfig)(nil).RateBackfillRequestInterval))
+}
+
+// RateBackfillRetries mocks base method.
+func (m *MockBackfillConfig) RateBackfillRetries() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateBackfillRetries")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// RateBackfillRetries indicates an expected call of RateBackfillRetries.
+func (mr *MockBackfillConfigMockRecorder) RateBackfillRetries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateBackfillRetries", reflect.TypeOf((*MockBackfillConfig)(nil).RateBackfillRetries))
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/rate_history_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockRateHistoryStore is a mock of RateHistoryStore interface.
+type MockRateHistoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateHistoryStoreMockRecorder
+}
+
+// MockRateHistoryStoreMockRecorder is the mock recorder for MockRateHistoryStore.
+type MockRateHistoryStoreMockRecorder struct {
+	mock *MockRateHistoryStore
+}
+
+// NewMockRateHistoryStore creates a new mock instance.
+func NewMockRateHistoryStore(ctrl *gomock.Controller) *MockRateHistoryStore {
+	mock := &MockRateHistoryStore{ctrl: ctrl}
+	mock.recorder = &MockRateHistoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateHistoryStore) EXPECT() *MockRateHistoryStoreMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockRateHistoryStore) GetHistory(ctx context.Context, currencies []string, startedFrom time.Time) (map[string][]model.RatePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, currencies, startedFrom)
+	ret0, _ := ret[0].(map[string][]model.RatePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockRateHistoryStoreMockRecorder) GetHistory(ctx, currencies, startedFrom interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockRateHistoryStore)(nil).GetHistory), ctx, currencies, startedFrom)
+}
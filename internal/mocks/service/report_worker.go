@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/report_worker.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockReportSender is a mock of ReportSender interface.
+type MockReportSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportSenderMockRecorder
+}
+
+// MockReportSenderMockRecorder is the mock recorder for MockReportSender.
+type MockReportSenderMockRecorder struct {
+	mock *MockReportSender
+}
+
+// NewMockReportSender creates a new mock instance.
+func NewMockReportSender(ctrl *gomock.Controller) *MockReportSender {
+	mock := &MockReportSender{ctrl: ctrl}
+	mock.recorder = &MockReportSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportSender) EXPECT() *MockReportSenderMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *MockReportSender) SendMessage(text string, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", text, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockReportSenderMockRecorder) SendMessage(text, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockReportSender)(nil).SendMessage), text, userID)
+}
+
+// MockReportCalculator is a mock of ReportCalculator interface.
+type MockReportCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportCalculatorMockRecorder
+}
+
+// MockReportCalculatorMockRecorder is the mock recorder for MockReportCalculator.
+type MockReportCalculatorMockRecorder struct {
+	mock *MockReportCalculator
+}
+
+// NewMockReportCalculator creates a new mock instance.
+func NewMockReportCalculator(ctrl *gomock.Controller) *MockReportCalculator {
+	mock := &MockReportCalculator{ctrl: ctrl}
+	mock.recorder = &MockReportCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportCalculator) EXPECT() *MockReportCalculatorMockRecorder {
+	return m.recorder
+}
+
+// CalcByCurrentMonth mocks base method.
+func (m *MockReportCalculator) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentMonth", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentMonth indicates an expected call of CalcByCurrentMonth.
+func (mr *MockReportCalculatorMockRecorder) CalcByCurrentMonth(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentMonth", reflect.TypeOf((*MockReportCalculator)(nil).CalcByCurrentMonth), ctx, userID, currency)
+}
+
+// CalcByCurrentWeek mocks base method.
+func (m *MockReportCalculator) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentWeek", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentWeek indicates an expected call of CalcByCurrentWeek.
+func (mr *MockReportCalculatorMockRecorder) CalcByCurrentWeek(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentWeek", reflect.TypeOf((*MockReportCalculator)(nil).CalcByCurrentWeek), ctx, userID, currency)
+}
+
+// CalcByCurrentYear mocks base method.
+func (m *MockReportCalculator) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcByCurrentYear", ctx, userID, currency)
+	ret0, _ := ret[0].(model.ReportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcByCurrentYear indicates an expected call of CalcByCurrentYear.
+func (mr *MockReportCalculatorMockRecorder) CalcByCurrentYear(ctx, userID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcByCurrentYear", reflect.TypeOf((*MockReportCalculator)(nil).CalcByCurrentYear), ctx, userID, currency)
+}
+
+// MockReportCategoryStore is a mock of ReportCategoryStore interface.
+type MockReportCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportCategoryStoreMockRecorder
+}
+
+// MockReportCategoryStoreMockRecorder is the mock recorder for MockReportCategoryStore.
+type MockReportCategoryStoreMockRecorder struct {
+	mock *MockReportCategoryStore
+}
+
+// NewMockReportCategoryStore creates a new mock instance.
+func NewMockReportCategoryStore(ctrl *gomock.Controller) *MockReportCategoryStore {
+	mock := &MockReportCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockReportCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportCategoryStore) EXPECT() *MockReportCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// ResolveCategories mocks base method.
+func (m *MockReportCategoryStore) ResolveCategories(ctx context.Context, lang string, IDs []string) (map[string]model.CategoryData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCategories", ctx, lang, IDs)
+	ret0, _ := ret[0].(map[string]model.CategoryData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCategories indicates an expected call of ResolveCategories.
+func (mr *MockReportCategoryStoreMockRecorder) ResolveCategories(ctx, lang, IDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCategories", reflect.TypeOf((*MockReportCategoryStore)(nil).ResolveCategories), ctx, lang, IDs)
+}
+
+// MockReportCurrencyStore is a mock of ReportCurrencyStore interface.
+type MockReportCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportCurrencyStoreMockRecorder
+}
+
+// MockReportCurrencyStoreMockRecorder is the mock recorder for MockReportCurrencyStore.
+type MockReportCurrencyStoreMockRecorder struct {
+	mock *MockReportCurrencyStore
+}
+
+// NewMockReportCurrencyStore creates a new mock instance.
+func NewMockReportCurrencyStore(ctrl *gomock.Controller) *MockReportCurrencyStore {
+	mock := &MockReportCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockReportCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportCurrencyStore) EXPECT() *MockReportCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetCurrency mocks base method.
+func (m *MockReportCurrencyStore) GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, currencyID)
+	ret0, _ := ret[0].(model.CurrencyData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockReportCurrencyStoreMockRecorder) GetCurrency(ctx, currencyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockReportCurrencyStore)(nil).GetCurrency), ctx, currencyID)
+}
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/sinks/user_webhook.go
+
+// Package mock_sinks is a generated GoMock package.
+package mock_sinks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// MockUserWebhookStore is a mock of UserWebhookStore interface.
+type MockUserWebhookStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWebhookStoreMockRecorder
+}
+
+// MockUserWebhookStoreMockRecorder is the mock recorder for MockUserWebhookStore.
+type MockUserWebhookStoreMockRecorder struct {
+	mock *MockUserWebhookStore
+}
+
+// NewMockUserWebhookStore creates a new mock instance.
+func NewMockUserWebhookStore(ctrl *gomock.Controller) *MockUserWebhookStore {
+	mock := &MockUserWebhookStore{ctrl: ctrl}
+	mock.recorder = &MockUserWebhookStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWebhookStore) EXPECT() *MockUserWebhookStoreMockRecorder {
+	return m.recorder
+}
+
+// AddDelivery mocks base method.
+func (m *MockUserWebhookStore) AddDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDelivery indicates an expected call of AddDelivery.
+func (mr *MockUserWebhookStoreMockRecorder) AddDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDelivery", reflect.TypeOf((*MockUserWebhookStore)(nil).AddDelivery), ctx, delivery)
+}
+
+// ClaimTasks mocks base method.
+func (m *MockUserWebhookStore) ClaimTasks(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTasks", ctx, limit, lease)
+	ret0, _ := ret[0].([]model.WebhookTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTasks indicates an expected call of ClaimTasks.
+func (mr *MockUserWebhookStoreMockRecorder) ClaimTasks(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTasks", reflect.TypeOf((*MockUserWebhookStore)(nil).ClaimTasks), ctx, limit, lease)
+}
+
+// EnqueueTasks mocks base method.
+func (m *MockUserWebhookStore) EnqueueTasks(ctx context.Context, tasks []model.WebhookTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueTasks", ctx, tasks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueTasks indicates an expected call of EnqueueTasks.
+func (mr *MockUserWebhookStoreMockRecorder) EnqueueTasks(ctx, tasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueTasks", reflect.TypeOf((*MockUserWebhookStore)(nil).EnqueueTasks), ctx, tasks)
+}
+
+// FinishTask mocks base method.
+func (m *MockUserWebhookStore) FinishTask(ctx context.Context, taskID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishTask indicates an expected call of FinishTask.
+func (mr *MockUserWebhookStoreMockRecorder) FinishTask(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTask", reflect.TypeOf((*MockUserWebhookStore)(nil).FinishTask), ctx, taskID)
+}
+
+// GetUserWebhooks mocks base method.
+func (m *MockUserWebhookStore) GetUserWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserWebhooks indicates an expected call of GetUserWebhooks.
+func (mr *MockUserWebhookStoreMockRecorder) GetUserWebhooks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWebhooks", reflect.TypeOf((*MockUserWebhookStore)(nil).GetUserWebhooks), ctx, userID)
+}
+
+// PurgeFinishedTasks mocks base method.
+func (m *MockUserWebhookStore) PurgeFinishedTasks(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFinishedTasks", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFinishedTasks indicates an expected call of PurgeFinishedTasks.
+func (mr *MockUserWebhookStoreMockRecorder) PurgeFinishedTasks(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFinishedTasks", reflect.TypeOf((*MockUserWebhookStore)(nil).PurgeFinishedTasks), ctx, before)
+}
+
+// RetryTask mocks base method.
+func (m *MockUserWebhookStore) RetryTask(ctx context.Context, taskID int64, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTask", ctx, taskID, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryTask indicates an expected call of RetryTask.
+func (mr *MockUserWebhookStoreMockRecorder) RetryTask(ctx, taskID, delay interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTask", reflect.TypeOf((*MockUserWebhookStore)(nil).RetryTask), ctx, taskID, delay)
+}
+
+// MockUserWebhookConfig is a mock of UserWebhookConfig interface.
+type MockUserWebhookConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWebhookConfigMockRecorder
+}
+
+// MockUserWebhookConfigMockRecorder is the mock recorder for MockUserWebhookConfig.
+type MockUserWebhookConfigMockRecorder struct {
+	mock *MockUserWebhookConfig
+}
+
+// NewMockUserWebhookConfig creates a new mock instance.
+func NewMockUserWebhookConfig(ctrl *gomock.Controller) *MockUserWebhookConfig {
+	mock := &MockUserWebhookConfig{ctrl: ctrl}
+	mock.recorder = &MockUserWebhookConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWebhookConfig) EXPECT() *MockUserWebhookConfigMockRecorder {
+	return m.recorder
+}
+
+// OutboxPollInterval mocks base method.
+func (m *MockUserWebhookConfig) OutboxPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// OutboxPollInterval indicates an expected call of OutboxPollInterval.
+func (mr *MockUserWebhookConfigMockRecorder) OutboxPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxPollInterval", reflect.TypeOf((*MockUserWebhookConfig)(nil).OutboxPollInterval))
+}
+
+// OutboxRetention mocks base method.
+func (m *MockUserWebhookConfig) OutboxRetention() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxRetention")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// OutboxRetention indicates an expected call of OutboxRetention.
+func (mr *MockUserWebhookConfigMockRecorder) OutboxRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxRetention", reflect.TypeOf((*MockUserWebhookConfig)(nil).OutboxRetention))
+}
+
+// OutboxWebhookTimeout mocks base method.
+func (m *MockUserWebhookConfig) OutboxWebhookTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxWebhookTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// OutboxWebhookTimeout indicates an expected call of OutboxWebhookTimeout.
+func (mr *MockUserWebhookConfigMockRecorder) OutboxWebhookTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxWebhookTimeout", reflect.TypeOf((*MockUserWebhookConfig)(nil).OutboxWebhookTimeout))
+}
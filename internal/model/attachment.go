@@ -0,0 +1,17 @@
+package model
+
+const (
+	PhotoAttachment    = "photo"
+	DocumentAttachment = "document"
+	VoiceAttachment    = "voice"
+)
+
+// Attachment is file sent by user together with message, the file itself stays in messenger and is
+// downloaded by its id when needed
+type Attachment struct {
+	Kind     string `json:"kind"`
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+}
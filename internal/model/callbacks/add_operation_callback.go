@@ -2,50 +2,48 @@ package callbacks
 
 import (
 	"context"
-	"fmt"
-	"strings"
 	"time"
 
-	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils"
-
 	"github.com/opentracing/opentracing-go"
 
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"go.uber.org/zap"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
 )
 
-func (s *Model) handleAddOperation(ctx context.Context, query *tgbotapi.CallbackQuery, params ...string) error {
+func (s *Model) handleAddOperation(ctx context.Context, callback Callback, params ...string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.AddOperation)
 	defer span.Finish()
 
-	input, err := s.parseCategoryWithAmountInputData(ctx, params, query)
+	err := s.startAmountInput(ctx, constants.AddOperation, callback, params...)
 	if err != nil {
 		span.SetTag("error", err.Error())
-		logger.Error("cannot parse input while adding new operation", zap.Error(err))
+		logger.Error("cannot start entering amount while adding new operation", zap.Error(err))
 		return err
 	}
-	span.SetTag("parse input category", "success")
+	return nil
+}
 
-	if err, needBreak := s.makeProcessOfEnteringAmount(params, input, query); err != nil {
-		span.SetTag("error", err.Error())
-		logger.Error("cannot parse amount while adding new operation", zap.Error(err))
-		return err
-	} else if needBreak {
-		return nil
-	}
-	span.SetTag("parse input amount", "success")
+// addOperation persists expense entered in dialog and checks category limit
+func (s *Model) addOperation(ctx context.Context, input *addOperationInputData) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "addOperation")
+	defer span.Finish()
 
-	multiplier, err := s.rateService.GetMultiplier(ctx, input.Currency, time.Now())
+	date := time.Now()
+	if input.Receipt != nil {
+		date = input.Receipt.Date
+	}
+	multiplier, err := s.rateService.GetMultiplier(ctx, input.Currency, date)
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot get multiplier while adding new operation", zap.Error(err))
-		return s.tgClient.SendEditMessage(fmt.Sprintf(constants.CannotGetRateForYouMsg, constants.ServerCurrency),
-			input.UserID, input.MessageID)
+		return s.tgClient.SendMessage(i18n.T(input.Lang, i18n.CannotGetRateForYou, constants.ServerCurrency), input.UserID)
 	}
 	span.SetTag("got multiplier", multiplier.String())
 
@@ -55,24 +53,37 @@ func (s *Model) handleAddOperation(ctx context.Context, query *tgbotapi.Callback
 	amount := input.Amount.Div(multiplier)
 
 	// resolve categories to display
-	categories, err := s.categoryRepo.ResolveCategories(ctx, []string{input.CategoryID})
+	categories, err := s.categoryRepo.ResolveCategories(ctx, string(input.Lang), []string{input.CategoryID})
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot resolve categories while adding new operation", zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, input.UserID)
+		return s.tgClient.SendMessage(i18n.T(input.Lang, i18n.InternalServerError), input.UserID)
 	}
 
 	// persist data
-	err = s.transactionRepo.AddOperation(ctx, input.UserID, input.CategoryID, amount, time.Now())
+	var transactionID int64
+	if input.Receipt != nil {
+		transactionID, err = s.transactionRepo.AddReceiptOperation(ctx, input.UserID, input.CategoryID, amount, *input.Receipt)
+	} else {
+		transactionID, err = s.transactionRepo.AddOperation(ctx, input.UserID, input.CategoryID, amount, date)
+	}
+	if errors.Is(err, constants.ReceiptAlreadyImportedErr) {
+		s.finishDialog(input.UserID)
+		return s.tgClient.SendEditMessage(i18n.T(input.Lang, i18n.ReceiptAlreadyImported), input.UserID, input.MessageID)
+	}
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot persist data while adding new operation", zap.Error(err))
 		return err
 	}
+	s.finishDialog(input.UserID)
+	if input.Attachment != nil {
+		if err = s.transactionRepo.SetAttachment(ctx, input.UserID, transactionID, *input.Attachment); err != nil {
+			logger.Warn("cannot attach receipt photo", zap.Int64("transactionID", transactionID), zap.Error(err))
+		}
+	}
 
-	s.deleteCacheValueForPeriod(input, 7)
-	s.deleteCacheValueForPeriod(input, 30)
-	s.deleteCacheValueForPeriod(input, 365)
+	_ = s.calcService.InvalidateReports(input.UserID)
 
 	spend, err := s.getSpendSinceStartOfMonth(ctx, input, multiplier)
 	if err != nil {
@@ -88,80 +99,41 @@ func (s *Model) handleAddOperation(ctx context.Context, query *tgbotapi.Callback
 		return err
 	}
 
+	currency := s.getCurrencyData(ctx, input.Currency)
 	if exceeded {
-		amountExceededText := fmt.Sprintf(constants.LimitExceededMsg,
+		amountExceededText := i18n.T(input.Lang, i18n.LimitExceeded,
 			categories[input.CategoryID].Name,
-			input.Amount.Round(2).String(),
-			input.Currency, diff.Mul(multiplier).Round(2).String(), input.Currency)
+			money.Format(input.Lang, input.Amount, currency),
+			money.Format(input.Lang, diff.Mul(multiplier), currency))
 		return s.tgClient.SendEditMessage(amountExceededText, input.UserID, input.MessageID)
 	}
 
 	span.SetTag("adding transaction", "success")
-	transactionAddedText := fmt.Sprintf(constants.TransactionAddedMsg, categories[input.CategoryID].Name, input.Amount.Round(2).String(), input.Currency)
+	transactionAddedText := i18n.T(input.Lang, i18n.TransactionAdded, categories[input.CategoryID].Name,
+		money.Format(input.Lang, input.Amount, currency))
 	return s.tgClient.SendEditMessage(transactionAddedText, input.UserID, input.MessageID)
 }
 
-func (s *Model) deleteCacheValueForPeriod(input *addOperationInputData, days int64) {
-	key := utils.GetCalcCacheKey(input.UserID, input.Currency, days)
-	if err := s.reportCache.Delete(key); err != nil {
-		logger.Warn("cannot delete value from cache for period", zap.Error(err))
-	}
-}
-
 func (s *Model) getSpendSinceStartOfMonth(ctx context.Context, input *addOperationInputData, multiplier decimal.Decimal) (decimal.Decimal, error) {
-	spendByCategories, err := s.calcService.CalcSinceStartOfMonth(ctx, input.UserID, input.Currency, int64(time.Now().Day()))
+	report, err := s.calcService.CalcSinceStartOfMonth(ctx, input.UserID, input.Currency, int64(time.Now().Day()))
 	if err != nil {
 		return decimal.Zero, err
 	}
-	if v, ok := spendByCategories[input.CategoryID]; ok {
+	if v, ok := report.Expenses[input.CategoryID]; ok {
 		return v.Div(multiplier), nil
 	}
 	return decimal.Zero, nil
 }
 
-func (s *Model) makeProcessOfEnteringAmount(params []string, input *addOperationInputData, query *tgbotapi.CallbackQuery) (error, bool) {
-	// process of entering whole amount (accumulation)
-	if params[len(params)-1] != "done" {
-		userMsg := fmt.Sprintf(constants.SpecifyAmountMsg, input.Currency) + strings.Join(params[1:], "")
-		markupData := numericKeyboardAccumulator(query.Data)
-		if len(params) > 1 {
-			return s.tgClient.SendEditMessageWithMarkupAndText(userMsg, markupData, input.UserID, input.MessageID), true
-		} else {
-			return s.tgClient.SendMessageWithMarkup(userMsg, markupData, input.UserID), true
-		}
-	}
-	return nil, false
-}
-
 type addOperationInputData struct {
 	UserID     int64
 	MessageID  int
 	CategoryID string
 	Currency   string
+	Lang       i18n.Lang
 	Amount     decimal.Decimal
-}
-
-func (s *Model) parseCategoryWithAmountInputData(ctx context.Context, params []string, query *tgbotapi.CallbackQuery) (*addOperationInputData, error) {
-	if len(params) == 0 {
-		return nil, emptyCallbackErr
-	}
-	userID := query.From.ID
-	messageID := query.Message.MessageID
-	var amount decimal.Decimal
-	if len(params) > 2 {
-		var err error
-		amount, err = decimal.NewFromString(params[1])
-		if err != nil {
-			return nil, s.tgClient.SendMessage(constants.IncorrectAmountClientMsg, userID)
-		}
-	}
-	return &addOperationInputData{
-		UserID:     userID,
-		MessageID:  messageID,
-		CategoryID: params[0],
-		Currency:   s.getUserCurrency(ctx, userID),
-		Amount:     amount,
-	}, nil
+	Receipt    *model.Receipt
+	Attachment *model.Attachment
 }
 
 func (s *Model) getUserCurrency(ctx context.Context, userID int64) string {
@@ -170,38 +142,3 @@ func (s *Model) getUserCurrency(ctx context.Context, userID int64) string {
 	}
 	return constants.ServerCurrency
 }
-
-func numericKeyboardAccumulator(callback string) [][]model.MarkupData {
-	return [][]model.MarkupData{
-		{
-			numericButton("1", callback),
-			numericButton("2", callback),
-			numericButton("3", callback),
-		},
-		{
-			numericButton("4", callback),
-			numericButton("5", callback),
-			numericButton("6", callback),
-		},
-		{
-			numericButton("7", callback),
-			numericButton("8", callback),
-			numericButton("9", callback),
-		},
-		{
-			numericButton(".", callback),
-			numericButton("0", callback),
-			model.MarkupData{
-				Data: callback + ":done",
-				Text: "done",
-			},
-		},
-	}
-}
-
-func numericButton(text, callback string) model.MarkupData {
-	return model.MarkupData{
-		Text: text,
-		Data: callback + text,
-	}
-}
@@ -0,0 +1,63 @@
+package callbacks
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// handleAttachment sends back file attached to transaction pressed in history
+func (s *Model) handleAttachment(ctx context.Context, callback Callback, params ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Attachment)
+	defer span.Finish()
+
+	if len(params) == 0 {
+		return emptyCallbackErr
+	}
+	transactionID, err := strconv.ParseInt(params[0], 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid transaction id '%s'", params[0])
+	}
+
+	userID := callback.UserID
+	attachment, err := s.transactionRepo.GetAttachment(ctx, userID, transactionID)
+	if errors.Is(err, constants.AttachmentNotFoundErr) {
+		lang := s.getUserLanguage(ctx, userID, callback.LanguageCode)
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.AttachmentNotFound), userID)
+	}
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get attachment",
+			zap.Int64("userID", userID),
+			zap.Int64("transactionID", transactionID),
+			zap.Error(err))
+		lang := s.getUserLanguage(ctx, userID, callback.LanguageCode)
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	return s.tgClient.SendAttachment(attachment, userID)
+}
+
+// AwaitAttachment makes the next file sent by user attached to transaction
+func (s *Model) AwaitAttachment(_ context.Context, userID, transactionID int64) error {
+	return s.dialogs.Save(userID, &dialog.State{
+		Flow:          constants.Attach,
+		Step:          dialog.AttachmentStep,
+		TransactionID: transactionID,
+	})
+}
+
+// PendingAttachment returns transaction waiting for file of user
+func (s *Model) PendingAttachment(_ context.Context, userID int64) (int64, bool) {
+	state, ok := s.dialogs.Get(userID)
+	if !ok || state.Step != dialog.AttachmentStep {
+		return 0, false
+	}
+	return state.TransactionID, true
+}
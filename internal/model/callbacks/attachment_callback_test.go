@@ -0,0 +1,62 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestAttachment_IsSentBack(t *testing.T) {
+	m := newTestModel(t)
+	userID := int64(123)
+	voice := model.Attachment{Kind: model.VoiceAttachment, FileID: "voice"}
+
+	m.transactionRepo.EXPECT().GetAttachment(gomock.Any(), userID, int64(42)).Return(voice, nil)
+	m.sender.EXPECT().SendAttachment(voice, userID)
+
+	err := m.model.HandleIncomingCallback(context.Background(), callbackQuery(userID, 7, "attachment:42"))
+
+	assert.NoError(t, err)
+}
+
+func TestAttachment_OfOtherUserIsNotFound(t *testing.T) {
+	m := newTestModel(t)
+	userID := int64(123)
+
+	m.transactionRepo.EXPECT().GetAttachment(gomock.Any(), userID, int64(42)).
+		Return(model.Attachment{}, errors.Wrap(constants.AttachmentNotFoundErr, "#42"))
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AttachmentNotFound), userID)
+
+	err := m.model.HandleIncomingCallback(context.Background(), callbackQuery(userID, 7, "attachment:42"))
+	assert.NoError(t, err)
+
+	err = m.model.HandleIncomingCallback(context.Background(), callbackQuery(userID, 7, "attachment:x"))
+	assert.Error(t, err)
+}
+
+func TestAttachment_IsAwaitedInDialog(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	state := &dialog.State{Flow: constants.Attach, Step: dialog.AttachmentStep, TransactionID: 42}
+
+	m.dialogs.EXPECT().Save(userID, state)
+	assert.NoError(t, m.model.AwaitAttachment(ctx, userID, 42))
+
+	m.dialogs.EXPECT().Get(userID).Return(state, true)
+	transactionID, ok := m.model.PendingAttachment(ctx, userID)
+	assert.True(t, ok)
+	assert.EqualValues(t, 42, transactionID)
+
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{Flow: constants.AddOperation, Step: dialog.AmountStep}, true)
+	_, ok = m.model.PendingAttachment(ctx, userID)
+	assert.False(t, ok)
+}
@@ -7,4 +7,5 @@ type CallbackSender interface {
 	SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error
 	SendEditMessage(text string, userID int64, messageID int) error
 	SendEditMessageWithMarkupAndText(text string, markup [][]model.MarkupData, userID int64, messageID int) error
+	SendAttachment(attachment model.Attachment, userID int64) error
 }
@@ -2,15 +2,14 @@ package callbacks
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/opentracing/opentracing-go"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 )
 
-func (s *Model) handleChangeCurrency(ctx context.Context, query *tgbotapi.CallbackQuery, params ...string) error {
+func (s *Model) handleChangeCurrency(ctx context.Context, callback Callback, params ...string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.ChangeCurrency)
 	defer span.Finish()
 
@@ -18,12 +17,22 @@ func (s *Model) handleChangeCurrency(ctx context.Context, query *tgbotapi.Callba
 		span.SetTag("error", emptyCallbackErr.Error())
 		return emptyCallbackErr
 	}
-	userID := query.From.ID
-	messageID := query.Message.MessageID
-	err := s.userRepo.SetUserCurrency(ctx, userID, params[0])
+	userID := callback.UserID
+	messageID := callback.MessageID
+	lang := s.getUserLanguage(ctx, callback.UserID, callback.LanguageCode)
+	currency, err := s.currencyRepo.GetCurrency(ctx, params[0])
 	if err != nil {
 		span.SetTag("error", err.Error())
-		return s.tgClient.SendEditMessage(constants.CannotChangeCurrencyMsg, userID, messageID)
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.CannotChangeCurrency), userID, messageID)
 	}
-	return s.tgClient.SendEditMessage(fmt.Sprintf(constants.CurrencyChangedSuccessfullyMsg, params[0]), userID, messageID)
+	if !currency.Enabled { // button of currency disabled after the menu was shown
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.UndefinedCurrency), userID, messageID)
+	}
+	err = s.userRepo.SetUserCurrency(ctx, userID, params[0])
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.CannotChangeCurrency), userID, messageID)
+	}
+	_ = s.calcService.InvalidateReports(userID)
+	return s.tgClient.SendEditMessage(i18n.T(lang, i18n.CurrencyChangedSuccessfully, params[0]), userID, messageID)
 }
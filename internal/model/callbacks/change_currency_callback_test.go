@@ -0,0 +1,41 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestChangeCurrency_EnabledCurrencyIsSet(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(model.CurrencyData{ID: "USD", Enabled: true}, nil)
+	m.userRepo.EXPECT().SetUserCurrency(gomock.Any(), userID, "USD")
+	m.calcService.EXPECT().InvalidateReports(userID)
+	m.sender.EXPECT().SendEditMessage(i18n.T(i18n.EN, i18n.CurrencyChangedSuccessfully, "USD"), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "change_currency:USD"))
+
+	assert.NoError(t, err)
+}
+
+func TestChangeCurrency_DisabledCurrencyIsRejected(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "GEL").Return(model.CurrencyData{ID: "GEL"}, nil)
+	m.sender.EXPECT().SendEditMessage(i18n.T(i18n.EN, i18n.UndefinedCurrency), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "change_currency:GEL"))
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,36 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+func (s *Model) handleChangeLanguage(ctx context.Context, callback Callback, params ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.ChangeLanguage)
+	defer span.Finish()
+
+	if len(params) == 0 {
+		span.SetTag("error", emptyCallbackErr.Error())
+		return emptyCallbackErr
+	}
+	userID := callback.UserID
+	messageID := callback.MessageID
+	newLang, ok := i18n.Parse(params[0])
+	if !ok {
+		span.SetTag("error", "unsupported language")
+		return s.tgClient.SendEditMessage(i18n.T(s.getUserLanguage(ctx, callback.UserID, callback.LanguageCode), i18n.CannotChangeLanguage), userID, messageID)
+	}
+	err := s.userRepo.SetUserLanguage(ctx, userID, string(newLang))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot change user language", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendEditMessage(i18n.T(s.getUserLanguage(ctx, callback.UserID, callback.LanguageCode), i18n.CannotChangeLanguage), userID, messageID)
+	}
+	return s.tgClient.SendEditMessage(i18n.T(newLang, i18n.LanguageChangedSuccessfully, i18n.Name(newLang)), userID, messageID)
+}
@@ -0,0 +1,258 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/keyboards"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/arithmetic"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
+	"go.uber.org/zap"
+)
+
+var (
+	unknownFlowErr       = errors.New("unknown dialog flow")
+	notPositiveAmountErr = errors.New("amount is not positive")
+)
+
+// startAmountInput remembers chosen category and turns category message into numeric keypad
+func (s *Model) startAmountInput(ctx context.Context, flow string, callback Callback, params ...string) error {
+	if len(params) == 0 || params[0] == "" {
+		return emptyCallbackErr
+	}
+	userID := callback.UserID
+	lang := s.getUserLanguage(ctx, userID, callback.LanguageCode)
+	state := &dialog.State{
+		Flow:       flow,
+		Step:       dialog.AmountStep,
+		CategoryID: params[0],
+		MessageID:  callback.MessageID,
+	}
+	if pending, ok := s.dialogs.Get(userID); ok && pending.Receipt != nil && pending.Flow == flow &&
+		pending.Step == dialog.CategoryStep { // category of scanned receipt is chosen
+		state.Receipt, state.Attachment = pending.Receipt, pending.Attachment
+		state.Amount = pending.Receipt.Total.String()
+	}
+	if err := s.dialogs.Save(userID, state); err != nil {
+		logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	return s.showAmountInput(userID, lang, state, s.getDialogCurrencyData(ctx, userID, state), "")
+}
+
+// showAmountInput renders keypad with live preview of entered amount and optional hint about rejected key
+func (s *Model) showAmountInput(userID int64, lang i18n.Lang, state *dialog.State, currency model.CurrencyData, hint string) error {
+	text := i18n.T(lang, i18n.SpecifyAmount, currency.ID) + previewAmount(lang, state.Amount, currency)
+	if hint != "" {
+		text += "\n\n⚠ " + hint
+	}
+	return s.tgClient.SendEditMessageWithMarkupAndText(text, keyboards.Amount(lang), userID, state.MessageID)
+}
+
+// previewAmount shows entered number or expression together with its current value
+func previewAmount(lang i18n.Lang, amount string, currency model.CurrencyData) string {
+	if !arithmetic.IsExpression(amount) {
+		return money.Preview(lang, amount, currency)
+	}
+	preview := arithmetic.Pretty(amount)
+	if result, err := arithmetic.Evaluate(amount, currency.Decimals()); err == nil {
+		preview += " = " + money.Format(lang, result, currency)
+	}
+	return preview
+}
+
+func amountHint(lang i18n.Lang, err error, currency model.CurrencyData) string {
+	switch {
+	case errors.Is(err, dialog.MisplacedOperatorErr):
+		return i18n.T(lang, i18n.AmountMisplacedOperator)
+	case errors.Is(err, arithmetic.DivisionByZeroErr):
+		return i18n.T(lang, i18n.DivisionByZero)
+	case errors.Is(err, notPositiveAmountErr):
+		return i18n.T(lang, i18n.AmountNotPositive)
+	case errors.Is(err, dialog.AmountTooLongErr):
+		return i18n.T(lang, i18n.AmountTooLong)
+	case errors.Is(err, dialog.SecondPointErr):
+		return i18n.T(lang, i18n.AmountSecondPoint)
+	case errors.Is(err, dialog.TooManyFractionErr):
+		return i18n.T(lang, i18n.AmountTooManyFraction, currency.Decimals())
+	}
+	return i18n.T(lang, i18n.IncorrectAmount)
+}
+
+func (s *Model) handleDialog(ctx context.Context, callback Callback, params ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Dialog)
+	defer span.Finish()
+
+	if len(params) == 0 {
+		span.SetTag("error", emptyCallbackErr.Error())
+		return emptyCallbackErr
+	}
+	key := params[0]
+	span.SetTag("key", key)
+
+	userID := callback.UserID
+	messageID := callback.MessageID
+	lang := s.getUserLanguage(ctx, userID, callback.LanguageCode)
+
+	if key == dialog.CancelKey {
+		if err := s.dialogs.Reset(userID); err != nil {
+			logger.Warn("cannot reset dialog state", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.DialogCancelled), userID, messageID)
+	}
+
+	state, ok := s.dialogs.Get(userID)
+	if !ok || state.MessageID != messageID { // keyboard of expired or replaced dialog
+		span.SetTag("result", "dialog expired")
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.DialogExpired), userID, messageID)
+	}
+
+	switch {
+	case key == dialog.BackKey && state.Step == dialog.ConfirmStep:
+		return s.backToAmountInput(ctx, userID, lang, state)
+	case key == dialog.BackKey:
+		return s.backToCategories(ctx, userID, lang, state)
+	case key == dialog.ConfirmKey && state.Step == dialog.ConfirmStep:
+		result, err := decimal.NewFromString(state.Result)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			return s.tgClient.SendEditMessage(i18n.T(lang, i18n.DialogExpired), userID, messageID)
+		}
+		return s.completeAmountInput(ctx, userID, lang, state, result)
+	case state.Step != dialog.AmountStep: // keypad of replaced step
+		span.SetTag("result", "dialog expired")
+		return s.tgClient.SendEditMessage(i18n.T(lang, i18n.DialogExpired), userID, messageID)
+	case key == dialog.DoneKey:
+		return s.submitAmount(ctx, userID, lang, state, state.Amount, true)
+	default:
+		currency := s.getDialogCurrencyData(ctx, userID, state)
+		amount, err := dialog.ApplyKey(state.Amount, key, currency.Decimals())
+		if err != nil {
+			span.SetTag("rejected key", err.Error())
+			return s.showAmountInput(userID, lang, state, currency, amountHint(lang, err, currency))
+		}
+		state.Amount = amount
+		if err = s.dialogs.Save(userID, state); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+		}
+		return s.showAmountInput(userID, lang, state, currency, "")
+	}
+}
+
+// backToAmountInput returns from confirmation to keypad keeping entered expression for editing
+func (s *Model) backToAmountInput(ctx context.Context, userID int64, lang i18n.Lang, state *dialog.State) error {
+	state.Step = dialog.AmountStep
+	state.Result = ""
+	if err := s.dialogs.Save(userID, state); err != nil {
+		logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	return s.showAmountInput(userID, lang, state, s.getDialogCurrencyData(ctx, userID, state), "")
+}
+
+func (s *Model) backToCategories(ctx context.Context, userID int64, lang i18n.Lang, state *dialog.State) error {
+	categories, err := s.categoryRepo.GetAllCategories(ctx, string(lang))
+	if err != nil {
+		logger.Error("cannot get categories while going back in dialog", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	state.Step = dialog.CategoryStep
+	state.CategoryID = ""
+	state.Amount = ""
+	if err = s.dialogs.Save(userID, state); err != nil {
+		logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	return s.tgClient.SendEditMessageWithMarkupAndText(i18n.T(lang, i18n.SpecifyCategory),
+		keyboards.Categories(categories, state.Flow, lang), userID, state.MessageID)
+}
+
+// HandleTextInput accepts amount or expression typed as text message while dialog waits for amount
+func (s *Model) HandleTextInput(ctx context.Context, userID int64, lang i18n.Lang, text string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "HandleTextInput")
+	defer span.Finish()
+
+	state, ok := s.dialogs.Get(userID)
+	if !ok || (state.Step != dialog.AmountStep && state.Step != dialog.ConfirmStep) {
+		return false, nil
+	}
+	span.SetTag("flow", state.Flow)
+	return true, s.submitAmount(ctx, userID, lang, state, arithmetic.Normalize(text), false)
+}
+
+// ResetDialog drops unfinished dialog, e.g. when user sends another command
+func (s *Model) ResetDialog(_ context.Context, userID int64) error {
+	return s.dialogs.Reset(userID)
+}
+
+// submitAmount evaluates entered amount, plain number completes flow at once,
+// result of expression is shown for confirmation first
+func (s *Model) submitAmount(ctx context.Context, userID int64, lang i18n.Lang, state *dialog.State, rawAmount string, fromKeypad bool) error {
+	currency := s.getDialogCurrencyData(ctx, userID, state)
+	amount, err := arithmetic.Evaluate(rawAmount, currency.Decimals())
+	if err == nil && !amount.IsPositive() {
+		err = notPositiveAmountErr
+	}
+	if err != nil {
+		if fromKeypad {
+			return s.showAmountInput(userID, lang, state, currency, amountHint(lang, err, currency))
+		}
+		return s.tgClient.SendMessage(amountHint(lang, err, currency), userID)
+	}
+	if !arithmetic.IsExpression(rawAmount) {
+		if !fromKeypad && state.Step == dialog.AmountStep { // typed amount is kept on keypad in case saving fails
+			state.Amount = rawAmount
+			if err = s.dialogs.Save(userID, state); err != nil {
+				logger.Warn("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+			}
+		}
+		return s.completeAmountInput(ctx, userID, lang, state, amount)
+	}
+
+	state.Step = dialog.ConfirmStep
+	state.Amount = rawAmount
+	state.Result = amount.String()
+	if err = s.dialogs.Save(userID, state); err != nil {
+		logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	text := i18n.T(lang, i18n.ConfirmAmount, arithmetic.Pretty(rawAmount), money.Format(lang, amount, currency))
+	return s.tgClient.SendEditMessageWithMarkupAndText(text, keyboards.ConfirmAmount(lang), userID, state.MessageID)
+}
+
+func (s *Model) completeAmountInput(ctx context.Context, userID int64, lang i18n.Lang, state *dialog.State, amount decimal.Decimal) error {
+	input := &addOperationInputData{
+		UserID:     userID,
+		MessageID:  state.MessageID,
+		CategoryID: state.CategoryID,
+		Currency:   s.getDialogCurrency(ctx, userID, state),
+		Lang:       lang,
+		Amount:     amount,
+		Receipt:    state.Receipt,
+		Attachment: state.Attachment,
+	}
+	switch state.Flow {
+	case constants.AddOperation:
+		return s.addOperation(ctx, input)
+	case constants.SetLimitation:
+		return s.setLimitation(ctx, input)
+	}
+	return unknownFlowErr
+}
+
+// finishDialog drops dialog state once its result is saved, until then failed attempt can be repeated from keypad
+func (s *Model) finishDialog(userID int64) {
+	if err := s.dialogs.Reset(userID); err != nil {
+		logger.Warn("cannot reset finished dialog state", zap.Int64("userID", userID), zap.Error(err))
+	}
+}
@@ -0,0 +1,355 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	callbacksMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/callbacks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/keyboards"
+)
+
+type testModel struct {
+	model           *Model
+	sender          *callbacksMocks.MockCallbackSender
+	transactionRepo *callbacksMocks.MockTransactionStore
+	userRepo        *callbacksMocks.MockUserStore
+	categoryRepo    *callbacksMocks.MockCategoryStore
+	currencyRepo    *callbacksMocks.MockCurrencyStore
+	limitationRepo  *callbacksMocks.MockLimitationRepo
+	rateService     *callbacksMocks.MockCurrencyExchanger
+	calcService     *callbacksMocks.MockCalculator
+	dialogs         *callbacksMocks.MockDialogStore
+	reports         *callbacksMocks.MockReportRequester
+}
+
+func newTestModel(t *testing.T) *testModel {
+	ctrl := gomock.NewController(t)
+	m := &testModel{
+		sender:          callbacksMocks.NewMockCallbackSender(ctrl),
+		transactionRepo: callbacksMocks.NewMockTransactionStore(ctrl),
+		userRepo:        callbacksMocks.NewMockUserStore(ctrl),
+		categoryRepo:    callbacksMocks.NewMockCategoryStore(ctrl),
+		currencyRepo:    callbacksMocks.NewMockCurrencyStore(ctrl),
+		limitationRepo:  callbacksMocks.NewMockLimitationRepo(ctrl),
+		rateService:     callbacksMocks.NewMockCurrencyExchanger(ctrl),
+		calcService:     callbacksMocks.NewMockCalculator(ctrl),
+		dialogs:         callbacksMocks.NewMockDialogStore(ctrl),
+		reports:         callbacksMocks.NewMockReportRequester(ctrl),
+	}
+	m.model = New(m.sender, m.transactionRepo, m.userRepo, m.categoryRepo, m.currencyRepo,
+		m.limitationRepo, m.rateService, m.calcService, m.dialogs, m.reports)
+	return m
+}
+
+type decimalMatcher struct {
+	want decimal.Decimal
+}
+
+func decimalEq(want decimal.Decimal) gomock.Matcher {
+	return decimalMatcher{want: want}
+}
+
+func (m decimalMatcher) Matches(x interface{}) bool {
+	got, ok := x.(decimal.Decimal)
+	return ok && got.Equal(m.want)
+}
+
+func (m decimalMatcher) String() string {
+	return "is equal to decimal " + m.want.String()
+}
+
+func callbackQuery(userID int64, messageID int, data string) Callback {
+	return Callback{UserID: userID, MessageID: messageID, Data: data}
+}
+
+func TestDialog_KeypadAccumulatesAmountOnServerSide(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "12", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "125", MessageID: 7,
+	})
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.RU, i18n.SpecifyAmount, "RUB")+"125\u00a0₽",
+		keyboards.Amount(i18n.RU), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:5"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_InvalidKeyIsRejectedWithHint(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("USD", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "1.5", MessageID: 7,
+	}, true)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(model.CurrencyData{ID: "USD", Symbol: "$"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.SpecifyAmount, "USD")+"$1.5\n\n⚠ "+
+		i18n.T(i18n.EN, i18n.AmountSecondPoint), keyboards.Amount(i18n.EN), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:."))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_KeypadAcceptsCurrencyPrecision(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	btc := model.CurrencyData{ID: "BTC", Symbol: "₿", Precision: 8}
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil).Times(2)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("BTC", nil).Times(2)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "BTC").Return(btc, nil).Times(2)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "0.0001234", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "0.00012345", MessageID: 7,
+	})
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.SpecifyAmount, "BTC")+"₿0.00012345",
+		keyboards.Amount(i18n.EN), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:5"))
+	assert.NoError(t, err)
+
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "0.00012345", MessageID: 7,
+	}, true)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.SpecifyAmount, "BTC")+"₿0.00012345\n\n⚠ "+
+		i18n.T(i18n.EN, i18n.AmountTooManyFraction, 8), keyboards.Amount(i18n.EN), userID, 7)
+
+	err = m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:6"))
+	assert.NoError(t, err)
+}
+
+func TestDialog_Backspace(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "125", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "12", MessageID: 7,
+	})
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.RU, i18n.SpecifyAmount, "RUB")+"12\u00a0₽",
+		keyboards.Amount(i18n.RU), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:backspace"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_KeyOfExpiredDialog(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(nil, false)
+	m.sender.EXPECT().SendEditMessage(i18n.T(i18n.EN, i18n.DialogExpired), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:5"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_Cancel(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("", nil)
+	m.dialogs.EXPECT().Reset(userID)
+	m.sender.EXPECT().SendEditMessage(i18n.T(i18n.RU, i18n.DialogCancelled), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:cancel"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_BackReturnsToCategories(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	categories := []model.CategoryData{{ID: "EDUCATION", Name: "Education"}}
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.SetLimitation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "1", MessageID: 7,
+	}, true)
+	m.categoryRepo.EXPECT().GetAllCategories(gomock.Any(), "en").Return(categories, nil)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{Flow: constants.SetLimitation, Step: dialog.CategoryStep, MessageID: 7})
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.SpecifyCategory),
+		keyboards.Categories(categories, constants.SetLimitation, i18n.EN), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:back"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_TypedAmountCompletesFlow(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.SetLimitation, Step: dialog.AmountStep, CategoryID: "EDUCATION", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.SetLimitation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "1500", MessageID: 7,
+	})
+	m.dialogs.EXPECT().Reset(userID)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil).Times(2)
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "RUB", gomock.Any()).Return(decimal.NewFromInt(1), nil)
+	m.limitationRepo.EXPECT().AddLimit(gomock.Any(), userID, "EDUCATION", decimalEq(decimal.NewFromInt(1500)), gomock.Any())
+	m.calcService.EXPECT().InvalidateReports(userID)
+	m.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "en", []string{"EDUCATION"}).Return(
+		map[string]model.CategoryData{"EDUCATION": {ID: "EDUCATION", Name: "Education"}}, nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil).Times(2)
+	m.sender.EXPECT().SendEditMessage(gomock.Any(), userID, 7)
+
+	handled, err := m.model.HandleTextInput(ctx, userID, i18n.EN, " 1500 ")
+
+	assert.True(t, handled)
+	assert.NoError(t, err)
+}
+
+func TestDialog_FailedSavingKeepsEnteredAmount(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "TAXI", Amount: "300", MessageID: 7,
+	}, true)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("USD", nil).Times(2)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(model.CurrencyData{ID: "USD", Symbol: "$"}, nil)
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "USD", gomock.Any()).Return(decimal.Zero, errors.New("no rate"))
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.CannotGetRateForYou, "RUB"), userID)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:"+dialog.DoneKey))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_TypedTextWithoutDialogIsNotHandled(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.dialogs.EXPECT().Get(userID).Return(nil, false)
+
+	handled, err := m.model.HandleTextInput(ctx, userID, i18n.EN, "1500")
+
+	assert.False(t, handled)
+	assert.NoError(t, err)
+}
+
+func TestDialog_TypedExpressionIsShownForConfirmation(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.ConfirmStep, CategoryID: "EDUCATION", Amount: "1200/3", Result: "400", MessageID: 7,
+	})
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("USD", nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(model.CurrencyData{ID: "USD", Symbol: "$"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.ConfirmAmount, "1200÷3", "$400.00"),
+		keyboards.ConfirmAmount(i18n.EN), userID, 7)
+
+	handled, err := m.model.HandleTextInput(ctx, userID, i18n.EN, "1200 ÷ 3")
+
+	assert.True(t, handled)
+	assert.NoError(t, err)
+}
+
+func TestDialog_ConfirmCompletesFlowWithEvaluatedAmount(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.SetLimitation, Step: dialog.ConfirmStep, CategoryID: "EDUCATION", Amount: "1200/3", Result: "400", MessageID: 7,
+	}, true)
+	m.dialogs.EXPECT().Reset(userID)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil)
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "RUB", gomock.Any()).Return(decimal.NewFromInt(1), nil)
+	m.limitationRepo.EXPECT().AddLimit(gomock.Any(), userID, "EDUCATION", decimalEq(decimal.NewFromInt(400)), gomock.Any())
+	m.calcService.EXPECT().InvalidateReports(userID)
+	m.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "en", []string{"EDUCATION"}).Return(
+		map[string]model.CategoryData{"EDUCATION": {ID: "EDUCATION", Name: "Education"}}, nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendEditMessage(gomock.Any(), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:confirm"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_DivisionByZeroIsRejectedWithHint(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", Amount: "5/0", MessageID: 7,
+	}, true)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.RU, i18n.SpecifyAmount, "RUB")+"5÷0\n\n⚠ "+
+		i18n.T(i18n.RU, i18n.DivisionByZero), keyboards.Amount(i18n.RU), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:done"))
+
+	assert.NoError(t, err)
+}
+
+func TestDialog_NegativeTypedTotalIsRejected(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "EDUCATION", MessageID: 7,
+	}, true)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("RUB", nil)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AmountNotPositive), userID)
+
+	handled, err := m.model.HandleTextInput(ctx, userID, i18n.EN, "100-250")
+
+	assert.True(t, handled)
+	assert.NoError(t, err)
+}
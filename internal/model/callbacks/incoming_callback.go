@@ -9,24 +9,38 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
 )
 
 type UserStore interface {
 	GetUserCurrency(ctx context.Context, userID int64) (currency string, err error)
 	SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error
+	GetUserLanguage(ctx context.Context, userID int64) (string, error)
+	SetUserLanguage(ctx context.Context, userID int64, lang string) error
 }
 
 type CategoryStore interface {
-	ResolveCategories(ctx context.Context, IDs []string) (category map[string]model.CategoryData, err error)
+	GetAllCategories(ctx context.Context, lang string) (category []model.CategoryData, err error)
+	ResolveCategories(ctx context.Context, lang string, IDs []string) (category map[string]model.CategoryData, err error)
+}
+
+type CurrencyStore interface {
+	GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error)
 }
 
 type TransactionStore interface {
-	AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) error
+	AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) (int64, error)
+	AddReceiptOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, receipt model.Receipt) (int64, error)
+	ReceiptImported(ctx context.Context, userID int64, receipt model.Receipt) (bool, error)
+	SetAttachment(ctx context.Context, userID, transactionID int64, attachment model.Attachment) error
+	GetAttachment(ctx context.Context, userID, transactionID int64) (model.Attachment, error)
 }
 
 type LimitationRepo interface {
@@ -38,17 +52,20 @@ type CurrencyExchanger interface {
 	GetMultiplier(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error)
 }
 
-type Cache interface {
-	Get(k string) (string, bool)
-	Add(k string, x string, d time.Duration) error
-	Delete(key string) error
+type Calculator interface {
+	CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (model.ReportData, error)
+	InvalidateReports(userID int64) error
+}
+
+// ReportRequester hands calculation of report over to report worker
+type ReportRequester interface {
+	RequestReport(ctx context.Context, request model.ReportRequest) error
 }
 
-type Calculator interface {
-	CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error)
-	CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error)
-	CalcByCurrentYear(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error)
-	CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (map[string]decimal.Decimal, error)
+type DialogStore interface {
+	Get(userID int64) (*dialog.State, bool)
+	Save(userID int64, state *dialog.State) error
+	Reset(userID int64) error
 }
 
 type Model struct {
@@ -56,23 +73,28 @@ type Model struct {
 	transactionRepo TransactionStore
 	userRepo        UserStore
 	categoryRepo    CategoryStore
+	currencyRepo    CurrencyStore
 	limitationRepo  LimitationRepo
 	rateService     CurrencyExchanger
 	calcService     Calculator
-	reportCache     Cache
+	dialogs         DialogStore
+	reports         ReportRequester
 }
 
 func New(tgClient CallbackSender, transactionRepo TransactionStore, userRepo UserStore, categoryRepo CategoryStore,
-	limitationRepo LimitationRepo, rateService CurrencyExchanger, calcService Calculator, reportCache Cache) *Model {
+	currencyRepo CurrencyStore, limitationRepo LimitationRepo, rateService CurrencyExchanger, calcService Calculator,
+	dialogs DialogStore, reports ReportRequester) *Model {
 	return &Model{
 		tgClient:        tgClient,
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
+		currencyRepo:    currencyRepo,
 		userRepo:        userRepo,
 		limitationRepo:  limitationRepo,
 		rateService:     rateService,
 		calcService:     calcService,
-		reportCache:     reportCache,
+		dialogs:         dialogs,
+		reports:         reports,
 	}
 }
 
@@ -80,12 +102,20 @@ var (
 	emptyCallbackErr = errors.New("empty callback data")
 )
 
-func (s *Model) HandleIncomingCallback(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+// Callback is press of inline button, MessageID identifies message which the button belongs to
+type Callback struct {
+	UserID       int64
+	MessageID    int
+	LanguageCode string
+	Data         string
+}
+
+func (s *Model) HandleIncomingCallback(ctx context.Context, callback Callback) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "HandleIncomingCallback")
 	defer span.Finish()
 
-	span.SetTag("userID", query.From.ID)
-	span.SetTag("messageID", query.Message.MessageID)
+	span.SetTag("userID", callback.UserID)
+	span.SetTag("messageID", callback.MessageID)
 
 	modelType := "callback"
 	operation := "unrecognized"
@@ -97,7 +127,7 @@ func (s *Model) HandleIncomingCallback(ctx context.Context, query *tgbotapi.Call
 		metrics.IncomingRequestsHistogramResponseTime.WithLabelValues(modelType, operation, status).Observe(tookTime)
 	}()
 
-	split := strings.Split(query.Data, ":")
+	split := strings.Split(callback.Data, ":")
 	if len(split) == 0 {
 		status = "error"
 		return emptyCallbackErr
@@ -106,13 +136,19 @@ func (s *Model) HandleIncomingCallback(ctx context.Context, query *tgbotapi.Call
 	var err error
 	switch operation {
 	case constants.AddOperation:
-		err = s.handleAddOperation(ctx, query, split[1:]...)
+		err = s.handleAddOperation(ctx, callback, split[1:]...)
 	case constants.SetLimitation:
-		err = s.handleSetLimitation(ctx, query, split[1:]...)
+		err = s.handleSetLimitation(ctx, callback, split[1:]...)
 	case constants.ShowReport:
-		err = s.handleShowReport(ctx, query, split[1:]...)
+		err = s.handleShowReport(ctx, callback, split[1:]...)
 	case constants.ChangeCurrency:
-		err = s.handleChangeCurrency(ctx, query, split[1:]...)
+		err = s.handleChangeCurrency(ctx, callback, split[1:]...)
+	case constants.ChangeLanguage:
+		err = s.handleChangeLanguage(ctx, callback, split[1:]...)
+	case constants.Dialog:
+		err = s.handleDialog(ctx, callback, split[1:]...)
+	case constants.Attachment:
+		err = s.handleAttachment(ctx, callback, split[1:]...)
 	default:
 		operation = "unrecognized"
 	}
@@ -121,3 +157,42 @@ func (s *Model) HandleIncomingCallback(ctx context.Context, query *tgbotapi.Call
 	}
 	return err
 }
+
+// getCurrencyData returns currency with symbol for formatting, falls back on bare ISO code
+func (s *Model) getCurrencyData(ctx context.Context, currencyID string) model.CurrencyData {
+	currency, err := s.currencyRepo.GetCurrency(ctx, currencyID)
+	if err != nil {
+		logger.Warn("cannot get currency symbol, fallback on currency code",
+			zap.String("currencyID", currencyID),
+			zap.Error(err))
+		return model.CurrencyData{ID: currencyID}
+	}
+	return currency
+}
+
+// getUserCurrencyData returns currency which user enters and sees amounts in
+func (s *Model) getUserCurrencyData(ctx context.Context, userID int64) model.CurrencyData {
+	return s.getCurrencyData(ctx, s.getUserCurrency(ctx, userID))
+}
+
+// getDialogCurrency returns currency amount of dialog is entered in, amounts of receipts are in server currency
+func (s *Model) getDialogCurrency(ctx context.Context, userID int64, state *dialog.State) string {
+	if state.Receipt != nil {
+		return constants.ServerCurrency
+	}
+	return s.getUserCurrency(ctx, userID)
+}
+
+func (s *Model) getDialogCurrencyData(ctx context.Context, userID int64, state *dialog.State) model.CurrencyData {
+	return s.getCurrencyData(ctx, s.getDialogCurrency(ctx, userID, state))
+}
+
+func (s *Model) getUserLanguage(ctx context.Context, userID int64, languageCode string) i18n.Lang {
+	preferred, err := s.userRepo.GetUserLanguage(ctx, userID)
+	if err != nil {
+		logger.Warn("cannot get user language, fallback on telegram language code",
+			zap.Int64("userID", userID),
+			zap.Error(err))
+	}
+	return i18n.Resolve(preferred, languageCode)
+}
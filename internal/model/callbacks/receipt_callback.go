@@ -0,0 +1,55 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/keyboards"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
+	"go.uber.org/zap"
+)
+
+// StartReceipt offers categories for expense of scanned receipt, the chosen one continues add operation flow
+// with amount and date of the receipt, photo of the receipt is attached to added operation
+func (s *Model) StartReceipt(ctx context.Context, userID int64, lang i18n.Lang, receipt model.Receipt,
+	photo *model.Attachment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StartReceipt")
+	defer span.Finish()
+
+	imported, err := s.transactionRepo.ReceiptImported(ctx, userID, receipt)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot check receipt", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	if imported {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.ReceiptAlreadyImported), userID)
+	}
+
+	categories, err := s.categoryRepo.GetAllCategories(ctx, string(lang))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get categories for receipt", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	state := &dialog.State{
+		Flow:       constants.AddOperation,
+		Step:       dialog.CategoryStep,
+		Receipt:    &receipt,
+		Attachment: photo,
+	}
+	if err = s.dialogs.Save(userID, state); err != nil {
+		logger.Error("cannot save dialog state", zap.Int64("userID", userID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
+	}
+	text := i18n.T(lang, i18n.ReceiptRecognized,
+		receipt.Date.Format(i18n.T(lang, i18n.DateLayout)+" 15:04"),
+		money.Format(lang, receipt.Total, s.getCurrencyData(ctx, constants.ServerCurrency))) +
+		i18n.T(lang, i18n.SpecifyCategory)
+	return s.tgClient.SendMessageWithMarkup(text, keyboards.Categories(categories, constants.AddOperation, lang), userID)
+}
@@ -0,0 +1,141 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/keyboards"
+)
+
+func testReceipt() model.Receipt {
+	return model.Receipt{
+		FiscalDriveNumber:    "9960440300000000",
+		FiscalDocumentNumber: "12345",
+		FiscalSign:           "1234567890",
+		Total:                decimal.RequireFromString("1234.50"),
+		Date:                 time.Date(2026, 10, 12, 18, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestReceipt_StartOffersCategories(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	receipt := testReceipt()
+	categories := []model.CategoryData{{ID: "SUPERMARKETS", Name: "Supermarkets"}}
+	photo := &model.Attachment{Kind: model.PhotoAttachment, FileID: "photo"}
+
+	m.transactionRepo.EXPECT().ReceiptImported(gomock.Any(), userID, receipt).Return(false, nil)
+	m.categoryRepo.EXPECT().GetAllCategories(gomock.Any(), "en").Return(categories, nil)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.CategoryStep, Receipt: &receipt, Attachment: photo,
+	})
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendMessageWithMarkup(
+		i18n.T(i18n.EN, i18n.ReceiptRecognized, "2026-10-12 18:30", "₽1,234.50")+i18n.T(i18n.EN, i18n.SpecifyCategory),
+		keyboards.Categories(categories, constants.AddOperation, i18n.EN), userID)
+
+	err := m.model.StartReceipt(ctx, userID, i18n.EN, receipt, photo)
+
+	assert.NoError(t, err)
+}
+
+func TestReceipt_ImportedReceiptIsRejected(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	receipt := testReceipt()
+
+	m.transactionRepo.EXPECT().ReceiptImported(gomock.Any(), userID, receipt).Return(true, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.ReceiptAlreadyImported), userID)
+
+	err := m.model.StartReceipt(ctx, userID, i18n.EN, receipt, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestReceipt_CategoryPrefillsAmountInServerCurrency(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	receipt := testReceipt()
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.CategoryStep, Receipt: &receipt,
+	}, true)
+	m.dialogs.EXPECT().Save(userID, &dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "SUPERMARKETS", Amount: "1234.5",
+		MessageID: 7, Receipt: &receipt,
+	})
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil)
+	m.sender.EXPECT().SendEditMessageWithMarkupAndText(i18n.T(i18n.EN, i18n.SpecifyAmount, "RUB")+"₽1,234.5",
+		keyboards.Amount(i18n.EN), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "add_operation:SUPERMARKETS"))
+
+	assert.NoError(t, err)
+}
+
+func TestReceipt_DoneImportsReceiptOnceByItsDate(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	receipt := testReceipt()
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "SUPERMARKETS", Amount: "1234.5",
+		MessageID: 7, Receipt: &receipt,
+	}, true)
+	m.dialogs.EXPECT().Reset(userID)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil).AnyTimes()
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "RUB", receipt.Date).Return(decimal.NewFromInt(1), nil)
+	m.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "en", []string{"SUPERMARKETS"}).Return(
+		map[string]model.CategoryData{"SUPERMARKETS": {ID: "SUPERMARKETS", Name: "Supermarkets"}}, nil)
+	m.transactionRepo.EXPECT().AddReceiptOperation(gomock.Any(), userID, "SUPERMARKETS",
+		decimalEq(decimal.RequireFromString("1234.5")), receipt).Return(int64(1), constants.ReceiptAlreadyImportedErr)
+	m.sender.EXPECT().SendEditMessage(i18n.T(i18n.EN, i18n.ReceiptAlreadyImported), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:"+dialog.DoneKey))
+
+	assert.NoError(t, err)
+}
+
+func TestReceipt_PhotoIsAttachedToAddedOperation(t *testing.T) {
+	m := newTestModel(t)
+	ctx := context.Background()
+	userID := int64(123)
+	receipt := testReceipt()
+	photo := &model.Attachment{Kind: model.PhotoAttachment, FileID: "photo"}
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.dialogs.EXPECT().Get(userID).Return(&dialog.State{
+		Flow: constants.AddOperation, Step: dialog.AmountStep, CategoryID: "SUPERMARKETS", Amount: "1234.5",
+		MessageID: 7, Receipt: &receipt, Attachment: photo,
+	}, true)
+	m.dialogs.EXPECT().Reset(userID)
+	m.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "RUB").Return(model.CurrencyData{ID: "RUB", Symbol: "₽"}, nil).AnyTimes()
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "RUB", receipt.Date).Return(decimal.NewFromInt(1), nil)
+	m.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "en", []string{"SUPERMARKETS"}).Return(
+		map[string]model.CategoryData{"SUPERMARKETS": {ID: "SUPERMARKETS", Name: "Supermarkets"}}, nil)
+	m.transactionRepo.EXPECT().AddReceiptOperation(gomock.Any(), userID, "SUPERMARKETS",
+		decimalEq(decimal.RequireFromString("1234.5")), receipt).Return(int64(42), nil)
+	m.transactionRepo.EXPECT().SetAttachment(gomock.Any(), userID, int64(42), *photo)
+	m.calcService.EXPECT().InvalidateReports(userID)
+	m.calcService.EXPECT().CalcSinceStartOfMonth(gomock.Any(), userID, "RUB", gomock.Any()).Return(model.ReportData{}, nil)
+	m.limitationRepo.EXPECT().CheckLimit(gomock.Any(), userID, "SUPERMARKETS", gomock.Any()).Return(decimal.Zero, false, nil)
+	m.sender.EXPECT().SendEditMessage(gomock.Any(), userID, 7)
+
+	err := m.model.HandleIncomingCallback(ctx, callbackQuery(userID, 7, "dialog:"+dialog.DoneKey))
+
+	assert.NoError(t, err)
+}
@@ -2,74 +2,68 @@ package callbacks
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
 	"go.uber.org/zap"
 )
 
-func (s *Model) handleSetLimitation(ctx context.Context, query *tgbotapi.CallbackQuery, params ...string) error {
+func (s *Model) handleSetLimitation(ctx context.Context, callback Callback, params ...string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.SetLimitation)
 	defer span.Finish()
 
-	input, err := s.parseCategoryWithAmountInputData(ctx, params, query)
+	err := s.startAmountInput(ctx, constants.SetLimitation, callback, params...)
 	if err != nil {
 		span.SetTag("error", err.Error())
-		logger.Error("cannot parse input while setting limit", zap.Error(err))
+		logger.Error("cannot start entering amount while setting limit", zap.Error(err))
 		return err
 	}
-	span.SetTag("parse input category", "success")
+	return nil
+}
 
-	if err, needBreak := s.makeProcessOfEnteringAmount(params, input, query); err != nil {
-		span.SetTag("error", err.Error())
-		logger.Error("cannot parse amount while adding new operation", zap.Error(err))
-		return err
-	} else if needBreak {
-		return nil
-	}
-	span.SetTag("parse input amount", "success")
+// setLimitation persists limit entered in dialog until the end of current month
+func (s *Model) setLimitation(ctx context.Context, input *addOperationInputData) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "setLimitation")
+	defer span.Finish()
 
 	multiplier, err := s.rateService.GetMultiplier(ctx, input.Currency, time.Now())
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot get multiplier while setting limit", zap.Error(err))
-		return s.tgClient.SendEditMessage(fmt.Sprintf(constants.CannotGetRateForYouMsg, constants.ServerCurrency),
-			input.UserID, input.MessageID)
+		return s.tgClient.SendMessage(i18n.T(input.Lang, i18n.CannotGetRateForYou, constants.ServerCurrency), input.UserID)
 	}
 	span.SetTag("got multiplier", multiplier.String())
 
-	untilDate := EndOfMonth(time.Now())
+	untilDate := utils.EndOfMonth(time.Now())
 	err = s.limitationRepo.AddLimit(ctx, input.UserID, input.CategoryID, input.Amount.Div(multiplier), untilDate) // just overwrite if exists
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot persist data while setting limit", zap.Error(err))
 		return err
 	}
+	s.finishDialog(input.UserID)
 	span.SetTag("adding limit", "success")
+	_ = s.calcService.InvalidateReports(input.UserID)
 
-	categories, err := s.categoryRepo.ResolveCategories(ctx, []string{input.CategoryID})
+	categories, err := s.categoryRepo.ResolveCategories(ctx, string(input.Lang), []string{input.CategoryID})
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot resolve categories while setting limit", zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, input.UserID)
+		return s.tgClient.SendMessage(i18n.T(input.Lang, i18n.InternalServerError), input.UserID)
 	}
 
-	msg := fmt.Sprintf(constants.SetLimitUntilDateMsg,
+	msg := i18n.T(input.Lang, i18n.SetLimitUntilDate,
 		categories[input.CategoryID].Name,
-		input.Amount.Round(2).String(),
-		input.Currency,
+		money.Format(input.Lang, input.Amount, s.getCurrencyData(ctx, input.Currency)),
 		untilDate.Format(untilDateFormat),
 	)
 	return s.tgClient.SendEditMessage(msg, input.UserID, input.MessageID)
 }
 
 var untilDateFormat = "Mon, 02 Jan 2006"
-
-func EndOfMonth(date time.Time) time.Time {
-	return date.AddDate(0, 1, -date.Day())
-}
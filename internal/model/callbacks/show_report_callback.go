@@ -8,14 +8,14 @@ import (
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"go.uber.org/zap"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
-	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
-	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/expenses"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
-func (s *Model) handleShowReport(ctx context.Context, query *tgbotapi.CallbackQuery, params ...string) (err error) {
+// handleShowReport requests report from report worker which sends it to user when it is calculated
+func (s *Model) handleShowReport(ctx context.Context, callback Callback, params ...string) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.ShowReport)
 	defer span.Finish()
 
@@ -23,53 +23,31 @@ func (s *Model) handleShowReport(ctx context.Context, query *tgbotapi.CallbackQu
 		span.SetTag("error", emptyCallbackErr.Error())
 		return emptyCallbackErr
 	}
-
-	userID := query.From.ID
-	selectedCurrency, _ := s.userRepo.GetUserCurrency(ctx, userID)
-	var res map[string]decimal.Decimal
-	var period string
 	switch params[0] {
-	case constants.WeekPeriod:
-		period = constants.WeekPeriod
-		res, err = s.calcService.CalcByCurrentWeek(ctx, userID, selectedCurrency)
-	case constants.MonthPeriod:
-		period = constants.MonthPeriod
-		res, err = s.calcService.CalcByCurrentMonth(ctx, userID, selectedCurrency)
-	case constants.YearPeriod:
-		period = constants.YearPeriod
-		res, err = s.calcService.CalcByCurrentYear(ctx, userID, selectedCurrency)
-	}
-	if err != nil {
+	case constants.WeekPeriod, constants.MonthPeriod, constants.YearPeriod:
+	default:
+		err = errors.Errorf("unknown report period '%s'", params[0])
 		span.SetTag("error", err.Error())
-		logger.Error("cannot make report",
-			zap.Int64("userID", userID),
-			zap.String("currency", selectedCurrency),
-			zap.String("period", period),
-			zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, userID)
-	}
-	categoryIDs := make([]string, 0)
-	for k := range res {
-		categoryIDs = append(categoryIDs, k)
+		return err
 	}
-	categories, err := s.categoryRepo.ResolveCategories(ctx, categoryIDs)
+
+	userID := callback.UserID
+	lang := s.getUserLanguage(ctx, callback.UserID, callback.LanguageCode)
+	selectedCurrency, _ := s.userRepo.GetUserCurrency(ctx, userID)
+	err = s.reports.RequestReport(ctx, model.ReportRequest{
+		UserID:   userID,
+		Period:   params[0],
+		Currency: selectedCurrency,
+		Language: string(lang),
+	})
 	if err != nil {
 		span.SetTag("error", err.Error())
-		logger.Error("cannot make report because of resolving categories problem",
-			zap.Int64("userID", userID),
-			zap.String("currency", selectedCurrency),
-			zap.String("period", period),
-			zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, userID)
-	}
-	if errors.Is(err, constants.MissingCurrencyErr) {
-		span.SetTag("error", err.Error())
-		logger.Error("cannot make report and fallback with fallback on default currency",
+		logger.Error("cannot request report",
 			zap.Int64("userID", userID),
 			zap.String("currency", selectedCurrency),
-			zap.String("period", period),
+			zap.String("period", params[0]),
 			zap.Error(err))
-		return s.tgClient.SendMessage(constants.ServerProblemMsg+expenses.Format(nil, categories, period, constants.ServerCurrency), userID)
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
 	}
-	return s.tgClient.SendMessage(expenses.Format(res, categories, period, selectedCurrency), userID)
+	return nil
 }
@@ -0,0 +1,47 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestShowReport_RequestsReportInUserSettings(t *testing.T) {
+	m := newTestModel(t)
+	userID := int64(1)
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("USD", nil)
+	m.reports.EXPECT().RequestReport(gomock.Any(), model.ReportRequest{
+		UserID: userID, Period: "month", Currency: "USD", Language: "en",
+	})
+
+	err := m.model.HandleIncomingCallback(context.Background(), Callback{UserID: userID, Data: "show_report:month"})
+
+	assert.NoError(t, err)
+}
+
+func TestShowReport_TellsUserWhenReportCannotBeRequested(t *testing.T) {
+	m := newTestModel(t)
+	userID := int64(1)
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), userID).Return("en", nil)
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), userID).Return("USD", nil)
+	m.reports.EXPECT().RequestReport(gomock.Any(), gomock.Any()).Return(errors.New("queue is full"))
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.InternalServerError), userID)
+
+	err := m.model.HandleIncomingCallback(context.Background(), Callback{UserID: userID, Data: "show_report:week"})
+
+	assert.NoError(t, err)
+}
+
+func TestShowReport_RejectsUnknownPeriod(t *testing.T) {
+	m := newTestModel(t)
+
+	err := m.model.HandleIncomingCallback(context.Background(), Callback{UserID: 1, Data: "show_report:decade"})
+
+	assert.Error(t, err)
+}
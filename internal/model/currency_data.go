@@ -0,0 +1,19 @@
+package model
+
+// defaultPrecision is number of fractional digits of fiat currencies
+const defaultPrecision = 2
+
+type CurrencyData struct {
+	ID        string
+	Symbol    string
+	Precision int32
+	Enabled   bool // users can choose currency
+}
+
+// Decimals returns number of fractional digits amounts in currency are kept with, e.g. 8 for BTC
+func (c CurrencyData) Decimals() int32 {
+	if c.Precision <= 0 {
+		return defaultPrecision
+	}
+	return c.Precision
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Types of domain events written to outbox along with changes they describe
+const (
+	TransactionCreatedEvent = "TransactionCreated"
+	LimitSetEvent           = "LimitSet"
+	LimitExceededEvent      = "LimitExceeded"
+	CurrencyChangedEvent    = "CurrencyChanged"
+)
+
+var EventTypes = []string{TransactionCreatedEvent, LimitSetEvent, LimitExceededEvent, CurrencyChangedEvent}
+
+// Event is domain event delivered to sinks at least once, receivers drop duplicates by IdempotencyKey
+type Event struct {
+	ID             int64           `json:"-"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Type           string          `json:"type"`
+	UserID         int64           `json:"user_id"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+	// Attempts is the number of failed deliveries before the current one
+	Attempts int `json:"-"`
+}
+
+// TransactionCreated is payload of TransactionCreatedEvent, amounts of events are in server currency
+type TransactionCreated struct {
+	TransactionID int64           `json:"transaction_id"`
+	CategoryID    string          `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	Date          time.Time       `json:"date"`
+}
+
+type LimitSet struct {
+	CategoryID  string          `json:"category_id"`
+	UpperBorder decimal.Decimal `json:"upper_border"`
+	Currency    string          `json:"currency"`
+	UntilDate   time.Time       `json:"until_date"`
+}
+
+// LimitExceeded is payload of LimitExceededEvent written when expense makes spending of month exceed limit
+type LimitExceeded struct {
+	CategoryID    string          `json:"category_id"`
+	TransactionID int64           `json:"transaction_id"`
+	Spent         decimal.Decimal `json:"spent"`
+	UpperBorder   decimal.Decimal `json:"upper_border"`
+	Currency      string          `json:"currency"`
+}
+
+type CurrencyChanged struct {
+	Currency string `json:"currency"`
+}
@@ -0,0 +1,91 @@
+package keyboards
+
+import (
+	"fmt"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/dialog"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// Categories builds category choice where callback is the flow which starts after choice
+func Categories(categories []model.CategoryData, callback string, lang i18n.Lang) [][]model.MarkupData {
+	buttons := make([][]model.MarkupData, 0, len(categories)+1)
+	for i := range categories {
+		buttons = append(buttons, []model.MarkupData{
+			{
+				Text: categories[i].Name,
+				Data: fmt.Sprintf("%s:%s", callback, categories[i].ID),
+			},
+		})
+	}
+	buttons = append(buttons, []model.MarkupData{
+		dialogButton(i18n.T(lang, i18n.CancelButton), dialog.CancelKey),
+	})
+	return buttons
+}
+
+// Amount builds numeric keypad with arithmetic operations, pressed keys are accumulated in dialog state
+func Amount(lang i18n.Lang) [][]model.MarkupData {
+	return [][]model.MarkupData{
+		{
+			numericButton("1"),
+			numericButton("2"),
+			numericButton("3"),
+			dialogButton("÷", dialog.DivideKey),
+		},
+		{
+			numericButton("4"),
+			numericButton("5"),
+			numericButton("6"),
+			dialogButton("×", dialog.MultiplyKey),
+		},
+		{
+			numericButton("7"),
+			numericButton("8"),
+			numericButton("9"),
+			dialogButton("−", dialog.MinusKey),
+		},
+		{
+			numericButton(dialog.PointKey),
+			numericButton("0"),
+			dialogButton("⌫", dialog.BackspaceKey),
+			dialogButton("+", dialog.PlusKey),
+		},
+		{
+			numericButton(dialog.OpenKey),
+			numericButton(dialog.CloseKey),
+			dialogButton("C", dialog.ClearKey),
+			dialogButton(i18n.T(lang, i18n.DoneButton), dialog.DoneKey),
+		},
+		{
+			dialogButton(i18n.T(lang, i18n.BackButton), dialog.BackKey),
+			dialogButton(i18n.T(lang, i18n.CancelButton), dialog.CancelKey),
+		},
+	}
+}
+
+// ConfirmAmount asks to confirm evaluated expression, back returns to keypad with the expression
+func ConfirmAmount(lang i18n.Lang) [][]model.MarkupData {
+	return [][]model.MarkupData{
+		{
+			dialogButton(i18n.T(lang, i18n.ConfirmButton), dialog.ConfirmKey),
+		},
+		{
+			dialogButton(i18n.T(lang, i18n.BackButton), dialog.BackKey),
+			dialogButton(i18n.T(lang, i18n.CancelButton), dialog.CancelKey),
+		},
+	}
+}
+
+func numericButton(text string) model.MarkupData {
+	return dialogButton(text, text)
+}
+
+func dialogButton(text, key string) model.MarkupData {
+	return model.MarkupData{
+		Text: text,
+		Data: fmt.Sprintf("%s:%s", constants.Dialog, key),
+	}
+}
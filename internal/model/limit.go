@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Limit is spending limit of category in server currency
+type Limit struct {
+	CategoryID  string
+	UpperBorder decimal.Decimal
+	UntilDate   time.Time
+}
@@ -0,0 +1,16 @@
+package messages
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+)
+
+func (s *Model) changeLanguage(ctx context.Context, msg Message, lang i18n.Lang) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, constants.ChangeLanguage)
+	defer span.Finish()
+
+	return s.tgClient.SendMessageWithMarkup(i18n.T(lang, i18n.SpecifyLanguage), getLanguages(), msg.UserID)
+}
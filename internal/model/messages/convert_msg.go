@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
+	"go.uber.org/zap"
+)
+
+// convertDateLayout is accepted regardless of language to keep command syntax the same for everyone
+const convertDateLayout = "2006-01-02"
+
+// convert handles "/convert 120 EUR RUB [2026-08-15]"
+func (s *Model) convert(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Convert)
+	defer span.Finish()
+
+	amount, from, to, date, ok := parseConvertArgs(args)
+	if !ok {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.ConvertUsage), msg.UserID)
+	}
+
+	conversion, err := s.converter.Convert(ctx, amount, from, to, date)
+	switch {
+	case errors.Is(err, constants.FutureDateErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.ConvertFutureDate), msg.UserID)
+	case errors.Is(err, constants.UndefinedCurrencyErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.ConvertUnknownCurrency), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot convert currency",
+			zap.Int64("userID", msg.UserID),
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Time("date", date),
+			zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotConvert), msg.UserID)
+	}
+	return s.tgClient.SendMessage(formatConversion(lang, conversion), msg.UserID)
+}
+
+func parseConvertArgs(args []string) (amount decimal.Decimal, from, to string, date time.Time, ok bool) {
+	if len(args) != 3 && len(args) != 4 {
+		return
+	}
+	amount, err := decimal.NewFromString(strings.ReplaceAll(args[0], ",", "."))
+	if err != nil || !amount.IsPositive() {
+		return
+	}
+	date = time.Now()
+	if len(args) == 4 {
+		if date, err = time.Parse(convertDateLayout, args[3]); err != nil {
+			return
+		}
+	}
+	return amount, strings.ToUpper(args[1]), strings.ToUpper(args[2]), date, true
+}
+
+func formatConversion(lang i18n.Lang, c model.Conversion) string {
+	// codes are clearer than symbols here since both sides may share symbol, e.g. USD and AUD
+	from, to := c.From, c.To
+	from.Symbol, to.Symbol = "", ""
+	text := i18n.T(lang, i18n.ConvertResult,
+		money.Format(lang, c.Amount, from),
+		money.Format(lang, c.Result, to),
+		from.ID, money.FormatRate(lang, c.Rate), to.ID)
+	// every leg has its own line since providers may publish rates for different days
+	for _, rate := range c.Legs {
+		text += "\n" + i18n.T(lang, i18n.ConvertSource, rate.Currency,
+			i18n.RateProvider(lang, rate.Provider), formatDate(lang, rate.EffectiveDate))
+	}
+	return text
+}
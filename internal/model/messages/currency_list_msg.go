@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// changeCurrencyList handles admin commands "/enable_currency CODE [symbol]" and "/disable_currency CODE"
+func (s *Model) changeCurrencyList(ctx context.Context, msg Message, lang i18n.Lang, command string, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, strings.TrimPrefix(command, "/"))
+	defer span.Finish()
+
+	if len(args) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.SpecifyCurrencyCode, command), msg.UserID)
+	}
+	code := strings.ToUpper(args[0])
+
+	var err error
+	var text string
+	if command == "/"+constants.EnableCurrency {
+		symbol := strings.Join(args[1:], " ")
+		currency, enableErr := s.currencyAdmin.EnableCurrency(ctx, code, symbol)
+		err = enableErr
+		text = i18n.T(lang, i18n.CurrencyEnabled, currency.Code, currency.Symbol)
+	} else {
+		err = s.currencyAdmin.DisableCurrency(ctx, code)
+		text = i18n.T(lang, i18n.CurrencyDisabled, code)
+	}
+
+	switch {
+	case errors.Is(err, constants.InvalidCurrencyCodeErr):
+		text = i18n.T(lang, i18n.SpecifyCurrencyCode, command)
+	case errors.Is(err, constants.UnknownCurrencySymbolErr):
+		text = i18n.T(lang, i18n.CurrencySymbolRequired, code, code)
+	case errors.Is(err, constants.ServerCurrencyErr):
+		text = i18n.T(lang, i18n.CannotDisableServerCurrency)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot change currency list", zap.String("currency", code), zap.Error(err))
+		text = i18n.T(lang, i18n.CannotChangeCurrencyList)
+	}
+	return s.tgClient.SendMessage(text, msg.UserID)
+}
@@ -0,0 +1,155 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
+	"go.uber.org/zap"
+)
+
+const (
+	// historySize is number of the latest transactions shown by /history, they are numbered from the newest one
+	historySize       = 10
+	attachmentsPerRow = 5
+	attachmentMark    = " 📎"
+)
+
+// showHistory handles "/history" listing the latest transactions with buttons sending their attachments back
+func (s *Model) showHistory(ctx context.Context, msg Message, lang i18n.Lang) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.History)
+	defer span.Finish()
+
+	transactions, _, err := s.transactions.ListTransactions(ctx, msg.UserID, historySize, 0)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot list transactions", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	if len(transactions) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoTransactions), msg.UserID)
+	}
+
+	names := s.getCategoryNames(ctx, lang)
+	var text strings.Builder
+	text.WriteString(i18n.T(lang, i18n.HistoryHeader))
+	buttons := make([]model.MarkupData, 0, len(transactions))
+	for i, t := range transactions {
+		text.WriteString(formatTransaction(lang, i+1, t, names))
+		if t.Attachment != nil {
+			buttons = append(buttons, mapToLabeledMarkupData(constants.Attachment,
+				fmt.Sprintf("📎 %d", i+1), strconv.FormatInt(t.ID, 10)))
+		}
+	}
+	text.WriteString("\n" + i18n.T(lang, i18n.AttachUsage))
+	if len(buttons) == 0 {
+		return s.tgClient.SendMessage(text.String(), msg.UserID)
+	}
+	return s.tgClient.SendMessageWithMarkup(text.String(), lo.Chunk(buttons, attachmentsPerRow), msg.UserID)
+}
+
+// attach handles "/attach [number]" waiting for file to attach to transaction with number from /history,
+// the latest one by default, file sent with the command as caption is attached at once
+func (s *Model) attach(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Attach)
+	defer span.Finish()
+
+	number := int64(1)
+	if len(args) > 1 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.AttachUsage), msg.UserID)
+	}
+	if len(args) == 1 {
+		var err error
+		if number, err = parseNumber(args[0]); err != nil || number < 1 {
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.AttachUsage), msg.UserID)
+		}
+	}
+
+	transactions, _, err := s.transactions.ListTransactions(ctx, msg.UserID, 1, int(number-1))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot find transaction to attach file to", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	if len(transactions) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TransactionNotFound), msg.UserID)
+	}
+	transaction := transactions[0]
+	if msg.Attachment != nil {
+		return s.saveAttachment(ctx, msg, lang, transaction.ID)
+	}
+
+	if err = s.dialog.AwaitAttachment(ctx, msg.UserID, transaction.ID); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot wait for attachment", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	line := formatTransaction(lang, int(number), transaction, s.getCategoryNames(ctx, lang))
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.AwaitingAttachment, line), msg.UserID)
+}
+
+// receiveAttachment attaches file to transaction chosen by /attach, photos sent without it are scanned for
+// receipt QR code
+func (s *Model) receiveAttachment(ctx context.Context, msg Message, lang i18n.Lang) error {
+	if transactionID, ok := s.dialog.PendingAttachment(ctx, msg.UserID); ok {
+		if err := s.dialog.ResetDialog(ctx, msg.UserID); err != nil {
+			logger.Warn("cannot reset dialog", zap.Int64("userID", msg.UserID), zap.Error(err))
+		}
+		return s.saveAttachment(ctx, msg, lang, transactionID)
+	}
+	if msg.Attachment.Kind == model.VoiceAttachment {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.AttachUsage), msg.UserID)
+	}
+	return s.scanReceipt(ctx, msg, lang)
+}
+
+func (s *Model) saveAttachment(ctx context.Context, msg Message, lang i18n.Lang, transactionID int64) error {
+	err := s.transactions.SetAttachment(ctx, msg.UserID, transactionID, *msg.Attachment)
+	if errors.Is(err, constants.TransactionNotFoundErr) {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TransactionNotFound), msg.UserID)
+	}
+	if err != nil {
+		logger.Error("cannot save attachment",
+			zap.Int64("userID", msg.UserID),
+			zap.Int64("transactionID", transactionID),
+			zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.AttachmentSaved), msg.UserID)
+}
+
+// getCategoryNames maps categories to their names in language of user, ids are shown when names are unavailable
+func (s *Model) getCategoryNames(ctx context.Context, lang i18n.Lang) map[string]string {
+	categories, err := s.categoryRepo.GetAllCategories(ctx, string(lang))
+	if err != nil {
+		logger.Warn("cannot get category names", zap.Error(err))
+	}
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	return names
+}
+
+// formatTransaction prints numbered transaction of history, its amount is kept in server currency
+func formatTransaction(lang i18n.Lang, number int, t model.Transaction, names map[string]string) string {
+	name, ok := names[t.CategoryID]
+	if !ok {
+		name = t.CategoryID
+	}
+	indicator := ""
+	if t.Attachment != nil {
+		indicator = attachmentMark
+	}
+	return i18n.T(lang, i18n.HistoryLine, number, formatDate(lang, t.Date), name,
+		money.Format(lang, t.Amount, model.CurrencyData{ID: constants.ServerCurrency}), indicator)
+}
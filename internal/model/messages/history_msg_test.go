@@ -0,0 +1,100 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+var testCategories = []model.CategoryData{{ID: "SUPERMARKETS", Name: "Supermarkets"}, {ID: "TAXI", Name: "Taxi"}}
+
+func TestOnHistory_ShouldMarkAttachmentsAndOfferThem(t *testing.T) {
+	m := newTestModel(t, "en")
+	date := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	photo := &model.Attachment{Kind: model.PhotoAttachment, FileID: "photo"}
+
+	m.transactions.EXPECT().ListTransactions(gomock.Any(), int64(123), 10, 0).Return([]model.Transaction{
+		{ID: 42, CategoryID: "SUPERMARKETS", Amount: decimal.RequireFromString("1234.56"), Date: date, Attachment: photo},
+		{ID: 41, CategoryID: "TAXI", Amount: decimal.NewFromInt(300), Date: date},
+	}, 2, nil)
+	m.categoryRepo.EXPECT().GetAllCategories(gomock.Any(), "en").Return(testCategories, nil)
+	m.sender.EXPECT().SendMessageWithMarkup(i18n.T(i18n.EN, i18n.HistoryHeader)+
+		"1. 2026-10-19 Supermarkets 1,234.56 RUB 📎\n"+
+		"2. 2026-10-19 Taxi 300.00 RUB\n\n"+
+		i18n.T(i18n.EN, i18n.AttachUsage),
+		[][]model.MarkupData{{{Text: "📎 1", Data: "attachment:42"}}}, int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/history", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnHistory_ShouldTellThereAreNoTransactions(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.transactions.EXPECT().ListTransactions(gomock.Any(), int64(123), 10, 0).Return(nil, 0, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.NoTransactions), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/history", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnAttach_ShouldWaitForFileOfChosenTransaction(t *testing.T) {
+	m := newTestModel(t, "en")
+	transaction := model.Transaction{ID: 41, CategoryID: "TAXI", Amount: decimal.NewFromInt(300),
+		Date: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+
+	m.transactions.EXPECT().ListTransactions(gomock.Any(), int64(123), 1, 1).Return([]model.Transaction{transaction}, 2, nil)
+	m.dialog.EXPECT().AwaitAttachment(gomock.Any(), int64(123), int64(41))
+	m.categoryRepo.EXPECT().GetAllCategories(gomock.Any(), "en").Return(testCategories, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AwaitingAttachment, "2. 2026-10-19 Taxi 300.00 RUB\n"), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/attach 2", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnAttach_ShouldAttachFileSentWithCommand(t *testing.T) {
+	m := newTestModel(t, "en")
+	document := &model.Attachment{Kind: model.DocumentAttachment, FileID: "doc", FileName: "warranty.pdf"}
+
+	m.transactions.EXPECT().ListTransactions(gomock.Any(), int64(123), 1, 0).Return([]model.Transaction{{ID: 42}}, 1, nil)
+	m.transactions.EXPECT().SetAttachment(gomock.Any(), int64(123), int64(42), *document)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AttachmentSaved), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(),
+		Message{Text: "/attach", UserID: 123, Attachment: document}))
+
+	m = newTestModel(t, "en")
+	m.transactions.EXPECT().ListTransactions(gomock.Any(), int64(123), 1, 8).Return(nil, 1, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.TransactionNotFound), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/attach 9", UserID: 123}))
+
+	m = newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AttachUsage), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/attach first", UserID: 123}))
+}
+
+func TestOnFile_ShouldBeAttachedToAwaitingTransaction(t *testing.T) {
+	m := newTestModel(t, "en")
+	voice := &model.Attachment{Kind: model.VoiceAttachment, FileID: "voice", MimeType: "audio/ogg"}
+
+	m.dialog.EXPECT().PendingAttachment(gomock.Any(), int64(123)).Return(int64(42), true)
+	m.transactions.EXPECT().SetAttachment(gomock.Any(), int64(123), int64(42), *voice).
+		Return(errors.Wrap(constants.TransactionNotFoundErr, "#42"))
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.TransactionNotFound), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{UserID: 123, Attachment: voice}))
+
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("en", nil)
+	m.dialog.EXPECT().PendingAttachment(gomock.Any(), int64(123)).Return(int64(0), false)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.AttachUsage), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{UserID: 123, Attachment: voice}))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
@@ -14,17 +15,23 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model/keyboards"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/receipt"
 )
 
 type UserStore interface {
 	SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error
 	GetCurrenciesFilteredByUser(ctx context.Context, userID int64) ([]string, error)
+	GetUserLanguage(ctx context.Context, userID int64) (string, error)
 }
 
 type CategoryStore interface {
-	GetAllCategories(ctx context.Context) (category []model.CategoryData, err error)
+	GetAllCategories(ctx context.Context, lang string) (category []model.CategoryData, err error)
 }
 
 type MessageSender interface {
@@ -32,26 +39,121 @@ type MessageSender interface {
 	SendMessageWithMarkup(text string, markup [][]model.MarkupData, userID int64) error
 }
 
+// DialogHandler continues multi-step flows started by callbacks with typed text or sent files
+type DialogHandler interface {
+	HandleTextInput(ctx context.Context, userID int64, lang i18n.Lang, text string) (bool, error)
+	ResetDialog(ctx context.Context, userID int64) error
+	AwaitAttachment(ctx context.Context, userID, transactionID int64) error
+	PendingAttachment(ctx context.Context, userID int64) (int64, bool)
+}
+
+// CurrencyAdmin changes set of currencies available to users, commands are accepted from admins only
+type CurrencyAdmin interface {
+	IsAdmin(userID int64) bool
+	EnableCurrency(ctx context.Context, code, symbol string) (iso4217.Currency, error)
+	DisableCurrency(ctx context.Context, code string) error
+}
+
+// CurrencyConverter converts amount between enabled currencies by rate on date
+type CurrencyConverter interface {
+	Convert(ctx context.Context, amount decimal.Decimal, from, to string, date time.Time) (model.Conversion, error)
+}
+
+// RateHistory shows stored rates of enabled currencies
+type RateHistory interface {
+	Overview(ctx context.Context) ([]model.RateChange, error)
+	History(ctx context.Context, currency string, days int) ([]model.RatePoint, error)
+}
+
+// RateAlerts manages alerts of user on currency prices
+type RateAlerts interface {
+	AddAlert(ctx context.Context, alert model.RateAlert) (model.RateAlert, error)
+	GetAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error)
+	DeleteAlert(ctx context.Context, userID, alertID int64) error
+}
+
+// APITokens issues and revokes tokens of REST API
+type APITokens interface {
+	Issue(ctx context.Context, userID int64) (string, error)
+	RevokeAll(ctx context.Context, userID int64) (int64, error)
+}
+
+// Webhooks manages outgoing webhooks of user and shows deliveries to them
+type Webhooks interface {
+	AddWebhook(ctx context.Context, userID int64, url string, eventTypes []string) (model.Webhook, error)
+	GetWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID int64) error
+	GetDeliveries(ctx context.Context, userID, webhookID int64) ([]model.WebhookDelivery, error)
+}
+
+// FileDownloader downloads files attached to messages
+type FileDownloader interface {
+	DownloadFile(ctx context.Context, fileID string) ([]byte, error)
+}
+
+// ReceiptImporter continues adding expense of recognized receipt with choice of its category
+type ReceiptImporter interface {
+	StartReceipt(ctx context.Context, userID int64, lang i18n.Lang, receipt model.Receipt, photo *model.Attachment) error
+}
+
+// TransactionHistory shows the latest transactions of user and attaches files to them
+type TransactionHistory interface {
+	ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error)
+	SetAttachment(ctx context.Context, userID, transactionID int64, attachment model.Attachment) error
+}
+
 type Model struct {
-	tgClient     MessageSender
-	userRepo     UserStore
-	categoryRepo CategoryStore
+	tgClient      MessageSender
+	userRepo      UserStore
+	categoryRepo  CategoryStore
+	dialog        DialogHandler
+	currencyAdmin CurrencyAdmin
+	converter     CurrencyConverter
+	rateHistory   RateHistory
+	rateAlerts    RateAlerts
+	apiTokens     APITokens
+	webhooks      Webhooks
+	files         FileDownloader
+	receipts      ReceiptImporter
+	transactions  TransactionHistory
 }
 
 func New(tgClient MessageSender,
 	userRepo UserStore,
 	categoryRepo CategoryStore,
+	dialog DialogHandler,
+	currencyAdmin CurrencyAdmin,
+	converter CurrencyConverter,
+	rateHistory RateHistory,
+	rateAlerts RateAlerts,
+	apiTokens APITokens,
+	webhooks Webhooks,
+	files FileDownloader,
+	receipts ReceiptImporter,
+	transactions TransactionHistory,
 ) *Model {
 	return &Model{
-		tgClient:     tgClient,
-		userRepo:     userRepo,
-		categoryRepo: categoryRepo,
+		tgClient:      tgClient,
+		userRepo:      userRepo,
+		categoryRepo:  categoryRepo,
+		dialog:        dialog,
+		currencyAdmin: currencyAdmin,
+		converter:     converter,
+		rateHistory:   rateHistory,
+		rateAlerts:    rateAlerts,
+		apiTokens:     apiTokens,
+		webhooks:      webhooks,
+		files:         files,
+		receipts:      receipts,
+		transactions:  transactions,
 	}
 }
 
 type Message struct {
-	Text   string
-	UserID int64
+	Text         string
+	UserID       int64
+	LanguageCode string
+	Attachment   *model.Attachment
 }
 
 func (s *Model) IncomingMessage(ctx context.Context, msg Message) error {
@@ -71,23 +173,78 @@ func (s *Model) IncomingMessage(ctx context.Context, msg Message) error {
 		metrics.IncomingRequestsHistogramResponseTime.WithLabelValues(modelType, operation, status).Observe(tookTime)
 	}()
 
+	lang := s.getUserLanguage(ctx, msg)
+	span.SetTag("lang", lang)
+
+	if strings.HasPrefix(msg.Text, "/") { // any command interrupts unfinished dialog
+		if err := s.dialog.ResetDialog(ctx, msg.UserID); err != nil {
+			logger.Warn("cannot reset dialog", zap.Int64("userID", msg.UserID), zap.Error(err))
+		}
+	}
+
 	var err error
-	switch msg.Text {
+	command, args := parseCommand(msg.Text)
+	switch command {
 	case "/" + constants.Start:
-		err = s.start(ctx, msg)
+		err = s.start(ctx, msg, lang)
 	case "/" + constants.AddOperation:
-		err = s.chooseCategory(ctx, msg.UserID, constants.AddOperation)
+		err = s.chooseCategory(ctx, msg.UserID, lang, constants.AddOperation)
 	case "/" + constants.SetLimitation:
-		err = s.chooseCategory(ctx, msg.UserID, constants.SetLimitation)
+		err = s.chooseCategory(ctx, msg.UserID, lang, constants.SetLimitation)
 	case "/" + constants.ShowCategoryList:
-		err = s.showCategories(ctx, msg)
+		err = s.showCategories(ctx, msg, lang)
 	case "/" + constants.ChangeCurrency:
-		err = s.changeCurrency(ctx, msg)
+		err = s.changeCurrency(ctx, msg, lang)
 	case "/" + constants.ShowReport:
-		err = s.showReport(ctx, msg)
+		err = s.showReport(ctx, msg, lang)
+	case "/" + constants.ChangeLanguage:
+		err = s.changeLanguage(ctx, msg, lang)
+	case "/" + constants.Convert:
+		err = s.convert(ctx, msg, lang, args)
+	case "/" + constants.Rates:
+		err = s.showRates(ctx, msg, lang, args)
+	case "/" + constants.Alert:
+		err = s.addAlert(ctx, msg, lang, args)
+	case "/" + constants.Alerts:
+		err = s.showAlerts(ctx, msg, lang)
+	case "/" + constants.DeleteAlert:
+		err = s.deleteAlert(ctx, msg, lang, args)
+	case "/" + constants.Token:
+		err = s.token(ctx, msg, lang, args)
+	case "/" + constants.Webhook:
+		err = s.addWebhook(ctx, msg, lang, args)
+	case "/" + constants.Webhooks:
+		err = s.showWebhooks(ctx, msg, lang)
+	case "/" + constants.DeleteWebhook:
+		err = s.deleteWebhook(ctx, msg, lang, args)
+	case "/" + constants.WebhookLog:
+		err = s.showWebhookLog(ctx, msg, lang, args)
+	case "/" + constants.Receipt:
+		err = s.receipt(ctx, msg, lang, args)
+	case "/" + constants.History:
+		err = s.showHistory(ctx, msg, lang)
+	case "/" + constants.Attach:
+		err = s.attach(ctx, msg, lang, args)
+	case "/" + constants.EnableCurrency, "/" + constants.DisableCurrency:
+		if !s.currencyAdmin.IsAdmin(msg.UserID) { // admin commands look unknown to other users
+			err = s.tgClient.SendMessage(i18n.T(lang, i18n.UnrecognizedCommand), msg.UserID)
+			break
+		}
+		err = s.changeCurrencyList(ctx, msg, lang, command, args)
 	default:
-		operation = "unrecognized"
-		err = s.tgClient.SendMessage(constants.UnrecognizedCommandMsg, msg.UserID)
+		switch {
+		case msg.Attachment != nil:
+			err = s.receiveAttachment(ctx, msg, lang)
+		case receipt.IsQRString(msg.Text):
+			err = s.readReceipt(ctx, msg, lang, msg.Text)
+		default:
+			var handled bool
+			handled, err = s.dialog.HandleTextInput(ctx, msg.UserID, lang, msg.Text)
+			if err == nil && !handled {
+				operation = "unrecognized"
+				err = s.tgClient.SendMessage(i18n.T(lang, i18n.UnrecognizedCommand), msg.UserID)
+			}
+		}
 	}
 	if err != nil {
 		status = "error"
@@ -95,56 +252,80 @@ func (s *Model) IncomingMessage(ctx context.Context, msg Message) error {
 	return err
 }
 
-func (s *Model) chooseCategory(ctx context.Context, userID int64, operation string) error {
+// parseCommand splits command from its arguments, e.g. "/enable_currency GEL"
+func parseCommand(text string) (string, []string) {
+	if !strings.HasPrefix(text, "/") {
+		return text, nil
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return text, nil
+	}
+	return fields[0], fields[1:]
+}
+
+func (s *Model) getUserLanguage(ctx context.Context, msg Message) i18n.Lang {
+	preferred, err := s.userRepo.GetUserLanguage(ctx, msg.UserID)
+	if err != nil {
+		logger.Warn("cannot get user language, fallback on telegram language code",
+			zap.Int64("userID", msg.UserID),
+			zap.Error(err))
+	}
+	return i18n.Resolve(preferred, msg.LanguageCode)
+}
+
+func (s *Model) chooseCategory(ctx context.Context, userID int64, lang i18n.Lang, operation string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, operation)
 	defer span.Finish()
 
-	categories, err := s.categoryRepo.GetAllCategories(ctx)
+	categories, err := s.categoryRepo.GetAllCategories(ctx, string(lang))
 	if err != nil {
 		logger.Error("cannot make choosing category",
 			zap.Int64("userID", userID),
 			zap.String("operation", operation),
 			zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, userID)
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), userID)
 	}
-	return s.tgClient.SendMessageWithMarkup(constants.SpecifyCategoryMsg, s.collectCategories(categories, operation), userID)
+	return s.tgClient.SendMessageWithMarkup(i18n.T(lang, i18n.SpecifyCategory),
+		keyboards.Categories(categories, operation, lang), userID)
 }
 
+// currenciesPerRow keeps currency keyboard readable when admins enable many currencies
+const currenciesPerRow = 4
+
 func getCurrencies(currencies []string) [][]model.MarkupData {
+	return lo.Map(lo.Chunk(currencies, currenciesPerRow), func(row []string, _ int) []model.MarkupData {
+		return lo.Map(row, func(t string, _ int) model.MarkupData {
+			return mapToMarkupData(constants.ChangeCurrency, t)
+		})
+	})
+}
+
+func getPeriods(lang i18n.Lang) [][]model.MarkupData {
 	result := make([][]model.MarkupData, 0, 1)
-	result = append(result, lo.Map(currencies, func(t string, _ int) model.MarkupData {
-		return mapToMarkupData(constants.ChangeCurrency, t)
+	result = append(result, lo.Map(constants.Periods, func(t string, _ int) model.MarkupData {
+		return mapToLabeledMarkupData(constants.ShowReport, i18n.Period(lang, t), t)
 	}))
 	return result
 }
 
-func getPeriods() [][]model.MarkupData {
+func getLanguages() [][]model.MarkupData {
 	result := make([][]model.MarkupData, 0, 1)
-	filtered := []string{constants.WeekPeriod, constants.MonthPeriod, constants.YearPeriod}
-	result = append(result, lo.Map(filtered, func(t string, _ int) model.MarkupData {
-		return mapToMarkupData(constants.ShowReport, t)
+	result = append(result, lo.Map(i18n.Supported, func(t i18n.Lang, _ int) model.MarkupData {
+		return mapToLabeledMarkupData(constants.ChangeLanguage, i18n.Name(t), string(t))
 	}))
 	return result
 }
 
 func mapToMarkupData(callback, input string) model.MarkupData {
-	return model.MarkupData{
-		Text: input,
-		Data: fmt.Sprintf("%s:%s", callback, input),
-	}
+	return mapToLabeledMarkupData(callback, input, input)
 }
 
-func (s *Model) collectCategories(categories []model.CategoryData, callback string) [][]model.MarkupData {
-	buttons := make([][]model.MarkupData, 0, len(categories))
-	for i := range categories {
-		buttons = append(buttons, []model.MarkupData{
-			{
-				Text: categories[i].Name,
-				Data: fmt.Sprintf("%s:%s:", callback, categories[i].ID),
-			},
-		})
+func mapToLabeledMarkupData(callback, text, input string) model.MarkupData {
+	return model.MarkupData{
+		Text: text,
+		Data: fmt.Sprintf("%s:%s", callback, input),
 	}
-	return buttons
 }
 
 func formatCategoryList(categories []model.CategoryData) string {
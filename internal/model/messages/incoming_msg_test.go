@@ -3,14 +3,60 @@ package messages
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	messagesMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
 
 	"github.com/stretchr/testify/assert"
 )
 
+type testModel struct {
+	model        *Model
+	sender       *messagesMocks.MockMessageSender
+	userRepo     *messagesMocks.MockUserStore
+	categoryRepo *messagesMocks.MockCategoryStore
+	dialog       *messagesMocks.MockDialogHandler
+	converter    *messagesMocks.MockCurrencyConverter
+	rateHistory  *messagesMocks.MockRateHistory
+	rateAlerts   *messagesMocks.MockRateAlerts
+	apiTokens    *messagesMocks.MockAPITokens
+	webhooks     *messagesMocks.MockWebhooks
+	files        *messagesMocks.MockFileDownloader
+	receipts     *messagesMocks.MockReceiptImporter
+	transactions *messagesMocks.MockTransactionHistory
+}
+
+// newTestModel expects language lookup and dialog reset which precede every command of user 123
+func newTestModel(t *testing.T, language string) *testModel {
+	ctrl := gomock.NewController(t)
+	m := &testModel{
+		sender:       messagesMocks.NewMockMessageSender(ctrl),
+		userRepo:     messagesMocks.NewMockUserStore(ctrl),
+		categoryRepo: messagesMocks.NewMockCategoryStore(ctrl),
+		dialog:       messagesMocks.NewMockDialogHandler(ctrl),
+		converter:    messagesMocks.NewMockCurrencyConverter(ctrl),
+		rateHistory:  messagesMocks.NewMockRateHistory(ctrl),
+		rateAlerts:   messagesMocks.NewMockRateAlerts(ctrl),
+		apiTokens:    messagesMocks.NewMockAPITokens(ctrl),
+		webhooks:     messagesMocks.NewMockWebhooks(ctrl),
+		files:        messagesMocks.NewMockFileDownloader(ctrl),
+		receipts:     messagesMocks.NewMockReceiptImporter(ctrl),
+		transactions: messagesMocks.NewMockTransactionHistory(ctrl),
+	}
+	m.model = New(m.sender, m.userRepo, m.categoryRepo, m.dialog,
+		messagesMocks.NewMockCurrencyAdmin(ctrl), m.converter, m.rateHistory, m.rateAlerts, m.apiTokens, m.webhooks,
+		m.files, m.receipts, m.transactions)
+	m.userRepo.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return(language, nil)
+	m.dialog.EXPECT().ResetDialog(gomock.Any(), int64(123))
+	return m
+}
+
 func TestOnStartCommand_ShouldAnswerWithIntroMessage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -18,10 +64,17 @@ func TestOnStartCommand_ShouldAnswerWithIntroMessage(t *testing.T) {
 	sender := messagesMocks.NewMockMessageSender(ctrl)
 	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
 	categoryRepoMock := messagesMocks.NewMockCategoryStore(ctrl)
-	model := New(sender, userRepoMock, categoryRepoMock)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	model := New(sender, userRepoMock, categoryRepoMock, dialogMock, messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
 
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("", nil)
+	dialogMock.EXPECT().ResetDialog(gomock.Any(), int64(123))
 	userRepoMock.EXPECT().SetUserCurrency(gomock.Any(), int64(123), "RUB").Times(1)
-	sender.EXPECT().SendMessage(constants.HelloMsg, int64(123))
+	sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.Hello), int64(123))
 
 	err := model.IncomingMessage(ctx, Message{
 		Text:   "/start",
@@ -38,9 +91,16 @@ func TestOnStartCommand_ShouldAnswerWithUnexpectedMessage(t *testing.T) {
 	sender := messagesMocks.NewMockMessageSender(ctrl)
 	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
 	categoryRepoMock := messagesMocks.NewMockCategoryStore(ctrl)
-	model := New(sender, userRepoMock, categoryRepoMock)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	model := New(sender, userRepoMock, categoryRepoMock, dialogMock, messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
 
-	sender.EXPECT().SendMessage(constants.UnrecognizedCommandMsg, int64(123))
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("", nil)
+	dialogMock.EXPECT().HandleTextInput(gomock.Any(), int64(123), i18n.RU, "what?").Return(false, nil)
+	sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.UnrecognizedCommand), int64(123))
 
 	err := model.IncomingMessage(ctx, Message{
 		Text:   "what?",
@@ -49,3 +109,198 @@ func TestOnStartCommand_ShouldAnswerWithUnexpectedMessage(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestOnStartCommand_ShouldAnswerInTelegramLanguage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	categoryRepoMock := messagesMocks.NewMockCategoryStore(ctrl)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	model := New(sender, userRepoMock, categoryRepoMock, dialogMock, messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("", nil)
+	dialogMock.EXPECT().ResetDialog(gomock.Any(), int64(123))
+	userRepoMock.EXPECT().SetUserCurrency(gomock.Any(), int64(123), "RUB").Times(1)
+	sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.Hello), int64(123))
+
+	err := model.IncomingMessage(ctx, Message{
+		Text:         "/start",
+		UserID:       123,
+		LanguageCode: "en-US",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOnChangeLanguageCommand_ShouldPreferStoredLanguage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	categoryRepoMock := messagesMocks.NewMockCategoryStore(ctrl)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	messagesModel := New(sender, userRepoMock, categoryRepoMock, dialogMock, messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("ru", nil)
+	dialogMock.EXPECT().ResetDialog(gomock.Any(), int64(123))
+	sender.EXPECT().SendMessageWithMarkup(i18n.T(i18n.RU, i18n.SpecifyLanguage), [][]model.MarkupData{
+		{
+			{Text: "Русский", Data: "change_language:ru"},
+			{Text: "English", Data: "change_language:en"},
+		},
+	}, int64(123))
+
+	err := messagesModel.IncomingMessage(ctx, Message{
+		Text:         "/change_language",
+		UserID:       123,
+		LanguageCode: "en",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOnTypedText_ShouldBeHandledByDialog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	categoryRepoMock := messagesMocks.NewMockCategoryStore(ctrl)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	model := New(sender, userRepoMock, categoryRepoMock, dialogMock, messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("", nil)
+	dialogMock.EXPECT().HandleTextInput(gomock.Any(), int64(123), i18n.RU, "1500").Return(true, nil)
+
+	err := model.IncomingMessage(ctx, Message{
+		Text:   "1500",
+		UserID: 123,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOnEnableCurrency_ShouldEnableCurrencyForAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	currencyAdminMock := messagesMocks.NewMockCurrencyAdmin(ctrl)
+	model := New(sender, userRepoMock, messagesMocks.NewMockCategoryStore(ctrl), dialogMock, currencyAdminMock,
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("en", nil)
+	dialogMock.EXPECT().ResetDialog(gomock.Any(), int64(123))
+	currencyAdminMock.EXPECT().IsAdmin(int64(123)).Return(true)
+	currencyAdminMock.EXPECT().EnableCurrency(gomock.Any(), "GEL", "").Return(
+		iso4217.Currency{Code: "GEL", Name: "лари", Symbol: "₾"}, nil)
+	sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.CurrencyEnabled, "GEL", "₾"), int64(123))
+
+	err := model.IncomingMessage(ctx, Message{
+		Text:   "/enable_currency gel",
+		UserID: 123,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOnEnableCurrency_ShouldBeUnknownForNotAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	dialogMock := messagesMocks.NewMockDialogHandler(ctrl)
+	currencyAdminMock := messagesMocks.NewMockCurrencyAdmin(ctrl)
+	model := New(sender, userRepoMock, messagesMocks.NewMockCategoryStore(ctrl), dialogMock, currencyAdminMock,
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), messagesMocks.NewMockReceiptImporter(ctrl),
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("", nil)
+	dialogMock.EXPECT().ResetDialog(gomock.Any(), int64(123))
+	currencyAdminMock.EXPECT().IsAdmin(int64(123)).Return(false)
+	sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.UnrecognizedCommand), int64(123))
+
+	err := model.IncomingMessage(ctx, Message{
+		Text:   "/disable_currency USD",
+		UserID: 123,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestGetCurrencies_ShouldWrapRows(t *testing.T) {
+	rows := getCurrencies([]string{"USD", "EUR", "CNY", "GEL", "KZT"})
+
+	assert.Len(t, rows, 2)
+	assert.Len(t, rows[0], currenciesPerRow)
+	assert.Equal(t, model.MarkupData{Text: "KZT", Data: "change_currency:KZT"}, rows[1][0])
+}
+
+func TestOnConvert_ShouldShowResultWithRateProviderAndDate(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	date := time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)
+	m.converter.EXPECT().Convert(gomock.Any(), decimal.NewFromInt(120), "EUR", "RUB", date).Return(model.Conversion{
+		Amount: decimal.NewFromInt(120),
+		From:   model.CurrencyData{ID: "EUR", Symbol: "€", Precision: 2},
+		To:     model.CurrencyData{ID: "RUB", Symbol: "₽", Precision: 2},
+		Result: decimal.NewFromInt(10560),
+		Rate:   decimal.NewFromInt(88),
+		Date:   date,
+		Legs: []model.Rate{{Currency: "EUR", Multiplier: decimal.RequireFromString("0.0114"), Date: date,
+			Provider: "cbr", EffectiveDate: date.AddDate(0, 0, -1)}},
+	}, nil)
+	m.sender.EXPECT().SendMessage("120.00\u00a0EUR = 10,560.00\u00a0RUB\n1 EUR = 88.0000 RUB\n"+
+		"EUR rate: Bank of Russia as of 2026-08-14", int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/convert 120 eur rub 2026-08-15", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnConvert_ShouldExplainUsageAndErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		convertErr error
+		want       i18n.Key
+	}{
+		{name: "missing currencies", text: "/convert 120", want: i18n.ConvertUsage},
+		{name: "bad date", text: "/convert 120 EUR RUB 15.08.2026", want: i18n.ConvertUsage},
+		{name: "negative amount", text: "/convert -5 EUR RUB", want: i18n.ConvertUsage},
+		{name: "future date", text: "/convert 5 EUR RUB", convertErr: constants.FutureDateErr, want: i18n.ConvertFutureDate},
+		{name: "disabled currency", text: "/convert 5 XYZ RUB", convertErr: constants.UndefinedCurrencyErr,
+			want: i18n.ConvertUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, "")
+			if tt.convertErr != nil {
+				m.converter.EXPECT().Convert(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(model.Conversion{}, tt.convertErr)
+			}
+			m.sender.EXPECT().SendMessage(i18n.T(i18n.RU, tt.want), int64(123))
+
+			err := m.model.IncomingMessage(context.Background(), Message{Text: tt.text, UserID: 123})
+			assert.NoError(t, err)
+		})
+	}
+}
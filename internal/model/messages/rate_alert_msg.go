@@ -0,0 +1,141 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+var alertDirections = map[string]string{
+	"<":     model.AlertBelow,
+	">":     model.AlertAbove,
+	"below": model.AlertBelow,
+	"above": model.AlertAbove,
+	"ниже":  model.AlertBelow,
+	"выше":  model.AlertAbove,
+}
+
+// addAlert handles "/alert USD < 85"
+func (s *Model) addAlert(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Alert)
+	defer span.Finish()
+
+	alert, ok := parseAlertArgs(msg.UserID, args)
+	if !ok {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.AlertUsage), msg.UserID)
+	}
+	alert, err := s.rateAlerts.AddAlert(ctx, alert)
+	switch {
+	case errors.Is(err, constants.UndefinedCurrencyErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.UndefinedCurrency), msg.UserID)
+	case errors.Is(err, constants.TooManyAlertsErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TooManyAlerts), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add rate alert", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeAlerts), msg.UserID)
+	}
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.AlertAdded, alert.ID, alert.Currency,
+		i18n.AlertDirection(lang, alert.Direction), formatPrice(lang, alert.Threshold)), msg.UserID)
+}
+
+// showAlerts handles "/alerts"
+func (s *Model) showAlerts(ctx context.Context, msg Message, lang i18n.Lang) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Alerts)
+	defer span.Finish()
+
+	alerts, err := s.rateAlerts.GetAlerts(ctx, msg.UserID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot show rate alerts", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	if len(alerts) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoAlerts), msg.UserID)
+	}
+	var formatted bytes.Buffer
+	formatted.WriteString(i18n.T(lang, i18n.AlertsHeader))
+	for _, a := range alerts {
+		formatted.WriteString(i18n.T(lang, i18n.AlertLine, a.ID, a.Currency,
+			i18n.AlertDirection(lang, a.Direction), formatPrice(lang, a.Threshold)))
+	}
+	formatted.WriteRune('\n')
+	formatted.WriteString(i18n.T(lang, i18n.DeleteAlertUsage))
+	return s.tgClient.SendMessage(formatted.String(), msg.UserID)
+}
+
+// deleteAlert handles "/delete_alert 3"
+func (s *Model) deleteAlert(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.DeleteAlert)
+	defer span.Finish()
+
+	if len(args) != 1 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.DeleteAlertUsage), msg.UserID)
+	}
+	alertID, err := parseNumber(args[0])
+	if err != nil {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.DeleteAlertUsage), msg.UserID)
+	}
+	err = s.rateAlerts.DeleteAlert(ctx, msg.UserID, alertID)
+	switch {
+	case errors.Is(err, constants.AlertNotFoundErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.AlertNotFound), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot delete rate alert", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeAlerts), msg.UserID)
+	}
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.AlertDeleted, alertID), msg.UserID)
+}
+
+func parseAlertArgs(userID int64, args []string) (model.RateAlert, bool) {
+	if len(args) != 3 {
+		return model.RateAlert{}, false
+	}
+	direction, ok := alertDirections[strings.ToLower(args[1])]
+	if !ok {
+		return model.RateAlert{}, false
+	}
+	threshold, err := decimal.NewFromString(strings.ReplaceAll(args[2], ",", "."))
+	if err != nil || !threshold.IsPositive() {
+		return model.RateAlert{}, false
+	}
+	return model.RateAlert{
+		UserID:    userID,
+		Currency:  strings.ToUpper(args[0]),
+		Direction: direction,
+		Threshold: threshold,
+	}, true
+}
+
+// RateAlertNotifier sends triggered rate alerts to their owners in their language
+type RateAlertNotifier struct {
+	tgClient MessageSender
+	userRepo UserStore
+}
+
+func NewRateAlertNotifier(tgClient MessageSender, userRepo UserStore) *RateAlertNotifier {
+	return &RateAlertNotifier{
+		tgClient: tgClient,
+		userRepo: userRepo,
+	}
+}
+
+func (n *RateAlertNotifier) NotifyRateAlert(ctx context.Context, alert model.RateAlert, price decimal.Decimal) error {
+	preferred, err := n.userRepo.GetUserLanguage(ctx, alert.UserID)
+	if err != nil {
+		logger.Warn("cannot get user language for rate alert", zap.Int64("userID", alert.UserID), zap.Error(err))
+	}
+	lang := i18n.Resolve(preferred, "")
+	return n.tgClient.SendMessage(i18n.T(lang, i18n.AlertTriggered, alert.Currency,
+		i18n.AlertDirection(lang, alert.Direction), formatPrice(lang, alert.Threshold), formatPrice(lang, price)), alert.UserID)
+}
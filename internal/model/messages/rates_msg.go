@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/sparkline"
+	"go.uber.org/zap"
+)
+
+const defaultHistoryDays = 30
+
+// showRates handles "/rates" with overview of enabled currencies and "/rates USD [days]" with history of one currency
+func (s *Model) showRates(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Rates)
+	defer span.Finish()
+
+	if len(args) == 0 {
+		return s.showRatesOverview(ctx, span, msg, lang)
+	}
+	days := defaultHistoryDays
+	if len(args) > 1 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed <= 0 {
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.RatesUsage), msg.UserID)
+		}
+		days = parsed
+	}
+	currency := strings.ToUpper(args[0])
+	points, err := s.rateHistory.History(ctx, currency, days)
+	switch {
+	case errors.Is(err, constants.UndefinedCurrencyErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.UndefinedCurrency), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot show rate history", zap.String("currency", currency), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotShowRates), msg.UserID)
+	case len(points) == 0:
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoRates), msg.UserID)
+	}
+	return s.tgClient.SendMessage(formatRateHistory(lang, currency, days, points), msg.UserID)
+}
+
+func (s *Model) showRatesOverview(ctx context.Context, span opentracing.Span, msg Message, lang i18n.Lang) error {
+	changes, err := s.rateHistory.Overview(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot show rates overview", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotShowRates), msg.UserID)
+	}
+	if len(changes) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoRates), msg.UserID)
+	}
+	return s.tgClient.SendMessage(formatRatesOverview(lang, changes), msg.UserID)
+}
+
+func formatRatesOverview(lang i18n.Lang, changes []model.RateChange) string {
+	latest := lo.MaxBy(changes, func(a, b model.RateChange) bool { return a.Date.After(b.Date) }).Date
+	var formatted bytes.Buffer
+	formatted.WriteString(i18n.T(lang, i18n.RatesHeader, formatDate(lang, latest)))
+	for _, c := range changes {
+		formatted.WriteString(i18n.T(lang, i18n.RatesLine, c.Currency, formatPrice(lang, c.Price),
+			formatChange(lang, c.Change7), formatChange(lang, c.Change30)))
+	}
+	return formatted.String()
+}
+
+func formatRateHistory(lang i18n.Lang, currency string, days int, points []model.RatePoint) string {
+	prices := lo.Map(points, func(p model.RatePoint, _ int) decimal.Decimal { return p.Price() })
+	return i18n.T(lang, i18n.RatesHistory, currency, days, sparkline.Render(prices),
+		formatPrice(lang, decimal.Min(prices[0], prices[1:]...)),
+		formatPrice(lang, decimal.Max(prices[0], prices[1:]...)),
+		formatPrice(lang, prices[len(prices)-1]))
+}
+
+// formatPrice prints cost of foreign currency unit in server currency
+func formatPrice(lang i18n.Lang, price decimal.Decimal) string {
+	currency, _ := iso4217.Lookup(constants.ServerCurrency)
+	return money.Format(lang, price, model.CurrencyData{ID: currency.Code, Symbol: currency.Symbol})
+}
+
+func formatChange(lang i18n.Lang, change decimal.NullDecimal) string {
+	if !change.Valid {
+		return i18n.T(lang, i18n.NoChange)
+	}
+	sign := ""
+	if change.Decimal.IsPositive() {
+		sign = "+"
+	}
+	return sign + money.FormatNumber(lang, change.Decimal) + "%"
+}
+
+func formatDate(lang i18n.Lang, date time.Time) string {
+	return date.Format(i18n.T(lang, i18n.DateLayout))
+}
@@ -0,0 +1,117 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	messagesMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestOnRates_ShouldShowOverviewWithChanges(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.rateHistory.EXPECT().Overview(gomock.Any()).Return([]model.RateChange{
+		{
+			Currency: "USD",
+			Date:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			Price:    decimal.RequireFromString("88.12"),
+			Change7:  decimal.NewNullDecimal(decimal.RequireFromString("1.2")),
+			Change30: decimal.NewNullDecimal(decimal.RequireFromString("-3.45")),
+		},
+		{
+			Currency: "CNY",
+			Date:     time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			Price:    decimal.RequireFromString("12.1"),
+		},
+	}, nil)
+	m.sender.EXPECT().SendMessage("Rates on 2026-10-19:\n\n"+
+		"USD: ₽88.12   7 d: +1.20%   30 d: -3.45%\n"+
+		"CNY: ₽12.10   7 d: —   30 d: —\n", int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/rates", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnRates_ShouldShowHistorySparkline(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.rateHistory.EXPECT().History(gomock.Any(), "USD", 7).Return([]model.RatePoint{
+		{Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Multiplier: decimal.RequireFromString("0.0125")},
+		{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Multiplier: decimal.RequireFromString("0.01")},
+		{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Multiplier: decimal.RequireFromString("0.0125")},
+	}, nil)
+	m.sender.EXPECT().SendMessage("USD for 7 days:\n▁█▁\nmin ₽80.00 · max ₽100.00 · now ₽80.00", int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/rates usd 7", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnAlert_ShouldAddAlert(t *testing.T) {
+	m := newTestModel(t, "")
+
+	m.rateAlerts.EXPECT().AddAlert(gomock.Any(), model.RateAlert{
+		UserID:    123,
+		Currency:  "USD",
+		Direction: model.AlertBelow,
+		Threshold: decimal.NewFromInt(85),
+	}).DoAndReturn(func(_ context.Context, alert model.RateAlert) (model.RateAlert, error) {
+		alert.ID = 3
+		return alert, nil
+	})
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.AlertAdded, int64(3), "USD", "ниже", "85,00 ₽"), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/alert usd < 85", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnAlert_ShouldExplainUsage(t *testing.T) {
+	for _, text := range []string{"/alert", "/alert USD 85", "/alert USD = 85", "/alert USD > -1"} {
+		t.Run(text, func(t *testing.T) {
+			m := newTestModel(t, "")
+			m.sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.AlertUsage), int64(123))
+
+			err := m.model.IncomingMessage(context.Background(), Message{Text: text, UserID: 123})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOnDeleteAlert_ShouldReportMissingAlert(t *testing.T) {
+	m := newTestModel(t, "")
+
+	m.rateAlerts.EXPECT().DeleteAlert(gomock.Any(), int64(123), int64(5)).Return(constants.AlertNotFoundErr)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.AlertNotFound), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/delete_alert 5", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestRateAlertNotifier_ShouldSendInUserLanguage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sender := messagesMocks.NewMockMessageSender(ctrl)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	notifier := NewRateAlertNotifier(sender, userRepoMock)
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("en", nil)
+	sender.EXPECT().SendMessage("🔔 USD is below ₽85.00: now ₽84.50", int64(123))
+
+	err := notifier.NotifyRateAlert(context.Background(), model.RateAlert{
+		UserID:    123,
+		Currency:  "USD",
+		Direction: model.AlertBelow,
+		Threshold: decimal.NewFromInt(85),
+	}, decimal.RequireFromString("84.5"))
+
+	assert.NoError(t, err)
+}
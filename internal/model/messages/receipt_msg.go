@@ -0,0 +1,69 @@
+package messages
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/receipt"
+	"go.uber.org/zap"
+)
+
+// maxReceiptImageSize is the largest image downloaded to look for QR code on
+const maxReceiptImageSize = 10 << 20
+
+// receipt handles "/receipt t=...&s=...&fn=...&i=...&fp=...&n=1" with text of receipt QR code
+func (s *Model) receipt(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	if msg.Attachment != nil {
+		return s.scanReceipt(ctx, msg, lang)
+	}
+	if len(args) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.ReceiptHint), msg.UserID)
+	}
+	return s.readReceipt(ctx, msg, lang, strings.Join(args, ""))
+}
+
+// scanReceipt looks for receipt QR code on attached photo or image
+func (s *Model) scanReceipt(ctx context.Context, msg Message, lang i18n.Lang) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "scanReceipt")
+	defer span.Finish()
+
+	attachment := msg.Attachment
+	span.SetTag("kind", attachment.Kind)
+	if attachment.Kind != model.PhotoAttachment && !strings.HasPrefix(attachment.MimeType, "image/") ||
+		attachment.Size > maxReceiptImageSize {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.UnsupportedAttachment), msg.UserID)
+	}
+
+	data, err := s.files.DownloadFile(ctx, attachment.FileID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot download receipt image", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	text, err := receipt.DecodeQR(data)
+	if errors.Is(err, constants.QRCodeNotFoundErr) {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.QRCodeNotFound), msg.UserID)
+	}
+	if err != nil {
+		logger.Warn("cannot read receipt image", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.UnsupportedAttachment), msg.UserID)
+	}
+	return s.readReceipt(ctx, msg, lang, text)
+}
+
+// readReceipt parses text of receipt QR code and offers to add its expense, photo the code is read from
+// is attached to the expense
+func (s *Model) readReceipt(ctx context.Context, msg Message, lang i18n.Lang, text string) error {
+	parsed, err := receipt.Parse(text)
+	if err != nil {
+		logger.Info("cannot parse receipt", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InvalidReceipt), msg.UserID)
+	}
+	return s.receipts.StartReceipt(ctx, msg.UserID, lang, parsed, msg.Attachment)
+}
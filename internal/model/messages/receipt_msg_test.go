@@ -0,0 +1,106 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	messagesMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/messages"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+const qrString = "t=20261019T1230&s=1234.56&fn=9960440300000000&i=12345&fp=1234567890&n=1"
+
+func wantReceipt() model.Receipt {
+	return model.Receipt{
+		FiscalDriveNumber:    "9960440300000000",
+		FiscalDocumentNumber: "12345",
+		FiscalSign:           "1234567890",
+		Total:                decimal.RequireFromString("1234.56"),
+		Date:                 time.Date(2026, 10, 19, 12, 30, 0, 0, time.Local),
+	}
+}
+
+func TestOnReceipt_ShouldStartImportOfQRString(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.receipts.EXPECT().StartReceipt(gomock.Any(), int64(123), i18n.EN, wantReceipt(), gomock.Nil())
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/receipt " + qrString, UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnReceipt_ShouldExplainUsageAndInvalidReceipts(t *testing.T) {
+	m := newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.ReceiptHint), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/receipt", UserID: 123}))
+
+	m = newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.InvalidReceipt), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(),
+		Message{Text: "/receipt t=20261019T1230&s=0&fn=1&i=2&fp=3&n=1", UserID: 123}))
+}
+
+func TestOnReceipt_ShouldScanAttachedPhoto(t *testing.T) {
+	m := newTestModel(t, "en")
+	matrix, err := qrcode.NewQRCodeWriter().Encode(qrString, gozxing.BarcodeFormat_QR_CODE, 300, 300, nil)
+	require.NoError(t, err)
+	var photo bytes.Buffer
+	require.NoError(t, png.Encode(&photo, matrix))
+
+	attachment := &model.Attachment{Kind: model.PhotoAttachment, FileID: "photo", MimeType: "image/jpeg"}
+
+	m.files.EXPECT().DownloadFile(gomock.Any(), "photo").Return(photo.Bytes(), nil)
+	m.receipts.EXPECT().StartReceipt(gomock.Any(), int64(123), i18n.EN, wantReceipt(), attachment)
+
+	err = m.model.IncomingMessage(context.Background(), Message{Text: "/receipt", UserID: 123, Attachment: attachment})
+
+	assert.NoError(t, err)
+}
+
+func TestOnReceipt_ShouldRejectPhotoWithoutCodeAndOtherFiles(t *testing.T) {
+	m := newTestModel(t, "en")
+	matrix, err := gozxing.NewBitMatrix(300, 300)
+	require.NoError(t, err)
+	var photo bytes.Buffer
+	require.NoError(t, png.Encode(&photo, matrix))
+
+	m.files.EXPECT().DownloadFile(gomock.Any(), "photo").Return(photo.Bytes(), nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.QRCodeNotFound), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/receipt", UserID: 123,
+		Attachment: &model.Attachment{Kind: model.PhotoAttachment, FileID: "photo"}}))
+
+	m = newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.UnsupportedAttachment), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/receipt", UserID: 123,
+		Attachment: &model.Attachment{Kind: model.DocumentAttachment, FileID: "doc", MimeType: "application/pdf"}}))
+}
+
+func TestOnQRString_ShouldStartImportWithoutCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepoMock := messagesMocks.NewMockUserStore(ctrl)
+	receipts := messagesMocks.NewMockReceiptImporter(ctrl)
+	messagesModel := New(messagesMocks.NewMockMessageSender(ctrl), userRepoMock, messagesMocks.NewMockCategoryStore(ctrl),
+		messagesMocks.NewMockDialogHandler(ctrl), messagesMocks.NewMockCurrencyAdmin(ctrl),
+		messagesMocks.NewMockCurrencyConverter(ctrl), messagesMocks.NewMockRateHistory(ctrl),
+		messagesMocks.NewMockRateAlerts(ctrl), messagesMocks.NewMockAPITokens(ctrl), messagesMocks.NewMockWebhooks(ctrl),
+		messagesMocks.NewMockFileDownloader(ctrl), receipts,
+		messagesMocks.NewMockTransactionHistory(ctrl))
+
+	userRepoMock.EXPECT().GetUserLanguage(gomock.Any(), int64(123)).Return("en", nil)
+	receipts.EXPECT().StartReceipt(gomock.Any(), int64(123), i18n.EN, wantReceipt(), gomock.Nil())
+
+	err := messagesModel.IncomingMessage(context.Background(), Message{Text: qrString, UserID: 123})
+
+	assert.NoError(t, err)
+}
@@ -5,11 +5,12 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 )
 
-func (s *Model) showReport(ctx context.Context, msg Message) error {
+func (s *Model) showReport(ctx context.Context, msg Message, lang i18n.Lang) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.ShowReport) // nolint
 	defer span.Finish()
 
-	return s.tgClient.SendMessageWithMarkup(constants.SpecifyPeriodMsg, getPeriods(), msg.UserID)
+	return s.tgClient.SendMessageWithMarkup(i18n.T(lang, i18n.SpecifyPeriod), getPeriods(lang), msg.UserID)
 }
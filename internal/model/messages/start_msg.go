@@ -5,11 +5,12 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"go.uber.org/zap"
 )
 
-func (s *Model) start(ctx context.Context, msg Message) error {
+func (s *Model) start(ctx context.Context, msg Message, lang i18n.Lang) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Start)
 	defer span.Finish()
 
@@ -17,7 +18,7 @@ func (s *Model) start(ctx context.Context, msg Message) error {
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot set user currency", zap.Error(err))
-		return s.tgClient.SendMessage(constants.InternalServerErrorMsg, msg.UserID)
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
 	}
-	return s.tgClient.SendMessage(constants.HelloMsg, msg.UserID)
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.Hello), msg.UserID)
 }
@@ -0,0 +1,40 @@
+package messages
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+const revokeTokensArg = "revoke"
+
+// token handles "/token" issuing new API token and "/token revoke"
+func (s *Model) token(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Token)
+	defer span.Finish()
+
+	switch {
+	case len(args) == 0:
+		token, err := s.apiTokens.Issue(ctx, msg.UserID)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot issue api token", zap.Int64("userID", msg.UserID), zap.Error(err))
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeTokens), msg.UserID)
+		}
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TokenIssued, token), msg.UserID)
+	case len(args) == 1 && args[0] == revokeTokensArg:
+		revoked, err := s.apiTokens.RevokeAll(ctx, msg.UserID)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot revoke api tokens", zap.Int64("userID", msg.UserID), zap.Error(err))
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeTokens), msg.UserID)
+		}
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TokensRevoked, revoked), msg.UserID)
+	default:
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TokenUsage), msg.UserID)
+	}
+}
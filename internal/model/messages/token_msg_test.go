@@ -0,0 +1,44 @@
+package messages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+)
+
+func TestOnToken_ShouldIssueToken(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.apiTokens.EXPECT().Issue(gomock.Any(), int64(123)).Return("fb_secret", nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.TokenIssued, "fb_secret"), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/token", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnToken_ShouldRevokeTokens(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.apiTokens.EXPECT().RevokeAll(gomock.Any(), int64(123)).Return(int64(2), nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.TokensRevoked, int64(2)), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/token revoke", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnToken_ShouldExplainUsageAndErrors(t *testing.T) {
+	m := newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.TokenUsage), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/token delete", UserID: 123}))
+
+	m = newTestModel(t, "en")
+	m.apiTokens.EXPECT().Issue(gomock.Any(), int64(123)).Return("", errors.New("db is down"))
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.CannotChangeTokens), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/token", UserID: 123}))
+}
@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+const deliveryTimeLayout = "02.01 15:04"
+
+// addWebhook handles "/webhook https://example.com/hook LimitExceeded"
+func (s *Model) addWebhook(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Webhook)
+	defer span.Finish()
+
+	eventTypes := strings.Join(model.EventTypes, ", ")
+	if len(args) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.WebhookUsage, eventTypes), msg.UserID)
+	}
+	webhook, err := s.webhooks.AddWebhook(ctx, msg.UserID, args[0], args[1:])
+	switch {
+	case errors.Is(err, constants.InvalidWebhookURLErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InvalidWebhookURL), msg.UserID)
+	case errors.Is(err, constants.UnknownEventTypeErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.UnknownEventType, eventTypes), msg.UserID)
+	case errors.Is(err, constants.TooManyWebhooksErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.TooManyWebhooks), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add webhook", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeWebhooks), msg.UserID)
+	}
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.WebhookAdded, webhook.ID, webhook.URL,
+		formatEventTypes(lang, webhook.EventTypes), webhook.Secret), msg.UserID)
+}
+
+// showWebhooks handles "/webhooks"
+func (s *Model) showWebhooks(ctx context.Context, msg Message, lang i18n.Lang) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.Webhooks)
+	defer span.Finish()
+
+	webhooks, err := s.webhooks.GetWebhooks(ctx, msg.UserID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot show webhooks", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	if len(webhooks) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoWebhooks), msg.UserID)
+	}
+	var formatted bytes.Buffer
+	formatted.WriteString(i18n.T(lang, i18n.WebhooksHeader))
+	for _, w := range webhooks {
+		formatted.WriteString(i18n.T(lang, i18n.WebhookLine, w.ID, w.URL, formatEventTypes(lang, w.EventTypes)))
+	}
+	formatted.WriteRune('\n')
+	formatted.WriteString(i18n.T(lang, i18n.DeleteWebhookUsage))
+	return s.tgClient.SendMessage(formatted.String(), msg.UserID)
+}
+
+// deleteWebhook handles "/delete_webhook 3"
+func (s *Model) deleteWebhook(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.DeleteWebhook)
+	defer span.Finish()
+
+	if len(args) != 1 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.DeleteWebhookUsage), msg.UserID)
+	}
+	webhookID, err := parseNumber(args[0])
+	if err != nil {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.DeleteWebhookUsage), msg.UserID)
+	}
+	err = s.webhooks.DeleteWebhook(ctx, msg.UserID, webhookID)
+	switch {
+	case errors.Is(err, constants.WebhookNotFoundErr):
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.WebhookNotFound), msg.UserID)
+	case err != nil:
+		span.SetTag("error", err.Error())
+		logger.Error("cannot delete webhook", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.CannotChangeWebhooks), msg.UserID)
+	}
+	return s.tgClient.SendMessage(i18n.T(lang, i18n.WebhookDeleted, webhookID), msg.UserID)
+}
+
+// showWebhookLog handles "/webhook_log" with deliveries to all webhooks and "/webhook_log 3"
+func (s *Model) showWebhookLog(ctx context.Context, msg Message, lang i18n.Lang, args []string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, constants.WebhookLog)
+	defer span.Finish()
+
+	var webhookID int64
+	if len(args) > 0 {
+		var err error
+		if webhookID, err = parseNumber(args[0]); err != nil || len(args) > 1 {
+			return s.tgClient.SendMessage(i18n.T(lang, i18n.WebhookLogUsage), msg.UserID)
+		}
+	}
+	deliveries, err := s.webhooks.GetDeliveries(ctx, msg.UserID, webhookID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot show webhook deliveries", zap.Int64("userID", msg.UserID), zap.Error(err))
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.InternalServerError), msg.UserID)
+	}
+	if len(deliveries) == 0 {
+		return s.tgClient.SendMessage(i18n.T(lang, i18n.NoWebhookDeliveries), msg.UserID)
+	}
+	var formatted bytes.Buffer
+	formatted.WriteString(i18n.T(lang, i18n.WebhookLogHeader))
+	for _, d := range deliveries {
+		mark, result := "❌", d.Error
+		if d.Delivered {
+			mark, result = "✅", fmt.Sprintf("HTTP %d", d.StatusCode)
+		}
+		formatted.WriteString(i18n.T(lang, i18n.WebhookLogLine, mark, d.CreatedAt.Format(deliveryTimeLayout),
+			d.WebhookID, d.EventType, d.Attempt, result))
+	}
+	return s.tgClient.SendMessage(formatted.String(), msg.UserID)
+}
+
+func formatEventTypes(lang i18n.Lang, eventTypes []string) string {
+	if len(eventTypes) == 0 {
+		return i18n.T(lang, i18n.AllEvents)
+	}
+	return strings.Join(eventTypes, ", ")
+}
+
+// parseNumber parses number of listed item, e.g. "3", "#3" or "№3"
+func parseNumber(arg string) (int64, error) {
+	return strconv.ParseInt(strings.TrimLeft(arg, "#№"), 10, 64)
+}
@@ -0,0 +1,112 @@
+package messages
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestOnWebhook_ShouldAddWebhookAndShowSecret(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.webhooks.EXPECT().AddWebhook(gomock.Any(), int64(123), "https://example.com/hook", []string{"LimitExceeded"}).
+		Return(model.Webhook{ID: 3, URL: "https://example.com/hook", Secret: "whsec_secret",
+			EventTypes: []string{model.LimitExceededEvent}}, nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.WebhookAdded, int64(3), "https://example.com/hook",
+		"LimitExceeded", "whsec_secret"), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(),
+		Message{Text: "/webhook https://example.com/hook LimitExceeded", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnWebhook_ShouldExplainRejectedWebhook(t *testing.T) {
+	eventTypes := strings.Join(model.EventTypes, ", ")
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: errors.Wrap(constants.InvalidWebhookURLErr, "http://example.com"), want: i18n.T(i18n.EN, i18n.InvalidWebhookURL)},
+		{err: errors.Wrap(constants.UnknownEventTypeErr, "Foo"), want: i18n.T(i18n.EN, i18n.UnknownEventType, eventTypes)},
+		{err: constants.TooManyWebhooksErr, want: i18n.T(i18n.EN, i18n.TooManyWebhooks)},
+		{err: errors.New("db is down"), want: i18n.T(i18n.EN, i18n.CannotChangeWebhooks)},
+	}
+	for _, tt := range tests {
+		m := newTestModel(t, "en")
+		m.webhooks.EXPECT().AddWebhook(gomock.Any(), int64(123), "http://example.com", []string{}).
+			Return(model.Webhook{}, tt.err)
+		m.sender.EXPECT().SendMessage(tt.want, int64(123))
+		assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/webhook http://example.com", UserID: 123}))
+	}
+
+	m := newTestModel(t, "en")
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.WebhookUsage, eventTypes), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/webhook", UserID: 123}))
+}
+
+func TestOnWebhooks_ShouldListWebhooks(t *testing.T) {
+	m := newTestModel(t, "en")
+
+	m.webhooks.EXPECT().GetWebhooks(gomock.Any(), int64(123)).Return([]model.Webhook{
+		{ID: 1, URL: "https://example.com/all"},
+		{ID: 2, URL: "https://example.com/limits", EventTypes: []string{model.LimitSetEvent, model.LimitExceededEvent}},
+	}, nil)
+	m.sender.EXPECT().SendMessage("Your webhooks:\n\n"+
+		"#1: https://example.com/all\nEvents: all\n"+
+		"#2: https://example.com/limits\nEvents: LimitSet, LimitExceeded\n\n"+
+		i18n.T(i18n.EN, i18n.DeleteWebhookUsage), int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/webhooks", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnDeleteWebhook(t *testing.T) {
+	m := newTestModel(t, "en")
+	m.webhooks.EXPECT().DeleteWebhook(gomock.Any(), int64(123), int64(2)).Return(nil)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.WebhookDeleted, int64(2)), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/delete_webhook #2", UserID: 123}))
+
+	m = newTestModel(t, "en")
+	m.webhooks.EXPECT().DeleteWebhook(gomock.Any(), int64(123), int64(5)).Return(constants.WebhookNotFoundErr)
+	m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.WebhookNotFound), int64(123))
+	assert.NoError(t, m.model.IncomingMessage(context.Background(), Message{Text: "/delete_webhook 5", UserID: 123}))
+}
+
+func TestOnWebhookLog_ShouldShowDeliveries(t *testing.T) {
+	m := newTestModel(t, "en")
+	at := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+	m.webhooks.EXPECT().GetDeliveries(gomock.Any(), int64(123), int64(2)).Return([]model.WebhookDelivery{
+		{WebhookID: 2, EventType: model.LimitExceededEvent, Attempt: 2, StatusCode: 200, Delivered: true, CreatedAt: at},
+		{WebhookID: 2, EventType: model.LimitExceededEvent, Attempt: 1, StatusCode: 503,
+			Error: "webhook responded with status 503", CreatedAt: at.Add(-time.Minute)},
+	}, nil)
+	m.sender.EXPECT().SendMessage("Latest webhook deliveries:\n\n"+
+		"✅ 19.10 12:30 #2 LimitExceeded, attempt 2: HTTP 200\n"+
+		"❌ 19.10 12:29 #2 LimitExceeded, attempt 1: webhook responded with status 503\n", int64(123))
+
+	err := m.model.IncomingMessage(context.Background(), Message{Text: "/webhook_log 2", UserID: 123})
+
+	assert.NoError(t, err)
+}
+
+func TestOnWebhookLog_ShouldExplainInvalidArgument(t *testing.T) {
+	for _, text := range []string{"/webhook_log abc", "/webhook_log 1 2"} {
+		m := newTestModel(t, "en")
+		m.sender.EXPECT().SendMessage(i18n.T(i18n.EN, i18n.WebhookLogUsage), int64(123))
+
+		err := m.model.IncomingMessage(context.Background(), Message{Text: text, UserID: 123})
+
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rate is multiplier of currency against server currency on date with provider which published it
+type Rate struct {
+	Currency   string
+	Multiplier decimal.Decimal
+	Date       time.Time
+	Provider   string
+	// EffectiveDate is date rate was published for, it precedes Date on weekends and holidays
+	EffectiveDate time.Time
+}
+
+// Quote is multiplier of currency loaded from rate provider, units of currency per one server currency
+type Quote struct {
+	Multiplier decimal.Decimal `json:"multiplier"`
+	Provider   string          `json:"provider"`
+	// Date is date rate was published for, it may precede requested one
+	Date time.Time `json:"date"`
+}
+
+// Conversion is amount converted between two currencies through server currency
+type Conversion struct {
+	Amount decimal.Decimal
+	From   CurrencyData
+	To     CurrencyData
+	Result decimal.Decimal
+	// Rate is number of To units per one From unit
+	Rate decimal.Decimal
+	Date time.Time
+	// Legs contain rates of both currencies except server currency which rate is always 1
+	Legs []Rate
+}
+
+// RatePoint is stored rate of currency on date
+type RatePoint struct {
+	Date       time.Time
+	Multiplier decimal.Decimal
+}
+
+// Price is cost of one currency unit in server currency, e.g. 88.12 RUB for USD
+func (p RatePoint) Price() decimal.Decimal {
+	if p.Multiplier.IsZero() {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(1).DivRound(p.Multiplier, 4)
+}
+
+// RateChange is current price of currency with its change in percents over week and month
+type RateChange struct {
+	Currency string
+	Date     time.Time
+	Price    decimal.Decimal
+	Change7  decimal.NullDecimal
+	Change30 decimal.NullDecimal
+}
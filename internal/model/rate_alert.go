@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	AlertBelow = "below"
+	AlertAbove = "above"
+)
+
+// RateAlert notifies user once when price of currency in server currency crosses threshold
+type RateAlert struct {
+	ID        int64
+	UserID    int64
+	Currency  string
+	Direction string
+	Threshold decimal.Decimal
+	CreatedAt time.Time
+}
+
+// Triggered reports whether price crossed threshold in alert direction
+func (a RateAlert) Triggered(price decimal.Decimal) bool {
+	if a.Direction == AlertAbove {
+		return price.GreaterThan(a.Threshold)
+	}
+	return price.LessThan(a.Threshold)
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Receipt is Russian fiscal receipt read from its QR code, fiscal drive number, document number and fiscal sign
+// identify it, total is in rubles
+type Receipt struct {
+	FiscalDriveNumber    string          `json:"fiscal_drive_number"`
+	FiscalDocumentNumber string          `json:"fiscal_document_number"`
+	FiscalSign           string          `json:"fiscal_sign"`
+	Total                decimal.Decimal `json:"total"`
+	Date                 time.Time       `json:"date"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// ReportData contains expenses by categories in user currency
+type ReportData struct {
+	Expenses map[string]decimal.Decimal
+	// PendingRates is number of dates which rates are still loaded by backfill, expenses of these dates are approximate
+	PendingRates int
+	// StaleRates is number of transactions converted by the nearest previous rate instead of the rate of their date
+	StaleRates int
+}
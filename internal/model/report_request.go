@@ -0,0 +1,11 @@
+package model
+
+// ReportRequest asks report worker to calculate expenses of period and send them to user
+type ReportRequest struct {
+	UserID int64 `json:"user_id"`
+	// Period is one of week, month or year
+	Period string `json:"period"`
+	// Currency and Language are resolved by bot when report is requested, so that worker needs no user settings
+	Currency string `json:"currency"`
+	Language string `json:"language"`
+}
@@ -6,8 +6,11 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Transaction is expense of user in server currency
 type Transaction struct {
+	ID         int64
 	Amount     decimal.Decimal
 	CategoryID string
 	Date       time.Time
+	Attachment *Attachment // photo, document or voice message attached by user
 }
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Webhook is HTTPS endpoint of user receiving signed events of this user, empty EventTypes subscribes to all events
+type Webhook struct {
+	ID         int64
+	UserID     int64
+	URL        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+// Subscribed reports whether events of given type are posted to webhook
+func (w Webhook) Subscribed(eventType string) bool {
+	return len(w.EventTypes) == 0 || lo.Contains(w.EventTypes, eventType)
+}
+
+// WebhookDelivery is one attempt to post event to webhook, it is shown to user in delivery log
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	IdempotencyKey string
+	EventType      string
+	Attempt        int
+	// StatusCode is zero when no response was received
+	StatusCode int
+	Error      string
+	Delivered  bool
+	CreatedAt  time.Time
+}
+
+// WebhookTask is pending delivery of event to one webhook, it is retried independently of other webhooks and sinks
+type WebhookTask struct {
+	ID             int64
+	Webhook        Webhook
+	IdempotencyKey string
+	EventType      string
+	Body           []byte
+	Attempts       int
+}
@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaProducer publishes messages to Kafka, key chooses partition so that messages of one key keep order
+type KafkaProducer struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaProducer(brokers []string) *KafkaProducer {
+	return &KafkaProducer{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// Publish waits until message is acknowledged by all in-sync replicas
+func (p *KafkaProducer) Publish(ctx context.Context, topic string, msg Message) error {
+	span, msg := traced(ctx, topic, msg)
+	defer span.Finish()
+
+	err := p.writer.WriteMessages(ctx, toKafkaMessage(topic, msg))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrapf(err, "cannot publish message to topic '%s'", topic)
+	}
+	return nil
+}
+
+func (p *KafkaProducer) Close() error {
+	return p.writer.Close()
+}
+
+// KafkaConsumer reads topics as member of consumer group, so that partitions are shared by worker instances
+type KafkaConsumer struct {
+	brokers []string
+	groupID string
+}
+
+func NewKafkaConsumer(brokers []string, groupID string) *KafkaConsumer {
+	return &KafkaConsumer{
+		brokers: brokers,
+		groupID: groupID,
+	}
+}
+
+// Consume handles messages of topic one by one until ctx is done, offset is committed after message is handled
+// so that message is redelivered only if worker stops in the middle of handling
+func (c *KafkaConsumer) Consume(ctx context.Context, topic string, handler Handler) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: c.brokers,
+		GroupID: c.groupID,
+		Topic:   topic,
+	})
+	defer reader.Close()
+
+	for {
+		kafkaMsg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "cannot fetch message of topic '%s'", topic)
+		}
+		handle(ctx, topic, fromKafkaMessage(kafkaMsg), handler)
+		if err = reader.CommitMessages(ctx, kafkaMsg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "cannot commit message of topic '%s'", topic)
+		}
+	}
+}
+
+func toKafkaMessage(topic string, msg Message) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return kafka.Message{
+		Topic:   topic,
+		Key:     []byte(msg.Key),
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+func fromKafkaMessage(msg kafka.Message) Message {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	return Message{
+		Key:     string(msg.Key),
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
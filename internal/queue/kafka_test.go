@@ -0,0 +1,16 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaMessage_KeepsKeyValueAndHeaders(t *testing.T) {
+	msg := Message{Key: "42", Value: []byte(`{"user_id":42}`), Headers: map[string]string{"uber-trace-id": "1:2:0:1"}}
+
+	kafkaMsg := toKafkaMessage("reports", msg)
+
+	assert.Equal(t, "reports", kafkaMsg.Topic)
+	assert.Equal(t, msg, fromKafkaMessage(kafkaMsg))
+}
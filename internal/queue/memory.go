@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Memory is in-process queue for tests and local runs, messages are lost on restart
+// and each of them is consumed once by any consumer of topic
+type Memory struct {
+	mu     sync.Mutex
+	topics map[string]chan Message
+	size   int
+}
+
+// NewMemory creates queue which keeps up to size unconsumed messages per topic
+func NewMemory(size int) *Memory {
+	return &Memory{
+		topics: make(map[string]chan Message),
+		size:   size,
+	}
+}
+
+func (m *Memory) topic(name string) chan Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ch, ok := m.topics[name]
+	if !ok {
+		ch = make(chan Message, m.size)
+		m.topics[name] = ch
+	}
+	return ch
+}
+
+// Publish does not wait for consumers, error is returned when topic is full
+func (m *Memory) Publish(ctx context.Context, topic string, msg Message) error {
+	span, msg := traced(ctx, topic, msg)
+	defer span.Finish()
+
+	select {
+	case m.topic(topic) <- msg:
+		return nil
+	default:
+		span.SetTag("error", "queue is full")
+		return errors.Errorf("queue of topic '%s' is full", topic)
+	}
+}
+
+// Consume handles messages of topic one by one until ctx is done
+func (m *Memory) Consume(ctx context.Context, topic string, handler Handler) error {
+	ch := m.topic(topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-ch:
+			handle(ctx, topic, msg, handler)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// consumeAll handles messages of topic in background until test ends
+func consumeAll(t *testing.T, q *Memory, topic string, handler Handler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, q.Consume(ctx, topic, handler))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestMemory_ConsumesMessagesInOrder(t *testing.T) {
+	q := NewMemory(10)
+	got := make(chan string, 10)
+	consumeAll(t, q, "reports", func(_ context.Context, msg Message) error {
+		got <- string(msg.Value)
+		return nil
+	})
+
+	for _, value := range []string{"first", "second", "third"} {
+		require.NoError(t, q.Publish(context.Background(), "reports", Message{Key: "1", Value: []byte(value)}))
+	}
+	require.NoError(t, q.Publish(context.Background(), "other", Message{Key: "1", Value: []byte("skipped")}))
+
+	for _, want := range []string{"first", "second", "third"} {
+		select {
+		case value := <-got:
+			assert.Equal(t, want, value)
+		case <-time.After(time.Second):
+			t.Fatalf("message %q is not consumed", want)
+		}
+	}
+}
+
+func TestMemory_RejectsMessageWhenTopicIsFull(t *testing.T) {
+	q := NewMemory(1)
+
+	assert.NoError(t, q.Publish(context.Background(), "reports", Message{Key: "1"}))
+	assert.Error(t, q.Publish(context.Background(), "reports", Message{Key: "2"}))
+}
+
+func TestMemory_ContinuesTraceOfProducer(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	q := NewMemory(1)
+	parent := tracer.StartSpan("HandleIncomingCallback")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+	msg := Message{Key: "1", Headers: map[string]string{"source": "bot"}}
+	require.NoError(t, q.Publish(ctx, "reports", msg))
+	parent.Finish()
+	assert.Len(t, msg.Headers, 1, "headers of published message are copied")
+
+	handled := make(chan *mocktracer.MockSpan, 1)
+	consumeAll(t, q, "reports", func(ctx context.Context, msg Message) error {
+		assert.Equal(t, "bot", msg.Headers["source"])
+		handled <- opentracing.SpanFromContext(ctx).(*mocktracer.MockSpan)
+		return nil
+	})
+
+	consumer := <-handled
+	assert.Equal(t, "consume:reports", consumer.OperationName)
+	assert.Equal(t, parent.Context().(mocktracer.MockSpanContext).TraceID, consumer.SpanContext.TraceID)
+}
@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/tracing"
+	"go.uber.org/zap"
+)
+
+const (
+	// MemoryBackend keeps messages in process, so they are consumed by the same process
+	MemoryBackend = "memory"
+	KafkaBackend  = "kafka"
+)
+
+// Message is event of message queue, messages with the same key are consumed in order they were published
+type Message struct {
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Handler processes consumed message, failed messages are logged and not redelivered
+type Handler func(ctx context.Context, msg Message) error
+
+// handle continues trace of producer and logs failure of handler
+func handle(ctx context.Context, topic string, msg Message, handler Handler) {
+	span, ctx := tracing.StartConsumerSpan(ctx, topic, msg.Headers)
+	defer span.Finish()
+
+	if err := handler(ctx, msg); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot handle message",
+			zap.String("topic", topic),
+			zap.String("key", msg.Key),
+			zap.Error(err))
+	}
+}
+
+// traced starts producer span and returns copy of message with its context in headers
+func traced(ctx context.Context, topic string, msg Message) (opentracing.Span, Message) {
+	headers := make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	span := tracing.InjectHeaders(ctx, topic, headers)
+	span.SetTag("key", msg.Key)
+	msg.Headers = headers
+	return span, msg
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// APITokenRepository keeps hashes of api tokens only, tokens themselves are shown to user once
+type APITokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPITokenRepository(pool *pgxpool.Pool) *APITokenRepository {
+	return &APITokenRepository{
+		pool: pool,
+	}
+}
+
+func (r APITokenRepository) AddToken(ctx context.Context, userID int64, tokenHash string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddToken")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.api_token (user_id, token_hash) VALUES ($1, $2)`
+	span.SetTag("sql", sql)
+	if _, err := r.pool.Exec(ctx, sql, userID, tokenHash); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add api token", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetUserByToken returns owner of token, constants.TokenNotFoundErr is returned for unknown or revoked token
+func (r APITokenRepository) GetUserByToken(ctx context.Context, tokenHash string) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetUserByToken")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT user_id FROM financial_bot.api_token WHERE token_hash = $1`
+	span.SetTag("sql", sql)
+	var userID int64
+	if err := r.pool.QueryRow(ctx, sql, tokenHash).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, constants.TokenNotFoundErr
+		}
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get user by api token", zap.Error(err))
+		return 0, err
+	}
+	return userID, nil
+}
+
+// RevokeTokens deletes all tokens of user and returns their number
+func (r APITokenRepository) RevokeTokens(ctx context.Context, userID int64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:RevokeTokens")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `DELETE FROM financial_bot.api_token WHERE user_id = $1`
+	span.SetTag("sql", sql)
+	tag, err := r.pool.Exec(ctx, sql, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot revoke api tokens", zap.Int64("userID", userID), zap.Error(err))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
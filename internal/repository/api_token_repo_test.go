@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+)
+
+func TestAPITokenRepo(t *testing.T) {
+	ctx := context.Background()
+	dbContainer, connPool := SetupTestDatabase()
+	defer dbContainer.Terminate(ctx) // nolint
+
+	repository := NewAPITokenRepository(connPool)
+	userID := int64(5551234)
+	assert.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "RUB"))
+
+	t.Run("resolving user by token hash", func(t *testing.T) {
+		assert.NoError(t, repository.AddToken(ctx, userID, "hash1"))
+		assert.NoError(t, repository.AddToken(ctx, userID, "hash2"))
+
+		owner, err := repository.GetUserByToken(ctx, "hash2")
+		assert.NoError(t, err)
+		assert.Equal(t, userID, owner)
+
+		_, err = repository.GetUserByToken(ctx, "unknown")
+		assert.ErrorIs(t, err, constants.TokenNotFoundErr)
+	})
+
+	t.Run("revoking all tokens of user", func(t *testing.T) {
+		revoked, err := repository.RevokeTokens(ctx, userID)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, revoked)
+
+		_, err = repository.GetUserByToken(ctx, "hash1")
+		assert.ErrorIs(t, err, constants.TokenNotFoundErr)
+	})
+}
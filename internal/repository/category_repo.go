@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/lo"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"go.uber.org/zap"
@@ -22,14 +23,18 @@ func NewCategoryRepository(pool *pgxpool.Pool) *CategoryRepository {
 	}
 }
 
-func (c CategoryRepository) GetAllCategories(ctx context.Context) (category []model.CategoryData, err error) {
+func (c CategoryRepository) GetAllCategories(ctx context.Context, lang string) (category []model.CategoryData, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetAllCategories")
 	defer span.Finish()
 
 	// language=SQL
-	sql := `SELECT id, name_ru FROM financial_bot.category`
+	sql := `SELECT c.id, COALESCE(t.name, d.name, c.id)
+			FROM financial_bot.category c
+				LEFT JOIN financial_bot.category_translation t ON t.category_id = c.id AND t.lang = $1
+				LEFT JOIN financial_bot.category_translation d ON d.category_id = c.id AND d.lang = $2`
 	span.SetTag("sql", sql)
-	rows, err := c.pool.Query(ctx, sql)
+	span.SetTag("lang", lang)
+	rows, err := c.pool.Query(ctx, sql, lang, string(i18n.DefaultLang))
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot extract categories from db", zap.Error(err))
@@ -53,11 +58,11 @@ func (c CategoryRepository) GetAllCategories(ctx context.Context) (category []mo
 	return categories, nil
 }
 
-func (c CategoryRepository) ResolveCategories(ctx context.Context, IDs []string) (category map[string]model.CategoryData, err error) {
+func (c CategoryRepository) ResolveCategories(ctx context.Context, lang string, IDs []string) (category map[string]model.CategoryData, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:ResolveCategories")
 	defer span.Finish()
 
-	categories, err := c.GetAllCategories(ctx)
+	categories, err := c.GetAllCategories(ctx, lang)
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot resolve categories from db", zap.Error(err))
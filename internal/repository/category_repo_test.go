@@ -15,15 +15,27 @@ func TestCategoryRepository(t *testing.T) {
 	repository := NewCategoryRepository(connPool)
 
 	t.Run("getting category list", func(t *testing.T) {
-		categories, err := repository.GetAllCategories(ctx)
+		categories, err := repository.GetAllCategories(ctx, "ru")
 		assert.NoError(t, err)
 		assert.Equal(t, 11, len(categories))
 	})
 
 	t.Run("resolving only several categories", func(t *testing.T) {
-		categories, err := repository.ResolveCategories(ctx, []string{"RESTAURANTS", "EDUCATION", "MEDICINE"})
+		categories, err := repository.ResolveCategories(ctx, "ru", []string{"RESTAURANTS", "EDUCATION", "MEDICINE"})
 		assert.NoError(t, err)
 		assert.Equal(t, len(categories), 3)
 		assert.Equal(t, "🎓 Образование", categories["EDUCATION"].Name)
 	})
+
+	t.Run("resolving categories in english", func(t *testing.T) {
+		categories, err := repository.ResolveCategories(ctx, "en", []string{"EDUCATION"})
+		assert.NoError(t, err)
+		assert.Equal(t, "🎓 Education", categories["EDUCATION"].Name)
+	})
+
+	t.Run("resolving categories in unknown language falls back to default", func(t *testing.T) {
+		categories, err := repository.ResolveCategories(ctx, "de", []string{"EDUCATION"})
+		assert.NoError(t, err)
+		assert.Equal(t, "🎓 Образование", categories["EDUCATION"].Name)
+	})
 }
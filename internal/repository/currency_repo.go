@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+	"go.uber.org/zap"
+)
+
+type CurrencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCurrencyRepository(pool *pgxpool.Pool) *CurrencyRepository {
+	return &CurrencyRepository{
+		pool: pool,
+	}
+}
+
+func (c CurrencyRepository) GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetCurrency")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT id, symbol, decimals, enabled FROM financial_bot.currency WHERE id = $1`
+	span.SetTag("sql", sql)
+	row := c.pool.QueryRow(ctx, sql, currencyID)
+	var currency model.CurrencyData
+	if err := row.Scan(&currency.ID, &currency.Symbol, &currency.Precision, &currency.Enabled); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract currency", zap.String("currencyID", currencyID), zap.Error(err))
+		return model.CurrencyData{ID: currencyID}, err
+	}
+	return currency, nil
+}
+
+// GetEnabledCurrencies returns currencies which users can choose and rates are loaded for
+func (c CurrencyRepository) GetEnabledCurrencies(ctx context.Context) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetEnabledCurrencies")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT id FROM financial_bot.currency WHERE enabled ORDER BY id`
+	span.SetTag("sql", sql)
+	rows, err := c.pool.Query(ctx, sql)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot select enabled currencies", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	currencies := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan enabled currencies", zap.Error(err))
+			return nil, err
+		}
+		currencies = append(currencies, id)
+	}
+	return currencies, rows.Err()
+}
+
+// EnableCurrency inserts new currency or enables existing one keeping its name and precision,
+// symbol given by admin replaces stored one, the saved symbol is returned
+func (c CurrencyRepository) EnableCurrency(ctx context.Context, currency iso4217.Currency, override string) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:EnableCurrency")
+	defer span.Finish()
+
+	symbol := override
+	if symbol == "" { // symbol from catalog is used for new currency only
+		symbol = currency.Symbol
+	}
+	// language=SQL
+	sql := `INSERT INTO financial_bot.currency (id, name_ru, symbol, decimals, enabled) VALUES ($1, $2, $3, $4, TRUE)
+			ON CONFLICT (id) DO UPDATE SET enabled = TRUE, symbol = COALESCE(NULLIF($5, ''), currency.symbol)
+			RETURNING symbol`
+	span.SetTag("sql", sql)
+	var saved string
+	row := c.pool.QueryRow(ctx, sql, currency.Code, currency.Name, symbol, currency.Precision, override)
+	if err := row.Scan(&saved); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot enable currency", zap.String("currencyID", currency.Code), zap.Error(err))
+		return "", err
+	}
+	return saved, nil
+}
+
+// DisableCurrency hides currency from users, users with this currency are switched to server currency
+// and get CurrencyChanged event
+func (c CurrencyRepository) DisableCurrency(ctx context.Context, currencyID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:DisableCurrency")
+	defer span.Finish()
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot begin transaction")
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	// language=SQL
+	sql := `UPDATE financial_bot.currency SET enabled = FALSE WHERE id = $1`
+	span.SetTag("sql", sql)
+	if _, err = tx.Exec(ctx, sql, currencyID); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot disable currency", zap.String("currencyID", currencyID), zap.Error(err))
+		return err
+	}
+	// language=SQL
+	sql = `WITH switched AS (
+				UPDATE financial_bot.user SET currency_id = $2 WHERE currency_id = $1 RETURNING id
+			)
+			INSERT INTO financial_bot.outbox (event_type, user_id, payload)
+			SELECT $3, id, jsonb_build_object('currency', $2::text) FROM switched`
+	if _, err = tx.Exec(ctx, sql, currencyID, constants.ServerCurrency, model.CurrencyChangedEvent); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot reset users currency", zap.String("currencyID", currencyID), zap.Error(err))
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+)
+
+func TestCurrencyRepository(t *testing.T) {
+	ctx := context.Background()
+	dbContainer, connPool := SetupTestDatabase()
+	defer dbContainer.Terminate(ctx) // nolint
+
+	repository := NewCurrencyRepository(connPool)
+
+	t.Run("getting currency with symbol", func(t *testing.T) {
+		currency, err := repository.GetCurrency(ctx, "RUB")
+		assert.NoError(t, err)
+		assert.Equal(t, model.CurrencyData{ID: "RUB", Symbol: "₽", Precision: 2, Enabled: true}, currency)
+	})
+
+	t.Run("enabling new currency", func(t *testing.T) {
+		symbol, err := repository.EnableCurrency(ctx, iso4217.Currency{Code: "GEL", Name: "лари", Symbol: "₾"}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "₾", symbol)
+		currencies, err := repository.GetEnabledCurrencies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"CNY", "EUR", "GEL", "RUB", "USD"}, currencies)
+	})
+
+	t.Run("enabling asset keeps its precision", func(t *testing.T) {
+		btc, _ := iso4217.Lookup("BTC")
+		_, err := repository.EnableCurrency(ctx, btc, "")
+		assert.NoError(t, err)
+		currency, err := repository.GetCurrency(ctx, "BTC")
+		assert.NoError(t, err)
+		assert.Equal(t, model.CurrencyData{ID: "BTC", Symbol: "₿", Precision: 8, Enabled: true}, currency)
+		assert.NoError(t, repository.DisableCurrency(ctx, "BTC"))
+	})
+
+	t.Run("enabling existing currency with new symbol", func(t *testing.T) {
+		symbol, err := repository.EnableCurrency(ctx, iso4217.Currency{Code: "GEL", Name: "лари", Symbol: "₾"}, "GEL")
+		assert.NoError(t, err)
+		assert.Equal(t, "GEL", symbol)
+		symbol, err = repository.EnableCurrency(ctx, iso4217.Currency{Code: "GEL", Name: "лари", Symbol: "₾"}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "GEL", symbol)
+	})
+
+	t.Run("disabling currency", func(t *testing.T) {
+		err := repository.DisableCurrency(ctx, "GEL")
+		assert.NoError(t, err)
+		currencies, err := repository.GetEnabledCurrencies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"CNY", "EUR", "RUB", "USD"}, currencies)
+	})
+
+	t.Run("getting unknown currency", func(t *testing.T) {
+		currency, err := repository.GetCurrency(ctx, "XYZ")
+		assert.Error(t, err)
+		assert.Equal(t, model.CurrencyData{ID: "XYZ"}, currency)
+	})
+}
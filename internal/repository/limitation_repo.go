@@ -10,7 +10,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"go.uber.org/zap"
 )
 
@@ -50,19 +52,27 @@ func (l LimitationRepository) CheckLimit(ctx context.Context, userID int64, cate
 	return amount.Sub(diff), diff.Cmp(amount) < 0, nil
 }
 
+// AddLimit sets limit of category replacing the previous one and writes LimitSet event
 func (l LimitationRepository) AddLimit(ctx context.Context, userID int64, categoryID string, upperBorder decimal.Decimal, untilDate time.Time) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddLimit")
 	defer span.Finish()
 
+	tx, err := l.pool.Begin(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot begin transaction")
+	}
+	defer tx.Rollback(ctx) // nolint
+
 	// language=SQL
 	sql := `INSERT INTO financial_bot.limitation (user_id, category_id, upper_border, until_date) 
 			VALUES ($1, $2, $3, $4) ON CONFLICT (user_id, category_id)
 			DO UPDATE SET upper_border = EXCLUDED.upper_border, until_date = EXCLUDED.until_date RETURNING (id)`
 	span.SetTag("sql", sql)
 
-	row := l.pool.QueryRow(ctx, sql, userID, categoryID, upperBorder, untilDate)
+	row := tx.QueryRow(ctx, sql, userID, categoryID, upperBorder, untilDate)
 	var transactionID int64
-	if err := row.Scan(&transactionID); err != nil {
+	if err = row.Scan(&transactionID); err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot add limitation",
 			zap.Int64("userID", userID),
@@ -71,5 +81,49 @@ func (l LimitationRepository) AddLimit(ctx context.Context, userID int64, catego
 			zap.Error(err))
 		return err
 	}
+	err = addEvent(ctx, tx, userID, model.LimitSetEvent, model.LimitSet{
+		CategoryID:  categoryID,
+		UpperBorder: upperBorder,
+		Currency:    constants.ServerCurrency,
+		UntilDate:   untilDate,
+	})
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot commit limitation")
+	}
 	return nil
 }
+
+// GetLimits returns limits of user which are not expired yet
+func (l LimitationRepository) GetLimits(ctx context.Context, userID int64) ([]model.Limit, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetLimits")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT category_id, upper_border, until_date FROM financial_bot.limitation
+	        WHERE user_id = $1 AND until_date > now() ORDER BY category_id`
+	span.SetTag("sql", sql)
+
+	rows, err := l.pool.Query(ctx, sql, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract limits", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	limits := make([]model.Limit, 0)
+	for rows.Next() {
+		var limit model.Limit
+		if err = rows.Scan(&limit.CategoryID, &limit.UpperBorder, &limit.UntilDate); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan limit", zap.Int64("userID", userID), zap.Error(err))
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
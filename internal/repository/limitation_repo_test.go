@@ -33,6 +33,14 @@ func TestLimitationRepo(t *testing.T) {
 		assert.Equal(t, diff, decimal.NewFromInt(500))
 	})
 
+	t.Run("listing active limits", func(t *testing.T) {
+		limits, err := repository.GetLimits(ctx, userID)
+		assert.NoError(t, err)
+		assert.Len(t, limits, 1)
+		assert.Equal(t, "EDUCATION", limits[0].CategoryID)
+		assert.Equal(t, "1000", limits[0].UpperBorder.String())
+	})
+
 	t.Run("check limit if no one exists", func(t *testing.T) {
 		_, under, err := repository.CheckLimit(ctx, userID, "TRANSPORT", decimal.NewFromInt(1500))
 		assert.NoError(t, err)
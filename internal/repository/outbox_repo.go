@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// addEvent writes domain event to outbox in transaction of the change it describes,
+// so that event is published if and only if the change is committed
+func addEvent(ctx context.Context, tx pgx.Tx, userID int64, eventType string, payload interface{}) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:addEvent")
+	defer span.Finish()
+	span.SetTag("eventType", eventType)
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrapf(err, "cannot encode %s event", eventType)
+	}
+	// language=SQL
+	sql := `INSERT INTO financial_bot.outbox (event_type, user_id, payload) VALUES ($1, $2, $3)`
+	span.SetTag("sql", sql)
+	if _, err = tx.Exec(ctx, sql, eventType, userID, raw); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add event to outbox",
+			zap.Int64("userID", userID),
+			zap.String("eventType", eventType),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{
+		pool: pool,
+	}
+}
+
+// ClaimEvents returns due undelivered events in order they were written and postpones their next attempt by lease,
+// so that concurrent relays skip them and events of crashed relay are delivered again when lease expires
+func (r *OutboxRepository) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:ClaimEvents")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.outbox SET next_attempt_at = now() + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id FROM financial_bot.outbox
+				WHERE published_at IS NULL AND next_attempt_at <= now()
+				ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, idempotency_key::text, event_type, user_id, payload, created_at, attempts`
+	span.SetTag("sql", sql)
+	rows, err := r.pool.Query(ctx, sql, limit, lease.Seconds())
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot claim outbox events", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	events := make([]model.Event, 0, limit)
+	for rows.Next() {
+		var e model.Event
+		if err = rows.Scan(&e.ID, &e.IdempotencyKey, &e.Type, &e.UserID, &e.Payload, &e.CreatedAt, &e.Attempts); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan outbox event", zap.Error(err))
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	// UPDATE does not keep order of subquery
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, rows.Err()
+}
+
+// MarkPublished stops delivery of event
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:MarkPublished")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.outbox SET published_at = now(), last_error = NULL WHERE id = $1`
+	span.SetTag("sql", sql)
+	if _, err := r.pool.Exec(ctx, sql, eventID); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot mark outbox event published", zap.Int64("eventID", eventID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// MarkFailed schedules next delivery of event after delay
+func (r *OutboxRepository) MarkFailed(ctx context.Context, eventID int64, reason string, delay time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:MarkFailed")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.outbox 
+			SET attempts = attempts + 1, last_error = $2, next_attempt_at = now() + make_interval(secs => $3)
+			WHERE id = $1`
+	span.SetTag("sql", sql)
+	if _, err := r.pool.Exec(ctx, sql, eventID, reason, delay.Seconds()); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot mark outbox event failed", zap.Int64("eventID", eventID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// PurgePublished deletes events published before the moment and returns their number
+func (r *OutboxRepository) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:PurgePublished")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `DELETE FROM financial_bot.outbox WHERE published_at < $1`
+	span.SetTag("sql", sql)
+	tag, err := r.pool.Exec(ctx, sql, before)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot purge published outbox events", zap.Error(err))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestOutboxRepo(t *testing.T) {
+	ctx := context.Background()
+	dbContainer, connPool := SetupTestDatabase()
+	defer dbContainer.Terminate(ctx) // nolint
+
+	repository := NewOutboxRepository(connPool)
+	userID := int64(5554321)
+
+	t.Run("changes are written with events", func(t *testing.T) {
+		require.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "USD"))
+		err := NewLimitationRepository(connPool).AddLimit(ctx, userID, "CLOTHES", decimal.NewFromInt(100),
+			time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		transactionID, err := NewTransactionRepository(connPool).AddOperation(ctx, userID, "CLOTHES",
+			decimal.NewFromInt(150), time.Now())
+		require.NoError(t, err)
+
+		events, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		types := make([]string, 0, len(events))
+		for _, e := range events {
+			types = append(types, e.Type)
+			assert.Equal(t, userID, e.UserID)
+			assert.NotEmpty(t, e.IdempotencyKey)
+		}
+		assert.Equal(t, []string{model.CurrencyChangedEvent, model.LimitSetEvent, model.TransactionCreatedEvent,
+			model.LimitExceededEvent}, types)
+
+		var exceeded model.LimitExceeded
+		require.NoError(t, json.Unmarshal(events[3].Payload, &exceeded))
+		assert.Equal(t, transactionID, exceeded.TransactionID)
+		assert.Equal(t, "150", exceeded.Spent.String())
+
+		claimedAgain, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, claimedAgain, "claimed events are hidden until lease expires")
+	})
+
+	t.Run("unchanged currency is not announced", func(t *testing.T) {
+		require.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "USD"))
+		_, err := connPool.Exec(ctx, `UPDATE financial_bot.outbox SET next_attempt_at = now()`)
+		require.NoError(t, err)
+		events, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Len(t, events, 4, "only events written before are due")
+		for _, e := range events {
+			require.NoError(t, repository.MarkPublished(ctx, e.ID))
+		}
+	})
+
+	t.Run("failed events are delivered again, published are not", func(t *testing.T) {
+		require.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "EUR"))
+		events, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		require.NoError(t, repository.MarkFailed(ctx, events[0].ID, "sink is down", 0))
+		retried, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, retried, 1)
+		assert.Equal(t, events[0].IdempotencyKey, retried[0].IdempotencyKey)
+		assert.Equal(t, 1, retried[0].Attempts)
+
+		require.NoError(t, repository.MarkPublished(ctx, retried[0].ID))
+		require.NoError(t, repository.MarkFailed(ctx, retried[0].ID, "late failure", 0))
+		published, err := repository.ClaimEvents(ctx, 10, time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, published)
+
+		purged, err := repository.PurgePublished(ctx, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, purged)
+	})
+}
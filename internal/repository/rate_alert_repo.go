@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+type RateAlertRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRateAlertRepository(pool *pgxpool.Pool) *RateAlertRepository {
+	return &RateAlertRepository{
+		pool: pool,
+	}
+}
+
+func (r RateAlertRepository) AddAlert(ctx context.Context, alert model.RateAlert) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddAlert")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.rate_alert (user_id, currency_id, direction, threshold)
+			VALUES ($1, $2, $3, $4) RETURNING id`
+	span.SetTag("sql", sql)
+	var id int64
+	err := r.pool.QueryRow(ctx, sql, alert.UserID, alert.Currency, alert.Direction, alert.Threshold).Scan(&id)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add rate alert",
+			zap.Int64("userID", alert.UserID),
+			zap.String("currency", alert.Currency),
+			zap.Error(err))
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r RateAlertRepository) GetUserAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetUserAlerts")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT id, user_id, currency_id, direction, threshold, created_at
+			FROM financial_bot.rate_alert WHERE user_id = $1 ORDER BY id`
+	span.SetTag("sql", sql)
+	return r.query(ctx, span, sql, userID)
+}
+
+// GetAlertsByCurrencies returns alerts of all users watching given currencies
+func (r RateAlertRepository) GetAlertsByCurrencies(ctx context.Context, currencies []string) ([]model.RateAlert, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetAlertsByCurrencies")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT id, user_id, currency_id, direction, threshold, created_at
+			FROM financial_bot.rate_alert WHERE currency_id = ANY($1) ORDER BY id`
+	span.SetTag("sql", sql)
+	return r.query(ctx, span, sql, pq.Array(currencies))
+}
+
+// DeleteAlert removes alert of user, constants.AlertNotFoundErr is returned for alert of another user
+func (r RateAlertRepository) DeleteAlert(ctx context.Context, userID, alertID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:DeleteAlert")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `DELETE FROM financial_bot.rate_alert WHERE id = $1 AND user_id = $2`
+	span.SetTag("sql", sql)
+	tag, err := r.pool.Exec(ctx, sql, alertID, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot delete rate alert",
+			zap.Int64("userID", userID),
+			zap.Int64("alertID", alertID),
+			zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return constants.AlertNotFoundErr
+	}
+	return nil
+}
+
+func (r RateAlertRepository) query(ctx context.Context, span opentracing.Span, sql string, args ...interface{}) ([]model.RateAlert, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract rate alerts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	alerts := make([]model.RateAlert, 0)
+	for rows.Next() {
+		var a model.RateAlert
+		if err = rows.Scan(&a.ID, &a.UserID, &a.Currency, &a.Direction, &a.Threshold, &a.CreatedAt); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan rate alert", zap.Error(err))
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, nil
+}
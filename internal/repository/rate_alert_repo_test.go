@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestRateAlertRepo(t *testing.T) {
+	ctx := context.Background()
+	dbContainer, connPool := SetupTestDatabase()
+	defer dbContainer.Terminate(ctx) // nolint
+
+	repository := NewRateAlertRepository(connPool)
+	userID := int64(7654321)
+	assert.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "RUB"))
+
+	var alertID int64
+	t.Run("adding alert", func(t *testing.T) {
+		var err error
+		alertID, err = repository.AddAlert(ctx, model.RateAlert{
+			UserID:    userID,
+			Currency:  "USD",
+			Direction: model.AlertBelow,
+			Threshold: decimal.NewFromInt(85),
+		})
+		assert.NoError(t, err)
+
+		alerts, err := repository.GetUserAlerts(ctx, userID)
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, "USD", alerts[0].Currency)
+		assert.Equal(t, "85", alerts[0].Threshold.String())
+	})
+
+	t.Run("getting alerts by currencies", func(t *testing.T) {
+		alerts, err := repository.GetAlertsByCurrencies(ctx, []string{"USD", "EUR"})
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+
+		alerts, err = repository.GetAlertsByCurrencies(ctx, []string{"CNY"})
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+
+	t.Run("deleting alert of another user", func(t *testing.T) {
+		err := repository.DeleteAlert(ctx, userID+1, alertID)
+		assert.ErrorIs(t, err, constants.AlertNotFoundErr)
+	})
+
+	t.Run("deleting alert", func(t *testing.T) {
+		assert.NoError(t, repository.DeleteAlert(ctx, userID, alertID))
+		alerts, err := repository.GetUserAlerts(ctx, userID)
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+	})
+}
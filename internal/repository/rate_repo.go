@@ -9,6 +9,7 @@ import (
 
 	"github.com/lib/pq"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"go.uber.org/zap"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -27,13 +28,14 @@ func NewRateRepository(pool *pgxpool.Pool) *RateRepository {
 
 const onDateTimeFormat = "2006-01-02"
 
+// GetBatch returns stored rates by dates they were requested for along with providers and dates they were published for
 func (r RateRepository) GetBatch(ctx context.Context, dates []time.Time,
-	currencies []string) (rates map[string]map[string]decimal.Decimal, err error) {
+	currencies []string) (rates map[string]map[string]model.Quote, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetBatch")
 	defer span.Finish()
 
 	// language=SQL
-	sql := `SELECT currency_id, multiplier, on_date 
+	sql := `SELECT currency_id, multiplier, on_date, provider, COALESCE(effective_date, on_date)
 			FROM financial_bot.rate 
 			WHERE currency_id = ANY($1) AND on_date = ANY($2)`
 	span.SetTag("sql", sql)
@@ -43,26 +45,26 @@ func (r RateRepository) GetBatch(ctx context.Context, dates []time.Time,
 		return nil, fmt.Errorf("cannot extract rates by currencies=%v", currencies)
 	}
 	defer rows.Close()
-	rates = make(map[string]map[string]decimal.Decimal)
+	rates = make(map[string]map[string]model.Quote)
 	for rows.Next() {
 		var currencyID string
 		var onDate time.Time
-		var multiplier decimal.Decimal
-		err = rows.Scan(&currencyID, &multiplier, &onDate)
+		var quote model.Quote
+		err = rows.Scan(&currencyID, &quote.Multiplier, &onDate, &quote.Provider, &quote.Date)
 		if err != nil {
 			span.SetTag("error", err.Error())
 			return nil, fmt.Errorf("cannot extract rates batch: %s", err.Error())
 		}
 		onDateStr := onDate.Format(onDateTimeFormat)
 		if _, ok := rates[onDateStr]; !ok {
-			rates[onDateStr] = make(map[string]decimal.Decimal)
+			rates[onDateStr] = make(map[string]model.Quote)
 		}
-		rates[onDateStr][currencyID] = multiplier
+		rates[onDateStr][currencyID] = quote
 	}
 	return rates, nil
 }
 
-func (r RateRepository) SaveAll(ctx context.Context, rates map[string]decimal.Decimal, inputDate time.Time) error {
+func (r RateRepository) SaveAll(ctx context.Context, rates map[string]model.Quote, inputDate time.Time) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:SaveAll")
 	defer span.Finish()
 
@@ -72,21 +74,32 @@ func (r RateRepository) SaveAll(ctx context.Context, rates map[string]decimal.De
 	currencies := make([]string, 0, len(rates))
 	multipliers := make([]decimal.Decimal, 0, len(rates))
 	dates := make([]time.Time, 0, len(rates))
+	providers := make([]string, 0, len(rates))
+	effectiveDates := make([]time.Time, 0, len(rates))
 	for k, v := range rates {
 		currencies = append(currencies, k)
-		multipliers = append(multipliers, v)
+		multipliers = append(multipliers, v.Multiplier)
 		dates = append(dates, inputDate)
+		providers = append(providers, v.Provider)
+		if v.Date.IsZero() {
+			v.Date = inputDate
+		}
+		effectiveDates = append(effectiveDates, v.Date)
 	}
 	// language=SQL
-	sql := `INSERT INTO financial_bot.rate (currency_id, multiplier, on_date) 
+	sql := `INSERT INTO financial_bot.rate (currency_id, multiplier, on_date, provider, effective_date) 
 			(SELECT 
 				unnest($1::TEXT[]) AS currency_id,
 				unnest($2::NUMERIC[]) AS multiplier,
-				unnest($3::DATE[]) AS on_date
+				unnest($3::DATE[]) AS on_date,
+				unnest($4::TEXT[]) AS provider,
+				unnest($5::DATE[]) AS effective_date
  			)
-			ON CONFLICT (currency_id, on_date) DO UPDATE SET multiplier = EXCLUDED.multiplier`
+			ON CONFLICT (currency_id, on_date) DO UPDATE SET multiplier = EXCLUDED.multiplier,
+				provider = EXCLUDED.provider, effective_date = EXCLUDED.effective_date`
 	span.SetTag("sql", sql)
-	_, err := r.pool.Exec(ctx, sql, pq.Array(currencies), pq.Array(multipliers), pq.Array(dates))
+	_, err := r.pool.Exec(ctx, sql, pq.Array(currencies), pq.Array(multipliers), pq.Array(dates),
+		pq.Array(providers), pq.Array(effectiveDates))
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot execute batch save rates",
@@ -102,7 +115,7 @@ func (r RateRepository) GetDatesWithoutRate(ctx context.Context, userID int64, s
 	defer span.Finish()
 
 	// language=SQL
-	sql := `SELECT t.created_at::DATE
+	sql := `SELECT DISTINCT t.created_at::DATE
     	FROM financial_bot.transaction t
         	JOIN financial_bot.user u ON t.user_id = u.id
         	LEFT JOIN financial_bot.rate r ON t.created_at::DATE = r.on_date AND r.currency_id = u.currency_id
@@ -134,3 +147,71 @@ func (r RateRepository) GetDatesWithoutRate(ctx context.Context, userID int64, s
 	}
 	return dates, nil
 }
+
+// GetAllDatesWithoutRates returns dates of transactions of all users which lack rate of user currency, latest first
+func (r RateRepository) GetAllDatesWithoutRates(ctx context.Context, startedFrom time.Time, serverCurrency string) ([]time.Time, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetAllDatesWithoutRates")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT DISTINCT t.created_at::DATE AS on_date
+    	FROM financial_bot.transaction t
+        	JOIN financial_bot.user u ON t.user_id = u.id
+        	LEFT JOIN financial_bot.rate r ON t.created_at::DATE = r.on_date AND r.currency_id = u.currency_id
+    	WHERE u.currency_id <> $2 AND t.created_at > $1 AND r.multiplier IS NULL
+    	ORDER BY on_date DESC`
+	span.SetTag("sql", sql)
+	rows, err := r.pool.Query(ctx, sql, startedFrom, serverCurrency)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract dates without rates of all users", zap.Time("startedFrom", startedFrom), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	dates := make([]time.Time, 0)
+	for rows.Next() {
+		var onDate time.Time
+		if err = rows.Scan(&onDate); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan dates without rates of all users", zap.Error(err))
+			return nil, err
+		}
+		dates = append(dates, onDate)
+	}
+	return dates, nil
+}
+
+// GetHistory returns stored rates of currencies since date ordered from the oldest
+func (r RateRepository) GetHistory(ctx context.Context, currencies []string, startedFrom time.Time) (map[string][]model.RatePoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetHistory")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT currency_id, multiplier, on_date
+			FROM financial_bot.rate
+			WHERE currency_id = ANY($1) AND on_date >= $2
+			ORDER BY on_date`
+	span.SetTag("sql", sql)
+	rows, err := r.pool.Query(ctx, sql, pq.Array(currencies), startedFrom)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract rate history",
+			zap.Strings("currencies", currencies),
+			zap.Time("startedFrom", startedFrom),
+			zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	history := make(map[string][]model.RatePoint)
+	for rows.Next() {
+		var currencyID string
+		var point model.RatePoint
+		if err = rows.Scan(&currencyID, &point.Multiplier, &point.Date); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan rate history", zap.Error(err))
+			return nil, err
+		}
+		history[currencyID] = append(history[currencyID], point)
+	}
+	return history, nil
+}
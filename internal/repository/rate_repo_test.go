@@ -7,6 +7,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 func TestRateRepo(t *testing.T) {
@@ -19,28 +20,50 @@ func TestRateRepo(t *testing.T) {
 	t.Run("get batch rates", func(t *testing.T) {
 		yesterday := time.Now().Add(-time.Hour * 24)
 		today := time.Now()
-		err := repository.SaveAll(ctx, map[string]decimal.Decimal{
-			"USD": decimal.NewFromFloat(0.009524),
-			"EUR": decimal.NewFromFloat(0.009502),
-			"CNY": decimal.NewFromFloat(0.068365),
+		friday := time.Date(2022, 10, 14, 0, 0, 0, 0, time.UTC)
+		err := repository.SaveAll(ctx, map[string]model.Quote{
+			"USD": {Multiplier: decimal.NewFromFloat(0.009524), Provider: "cbr", Date: friday},
+			"EUR": {Multiplier: decimal.NewFromFloat(0.009502), Provider: "cbr", Date: friday},
+			"CNY": {Multiplier: decimal.NewFromFloat(0.068365), Provider: "cbr", Date: friday},
 		}, yesterday)
 		assert.NoError(t, err)
 
-		err = repository.SaveAll(ctx, map[string]decimal.Decimal{
-			"USD": decimal.NewFromFloat(0.009624),
-			"EUR": decimal.NewFromFloat(0.009402),
-			"CNY": decimal.NewFromFloat(0.069365),
+		err = repository.SaveAll(ctx, map[string]model.Quote{
+			"USD": {Multiplier: decimal.NewFromFloat(0.009624), Provider: "ecb"},
+			"EUR": {Multiplier: decimal.NewFromFloat(0.009402), Provider: "ecb"},
+			"CNY": {Multiplier: decimal.NewFromFloat(0.069365), Provider: "ecb"},
 		}, today)
 		assert.NoError(t, err)
 
 		res, err := repository.GetBatch(ctx, []time.Time{yesterday}, []string{"EUR", "USD"})
 		assert.NoError(t, err)
 		assert.Equal(t, len(res), 1)
-		assert.Equal(t, map[string]map[string]decimal.Decimal{
-			yesterday.Format("2006-01-02"): {
-				"USD": decimal.NewFromFloat(0.009524),
-				"EUR": decimal.NewFromFloat(0.009502),
-			},
-		}, res)
+		day := res[yesterday.Format("2006-01-02")]
+		assert.Len(t, day, 2)
+		assert.Equal(t, decimal.NewFromFloat(0.009524), day["USD"].Multiplier)
+		assert.Equal(t, decimal.NewFromFloat(0.009502), day["EUR"].Multiplier)
+		assert.Equal(t, "cbr", day["USD"].Provider)
+		assert.True(t, friday.Equal(day["USD"].Date), day["USD"].Date.String())
+
+		res, err = repository.GetBatch(ctx, []time.Time{today}, []string{"USD"})
+		assert.NoError(t, err)
+		assert.Equal(t, "ecb", res[today.Format("2006-01-02")]["USD"].Provider)
+		assert.Equal(t, today.Format("2006-01-02"), res[today.Format("2006-01-02")]["USD"].Date.Format("2006-01-02"))
+	})
+
+	t.Run("get rate history", func(t *testing.T) {
+		history, err := repository.GetHistory(ctx, []string{"USD"}, time.Now().Add(-time.Hour*48))
+		assert.NoError(t, err)
+		assert.Len(t, history["USD"], 2)
+		assert.True(t, history["USD"][0].Date.Before(history["USD"][1].Date))
+		assert.Equal(t, "0.009624", history["USD"][1].Multiplier.String())
+	})
+
+	t.Run("get dates without rates of all users", func(t *testing.T) {
+		dates, err := repository.GetAllDatesWithoutRates(ctx, time.Now().Add(-time.Hour*24*365), "RUB")
+		assert.NoError(t, err)
+		for i := 1; i < len(dates); i++ {
+			assert.True(t, dates[i-1].After(dates[i]), "dates must be ordered from the latest")
+		}
 	})
 }
@@ -9,8 +9,12 @@ import (
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"go.uber.org/zap"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
@@ -26,59 +30,302 @@ func NewTransactionRepository(pool *pgxpool.Pool) *TransactionRepository {
 	}
 }
 
-func (c *TransactionRepository) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) error {
+// uniqueViolationCode is SQLSTATE of insert conflicting with unique index
+const uniqueViolationCode = "23505"
+
+// AddOperation persists expense in server currency and returns its id, TransactionCreated event is written along
+// with it and LimitExceeded one too if spending of current month exceeds limit of category
+func (c *TransactionRepository) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) (int64, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddOperation")
 	defer span.Finish()
 
+	return c.addOperation(ctx, span, userID, categoryID, amount, createdAt, nil)
+}
+
+// AddReceiptOperation persists expense of receipt dated by receipt, constants.ReceiptAlreadyImportedErr is returned
+// when user has already imported the receipt
+func (c *TransactionRepository) AddReceiptOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, receipt model.Receipt) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddReceiptOperation")
+	defer span.Finish()
+
+	return c.addOperation(ctx, span, userID, categoryID, amount, receipt.Date, &receipt)
+}
+
+func (c *TransactionRepository) addOperation(ctx context.Context, span opentracing.Span, userID int64, categoryID string,
+	amount decimal.Decimal, createdAt time.Time, receipt *model.Receipt) (int64, error) {
+	var fiscalDriveNumber, fiscalDocumentNumber, fiscalSign *string
+	if receipt != nil {
+		fiscalDriveNumber, fiscalDocumentNumber, fiscalSign =
+			&receipt.FiscalDriveNumber, &receipt.FiscalDocumentNumber, &receipt.FiscalSign
+	}
+
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0, errors.Wrap(err, "cannot begin transaction")
+	}
+	defer tx.Rollback(ctx) // nolint
+
 	// language=SQL
 	sql := `INSERT INTO financial_bot.transaction 
-			(user_id, category_id, amount, created_at) 
-			VALUES($1, $2, $3, $4) RETURNING id`
+			(user_id, category_id, amount, created_at, fiscal_drive_number, fiscal_document_number, fiscal_sign) 
+			VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 	span.SetTag("sql", sql)
-	row := c.pool.QueryRow(ctx, sql, userID, categoryID, amount, time.Now())
+	row := tx.QueryRow(ctx, sql, userID, categoryID, amount, createdAt, fiscalDriveNumber, fiscalDocumentNumber, fiscalSign)
 	var transactionID int64
-	err := row.Scan(&transactionID)
+	err = row.Scan(&transactionID)
+	var pgErr *pgconn.PgError
+	if receipt != nil && errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return 0, constants.ReceiptAlreadyImportedErr
+	}
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot add new operation",
 			zap.Int64("userID", userID),
 			zap.String("categoryID", categoryID),
 			zap.Error(err))
+		return 0, err
+	}
+	err = addEvent(ctx, tx, userID, model.TransactionCreatedEvent, model.TransactionCreated{
+		TransactionID: transactionID,
+		CategoryID:    categoryID,
+		Amount:        amount,
+		Currency:      constants.ServerCurrency,
+		Date:          createdAt,
+	})
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0, err
+	}
+	if err = addLimitExceededEvent(ctx, tx, userID, categoryID, transactionID); err != nil {
+		span.SetTag("error", err.Error())
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		span.SetTag("error", err.Error())
+		return 0, errors.Wrap(err, "cannot commit operation")
+	}
+	return transactionID, nil
+}
+
+// ReceiptImported reports whether user has already added expense of receipt
+func (c *TransactionRepository) ReceiptImported(ctx context.Context, userID int64, receipt model.Receipt) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:ReceiptImported")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT EXISTS(
+				SELECT 1 FROM financial_bot.transaction
+				WHERE user_id = $1 AND fiscal_drive_number = $2 AND fiscal_document_number = $3 AND fiscal_sign = $4
+			)`
+	span.SetTag("sql", sql)
+	var imported bool
+	err := c.pool.QueryRow(ctx, sql, userID, receipt.FiscalDriveNumber, receipt.FiscalDocumentNumber, receipt.FiscalSign).
+		Scan(&imported)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot check receipt", zap.Int64("userID", userID), zap.Error(err))
+		return false, err
+	}
+	return imported, nil
+}
+
+// addLimitExceededEvent compares spending of current month with active limit of category
+func addLimitExceededEvent(ctx context.Context, tx pgx.Tx, userID int64, categoryID string, transactionID int64) error {
+	// language=SQL
+	sql := `SELECT l.upper_border, COALESCE(SUM(t.amount), 0)
+			FROM financial_bot.limitation l
+				LEFT JOIN financial_bot.transaction t ON t.user_id = l.user_id AND t.category_id = l.category_id
+					AND t.created_at >= date_trunc('month', now())
+			WHERE l.user_id = $1 AND l.category_id = $2 AND l.until_date > now()
+			GROUP BY l.upper_border`
+	var upperBorder, spent decimal.Decimal
+	err := tx.QueryRow(ctx, sql, userID, categoryID).Scan(&upperBorder, &spent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		logger.Error("cannot check limit of new operation",
+			zap.Int64("userID", userID),
+			zap.String("categoryID", categoryID),
+			zap.Error(err))
 		return err
 	}
+	if spent.LessThanOrEqual(upperBorder) {
+		return nil
+	}
+	return addEvent(ctx, tx, userID, model.LimitExceededEvent, model.LimitExceeded{
+		CategoryID:    categoryID,
+		TransactionID: transactionID,
+		Spent:         spent,
+		UpperBorder:   upperBorder,
+		Currency:      constants.ServerCurrency,
+	})
+}
+
+// ListTransactions returns page of user transactions from the newest one along with number of all of them
+func (c *TransactionRepository) ListTransactions(ctx context.Context, userID int64, limit, offset int) ([]model.Transaction, int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:ListTransactions")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT t.id, t.category_id, t.amount, t.created_at, COUNT(*) OVER () AS total,
+				a.kind, a.file_id, a.file_name, a.mime_type, a.size
+			FROM financial_bot.transaction t
+				LEFT JOIN financial_bot.transaction_attachment a ON a.transaction_id = t.id
+			WHERE t.user_id = $1
+			ORDER BY t.created_at DESC, t.id DESC LIMIT $2 OFFSET $3`
+	span.SetTag("sql", sql)
+	rows, err := c.pool.Query(ctx, sql, userID, limit, offset)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot list transactions", zap.Int64("userID", userID), zap.Error(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+	transactions := make([]model.Transaction, 0, limit)
+	var total int
+	for rows.Next() {
+		var t model.Transaction
+		var kind, fileID, fileName, mimeType *string
+		var size *int64
+		err = rows.Scan(&t.ID, &t.CategoryID, &t.Amount, &t.Date, &total, &kind, &fileID, &fileName, &mimeType, &size)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan transaction", zap.Int64("userID", userID), zap.Error(err))
+			return nil, 0, err
+		}
+		if kind != nil {
+			t.Attachment = &model.Attachment{Kind: *kind, FileID: *fileID, FileName: *fileName, MimeType: *mimeType, Size: *size}
+		}
+		transactions = append(transactions, t)
+	}
+	if len(transactions) == 0 && offset > 0 { // page after the last one does not tell the total
+		// language=SQL
+		err = c.pool.QueryRow(ctx, `SELECT COUNT(*) FROM financial_bot.transaction WHERE user_id = $1`, userID).Scan(&total)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot count transactions", zap.Int64("userID", userID), zap.Error(err))
+			return nil, 0, err
+		}
+	}
+	return transactions, total, nil
+}
+
+// SetAttachment attaches file to transaction of user replacing the previous one,
+// constants.TransactionNotFoundErr is returned for transactions of other users
+func (c *TransactionRepository) SetAttachment(ctx context.Context, userID, transactionID int64, attachment model.Attachment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:SetAttachment")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.transaction_attachment (transaction_id, kind, file_id, file_name, mime_type, size)
+			SELECT id, $3, $4, $5, $6, $7 FROM financial_bot.transaction WHERE id = $1 AND user_id = $2
+			ON CONFLICT (transaction_id) DO UPDATE SET kind = excluded.kind, file_id = excluded.file_id,
+				file_name = excluded.file_name, mime_type = excluded.mime_type, size = excluded.size, created_at = now()`
+	span.SetTag("sql", sql)
+	tag, err := c.pool.Exec(ctx, sql, transactionID, userID, attachment.Kind, attachment.FileID, attachment.FileName,
+		attachment.MimeType, attachment.Size)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot set attachment",
+			zap.Int64("userID", userID),
+			zap.Int64("transactionID", transactionID),
+			zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(constants.TransactionNotFoundErr, "#%d", transactionID)
+	}
 	return nil
 }
 
-func (c *TransactionRepository) CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string) (map[string]decimal.Decimal, error) {
+// GetAttachment returns file attached to transaction of user
+func (c *TransactionRepository) GetAttachment(ctx context.Context, userID, transactionID int64) (model.Attachment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetAttachment")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT a.kind, a.file_id, a.file_name, a.mime_type, a.size
+			FROM financial_bot.transaction_attachment a
+				JOIN financial_bot.transaction t ON t.id = a.transaction_id
+			WHERE a.transaction_id = $1 AND t.user_id = $2`
+	span.SetTag("sql", sql)
+	var attachment model.Attachment
+	err := c.pool.QueryRow(ctx, sql, transactionID, userID).
+		Scan(&attachment.Kind, &attachment.FileID, &attachment.FileName, &attachment.MimeType, &attachment.Size)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Attachment{}, errors.Wrapf(constants.AttachmentNotFoundErr, "#%d", transactionID)
+	}
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get attachment",
+			zap.Int64("userID", userID),
+			zap.Int64("transactionID", transactionID),
+			zap.Error(err))
+		return model.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// CalcAmountByPeriod converts transactions by the rate of their date or by the nearest previous rate
+// not older than maxStaleness, fails with constants.MissingRateErr when there is no such rate,
+// report of transactions which were converted is returned along with this error
+func (c *TransactionRepository) CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string,
+	maxStaleness time.Duration) (model.ReportData, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:CalcAmountByPeriod")
 	defer span.Finish()
 
 	// language=SQL
 	sql := `SELECT 
-    		t.category_id, SUM(t.amount * COALESCE(r.multiplier, 1)) AS amount
+    		t.category_id,
+    		SUM(t.amount * CASE WHEN $3 = $5 THEN 1 ELSE r.multiplier END) AS amount,
+    		COUNT(*) FILTER (WHERE $3 <> $5 AND r.on_date < t.created_at::date) AS stale,
+    		COUNT(*) FILTER (WHERE $3 <> $5 AND r.multiplier IS NULL) AS missing
     	FROM financial_bot.transaction t
-    		LEFT JOIN financial_bot.rate r on t.created_at::date = r.on_date AND r.currency_id = $3
+    		LEFT JOIN LATERAL (
+    		    SELECT rate.multiplier, rate.on_date FROM financial_bot.rate
+    		    WHERE rate.currency_id = $3 AND rate.on_date <= t.created_at::date
+    		      AND rate.on_date >= t.created_at::date - $4::int
+    		    ORDER BY rate.on_date DESC LIMIT 1
+    		) r ON TRUE
 			WHERE t.user_id = $1 AND t.created_at > $2
     		GROUP BY t.category_id`
 	span.SetTag("sql", sql)
-	rows, err := c.pool.Query(ctx, sql, userID, moment, currencyID)
+	staleDays := int(maxStaleness / (24 * time.Hour))
+	rows, err := c.pool.Query(ctx, sql, userID, moment, currencyID, staleDays, constants.ServerCurrency)
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot extract transactions", zap.Int64("userID", userID), zap.Error(err))
-		return nil, err
+		return model.ReportData{}, err
 	}
 	defer rows.Close()
-	expenses := make(map[string]decimal.Decimal)
+	report := model.ReportData{Expenses: make(map[string]decimal.Decimal)}
+	var missing int
 	for rows.Next() {
 		var categoryID string
-		var amount decimal.Decimal
-		err = rows.Scan(&categoryID, &amount)
+		var amount decimal.NullDecimal
+		var staleCount, missingCount int
+		err = rows.Scan(&categoryID, &amount, &staleCount, &missingCount)
 		if err != nil {
 			span.SetTag("error", err.Error())
 			logger.Error("cannot scan transactions", zap.Int64("userID", userID), zap.Error(err))
-			return nil, err
+			return model.ReportData{}, err
+		}
+		if amount.Valid { // none of transactions of category is converted
+			report.Expenses[categoryID] = amount.Decimal
 		}
-		expenses[categoryID] = amount
+		report.StaleRates += staleCount
+		missing += missingCount
+	}
+	if missing > 0 {
+		err = errors.Wrapf(constants.MissingRateErr, "%d transactions in %s", missing, currencyID)
+		span.SetTag("error", err.Error())
+		logger.Error("cannot convert transactions without rate",
+			zap.Int64("userID", userID),
+			zap.String("currency", currencyID),
+			zap.Int("missing", missing))
+		return report, err
 	}
-	return expenses, nil
+	return report, nil
 }
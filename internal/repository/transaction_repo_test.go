@@ -7,6 +7,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 func TestTransactionRepo(t *testing.T) {
@@ -18,31 +20,106 @@ func TestTransactionRepo(t *testing.T) {
 	userID := int64(12345678)
 
 	t.Run("calculation amount by categories", func(t *testing.T) {
-		err := repository.AddOperation(ctx, userID, "RESTAURANTS", decimal.NewFromInt(1000),
-			time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC))
+		_, err := repository.AddOperation(ctx, userID, "RESTAURANTS", decimal.NewFromInt(1000), time.Now())
 		assert.NoError(t, err)
 
-		err = repository.AddOperation(ctx, userID, "RESTAURANTS", decimal.NewFromInt(1580),
-			time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC))
+		_, err = repository.AddOperation(ctx, userID, "RESTAURANTS", decimal.NewFromInt(1580), time.Now())
 		assert.NoError(t, err)
 
-		err = repository.AddOperation(ctx, userID, "CLOTHES", decimal.NewFromInt(1053),
-			time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC))
+		_, err = repository.AddOperation(ctx, userID, "CLOTHES", decimal.NewFromInt(1053), time.Now())
 		assert.NoError(t, err)
 
-		err = repository.AddOperation(ctx, userID, "MEDICINE", decimal.NewFromInt(15807),
-			time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC))
+		_, err = repository.AddOperation(ctx, userID, "MEDICINE", decimal.NewFromInt(15807), time.Now())
 		assert.NoError(t, err)
 
-		err = repository.AddOperation(ctx, userID, "CLOTHES", decimal.NewFromInt(2107),
-			time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC))
+		_, err = repository.AddOperation(ctx, userID, "CLOTHES", decimal.NewFromInt(2107), time.Now())
 		assert.NoError(t, err)
 
-		expenses, err := repository.CalcAmountByPeriod(ctx, userID, time.Now().Add(-time.Hour*24), "RUB")
+		report, err := repository.CalcAmountByPeriod(ctx, userID, time.Now().Add(-time.Hour*24), "RUB", 7*24*time.Hour)
 		assert.NoError(t, err)
+		expenses := report.Expenses
 		assert.Equal(t, 3, len(expenses))
 		assert.Equal(t, "2580", expenses["RESTAURANTS"].String())
 		assert.Equal(t, "3160", expenses["CLOTHES"].String())
 		assert.Equal(t, "15807", expenses["MEDICINE"].String())
 	})
+	t.Run("calculation by nearest previous rate", func(t *testing.T) {
+		foreignUserID := int64(87654321)
+		_, err := repository.AddOperation(ctx, foreignUserID, "RESTAURANTS", decimal.NewFromInt(100), time.Now())
+		assert.NoError(t, err)
+
+		report, err := repository.CalcAmountByPeriod(ctx, foreignUserID, time.Now().Add(-time.Hour*24), "USD", 7*24*time.Hour)
+		assert.ErrorIs(t, err, constants.MissingRateErr)
+		assert.Empty(t, report.Expenses, "transactions without rate are left out")
+
+		err = NewRateRepository(connPool).SaveAll(ctx, map[string]model.Quote{"USD": {Multiplier: decimal.NewFromFloat(0.5)}},
+			time.Now().Add(-time.Hour*48))
+		assert.NoError(t, err)
+
+		report, err = repository.CalcAmountByPeriod(ctx, foreignUserID, time.Now().Add(-time.Hour*24), "USD", 7*24*time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, "50", report.Expenses["RESTAURANTS"].String())
+		assert.Equal(t, 1, report.StaleRates)
+
+		_, err = repository.CalcAmountByPeriod(ctx, foreignUserID, time.Now().Add(-time.Hour*24), "USD", 24*time.Hour)
+		assert.ErrorIs(t, err, constants.MissingRateErr)
+	})
+	t.Run("listing transactions by pages", func(t *testing.T) {
+		page, total, err := repository.ListTransactions(ctx, userID, 2, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Len(t, page, 2)
+		assert.Equal(t, "CLOTHES", page[0].CategoryID)
+		assert.Equal(t, "2107", page[0].Amount.String())
+
+		page, total, err = repository.ListTransactions(ctx, userID, 2, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Empty(t, page)
+	})
+	t.Run("importing receipt once", func(t *testing.T) {
+		receipt := model.Receipt{
+			FiscalDriveNumber:    "9960440300000000",
+			FiscalDocumentNumber: "12345",
+			FiscalSign:           "1234567890",
+			Total:                decimal.RequireFromString("1234.56"),
+			Date:                 time.Now().Add(-48 * time.Hour),
+		}
+		imported, err := repository.ReceiptImported(ctx, userID, receipt)
+		assert.NoError(t, err)
+		assert.False(t, imported)
+
+		_, err = repository.AddReceiptOperation(ctx, userID, "SUPERMARKETS", receipt.Total, receipt)
+		assert.NoError(t, err)
+		imported, err = repository.ReceiptImported(ctx, userID, receipt)
+		assert.NoError(t, err)
+		assert.True(t, imported)
+
+		_, err = repository.AddReceiptOperation(ctx, userID, "SUPERMARKETS", receipt.Total, receipt)
+		assert.ErrorIs(t, err, constants.ReceiptAlreadyImportedErr)
+	})
+	t.Run("attaching files to transactions", func(t *testing.T) {
+		transactionID, err := repository.AddOperation(ctx, userID, "CLOTHES", decimal.NewFromInt(500), time.Now())
+		assert.NoError(t, err)
+		_, err = repository.GetAttachment(ctx, userID, transactionID)
+		assert.ErrorIs(t, err, constants.AttachmentNotFoundErr)
+
+		photo := model.Attachment{Kind: model.PhotoAttachment, FileID: "photo", MimeType: "image/jpeg", Size: 1024}
+		assert.NoError(t, repository.SetAttachment(ctx, userID, transactionID, photo))
+		voice := model.Attachment{Kind: model.VoiceAttachment, FileID: "voice", MimeType: "audio/ogg", Size: 2048}
+		assert.NoError(t, repository.SetAttachment(ctx, userID, transactionID, voice))
+		assert.ErrorIs(t, repository.SetAttachment(ctx, userID+1, transactionID, photo), constants.TransactionNotFoundErr)
+
+		attachment, err := repository.GetAttachment(ctx, userID, transactionID)
+		assert.NoError(t, err)
+		assert.Equal(t, voice, attachment)
+		_, err = repository.GetAttachment(ctx, userID+1, transactionID)
+		assert.ErrorIs(t, err, constants.AttachmentNotFoundErr)
+
+		page, _, err := repository.ListTransactions(ctx, userID, 2, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, transactionID, page[0].ID)
+		assert.Equal(t, &voice, page[0].Attachment)
+		assert.Nil(t, page[1].Attachment)
+	})
 }
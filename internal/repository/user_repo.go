@@ -8,8 +8,11 @@ import (
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
 	"go.uber.org/zap"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 type UserRepository struct {
@@ -38,21 +41,82 @@ func (c *UserRepository) GetUserCurrency(ctx context.Context, userID int64) (cur
 	return
 }
 
+// SetUserCurrency registers user if needed and writes CurrencyChanged event
 func (c *UserRepository) SetUserCurrency(ctx context.Context, userID int64, newCurrency string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "db:SetUserCurrency")
 	defer span.Finish()
 
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot begin transaction")
+	}
+	defer tx.Rollback(ctx) // nolint
+
 	// language=SQL
-	sql := `INSERT INTO financial_bot.user (id, currency_id) 
+	sql := `INSERT INTO financial_bot.user AS u (id, currency_id) 
 			VALUES ($1, $2) ON CONFLICT (id) 
-			DO UPDATE SET currency_id = EXCLUDED.currency_id`
+			DO UPDATE SET currency_id = EXCLUDED.currency_id WHERE u.currency_id IS DISTINCT FROM EXCLUDED.currency_id`
 	span.SetTag("sql", sql)
-	_, err := c.pool.Exec(ctx, sql, userID, newCurrency)
+	tag, err := tx.Exec(ctx, sql, userID, newCurrency)
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot set currency", zap.Int64("userID", userID), zap.Error(err))
 		return err
 	}
+	if tag.RowsAffected() == 0 { // currency is the same, so nothing is announced
+		return nil
+	}
+	err = addEvent(ctx, tx, userID, model.CurrencyChangedEvent, model.CurrencyChanged{Currency: newCurrency})
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot commit currency")
+	}
+	return nil
+}
+
+func (c *UserRepository) GetUserLanguage(ctx context.Context, userID int64) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetUserLanguage")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT language_code FROM financial_bot.user WHERE id = $1`
+	span.SetTag("sql", sql)
+	row := c.pool.QueryRow(ctx, sql, userID)
+	var lang *string
+	if err := row.Scan(&lang); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract language", zap.Int64("userID", userID), zap.Error(err))
+		return "", err
+	}
+	if lang == nil {
+		return "", nil
+	}
+	return *lang, nil
+}
+
+func (c *UserRepository) SetUserLanguage(ctx context.Context, userID int64, lang string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:SetUserLanguage")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.user (id, language_code) 
+			VALUES ($1, $2) ON CONFLICT (id) 
+			DO UPDATE SET language_code = EXCLUDED.language_code`
+	span.SetTag("sql", sql)
+	_, err := c.pool.Exec(ctx, sql, userID, lang)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot set language", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
 	return nil
 }
 
@@ -62,7 +126,7 @@ func (c *UserRepository) GetCurrenciesFilteredByUser(ctx context.Context, userID
 
 	// language=SQL
 	sql := `SELECT id FROM financial_bot.currency
-			WHERE id NOT IN (SELECT u.currency_id 
+			WHERE enabled AND id NOT IN (SELECT u.currency_id 
 			FROM financial_bot.user u where u.id = $1)`
 	span.SetTag("sql", sql)
 	rows, err := c.pool.Query(ctx, sql, userID)
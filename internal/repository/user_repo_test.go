@@ -36,4 +36,19 @@ func TestUserRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, []string{"RUB", "USD", "CNY"}, currencies)
 	})
+
+	t.Run("language is empty until user chooses it", func(t *testing.T) {
+		lang, err := repository.GetUserLanguage(ctx, 123548568)
+		assert.NoError(t, err)
+		assert.Equal(t, "", lang)
+	})
+
+	t.Run("changing user language", func(t *testing.T) {
+		err := repository.SetUserLanguage(ctx, 123548568, "en")
+		assert.NoError(t, err)
+
+		lang, err := repository.GetUserLanguage(ctx, 123548568)
+		assert.NoError(t, err)
+		assert.Equal(t, "en", lang)
+	})
 }
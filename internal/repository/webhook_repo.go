@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// WebhookRepository keeps webhooks of users along with queue and log of deliveries to them
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{
+		pool: pool,
+	}
+}
+
+func (r WebhookRepository) AddWebhook(ctx context.Context, webhook model.Webhook) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddWebhook")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.user_webhook (user_id, url, secret, event_types)
+			VALUES ($1, $2, $3, $4) RETURNING id`
+	span.SetTag("sql", sql)
+	var id int64
+	err := r.pool.QueryRow(ctx, sql, webhook.UserID, webhook.URL, webhook.Secret, pq.Array(webhook.EventTypes)).Scan(&id)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add webhook", zap.Int64("userID", webhook.UserID), zap.Error(err))
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r WebhookRepository) GetUserWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetUserWebhooks")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT id, user_id, url, secret, event_types, created_at
+			FROM financial_bot.user_webhook WHERE user_id = $1 ORDER BY id`
+	span.SetTag("sql", sql)
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract webhooks", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	webhooks := make([]model.Webhook, 0)
+	for rows.Next() {
+		var w model.Webhook
+		if err = rows.Scan(&w.ID, &w.UserID, &w.URL, &w.Secret, &w.EventTypes, &w.CreatedAt); err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan webhook", zap.Error(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes webhook of user with its deliveries, constants.WebhookNotFoundErr is returned
+// for webhook of another user
+func (r WebhookRepository) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:DeleteWebhook")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `DELETE FROM financial_bot.user_webhook WHERE id = $1 AND user_id = $2`
+	span.SetTag("sql", sql)
+	tag, err := r.pool.Exec(ctx, sql, webhookID, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot delete webhook",
+			zap.Int64("userID", userID),
+			zap.Int64("webhookID", webhookID),
+			zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return constants.WebhookNotFoundErr
+	}
+	return nil
+}
+
+func (r WebhookRepository) AddDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:AddDelivery")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `INSERT INTO financial_bot.webhook_delivery
+				(webhook_id, idempotency_key, event_type, attempt, status_code, error, delivered)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	span.SetTag("sql", sql)
+	_, err := r.pool.Exec(ctx, sql, delivery.WebhookID, delivery.IdempotencyKey, delivery.EventType, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.Delivered)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot add webhook delivery",
+			zap.Int64("webhookID", delivery.WebhookID),
+			zap.String("idempotencyKey", delivery.IdempotencyKey),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetUserDeliveries returns the latest deliveries to webhooks of user, zero webhookID means all webhooks
+func (r WebhookRepository) GetUserDeliveries(ctx context.Context, userID, webhookID int64, limit int) ([]model.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:GetUserDeliveries")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `SELECT d.id, d.webhook_id, d.idempotency_key::text, d.event_type, d.attempt, d.status_code, d.error,
+				d.delivered, d.created_at
+			FROM financial_bot.webhook_delivery d
+			JOIN financial_bot.user_webhook w ON w.id = d.webhook_id
+			WHERE w.user_id = $1 AND ($2::bigint = 0 OR w.id = $2::bigint)
+			ORDER BY d.id DESC LIMIT $3`
+	span.SetTag("sql", sql)
+	return r.queryDeliveries(ctx, span, sql, userID, webhookID, limit)
+}
+
+func (r WebhookRepository) queryDeliveries(ctx context.Context, span opentracing.Span, sql string,
+	args ...interface{}) ([]model.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot extract webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	deliveries := make([]model.WebhookDelivery, 0)
+	for rows.Next() {
+		var d model.WebhookDelivery
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.IdempotencyKey, &d.EventType, &d.Attempt, &d.StatusCode, &d.Error,
+			&d.Delivered, &d.CreatedAt)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan webhook delivery", zap.Error(err))
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// EnqueueTasks adds deliveries of event to webhooks, tasks already queued for the same event and webhook are skipped,
+// so that relaying event again does not post it twice
+func (r WebhookRepository) EnqueueTasks(ctx context.Context, tasks []model.WebhookTask) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:EnqueueTasks")
+	defer span.Finish()
+
+	webhookIDs := make([]int64, 0, len(tasks))
+	keys := make([]string, 0, len(tasks))
+	eventTypes := make([]string, 0, len(tasks))
+	bodies := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		webhookIDs = append(webhookIDs, task.Webhook.ID)
+		keys = append(keys, task.IdempotencyKey)
+		eventTypes = append(eventTypes, task.EventType)
+		bodies = append(bodies, string(task.Body))
+	}
+	// language=SQL
+	sql := `INSERT INTO financial_bot.webhook_task (webhook_id, idempotency_key, event_type, body)
+			(SELECT
+				unnest($1::BIGINT[]) AS webhook_id,
+				unnest($2::UUID[]) AS idempotency_key,
+				unnest($3::TEXT[]) AS event_type,
+				unnest($4::TEXT[]) AS body
+			)
+			ON CONFLICT (idempotency_key, webhook_id) DO NOTHING`
+	span.SetTag("sql", sql)
+	_, err := r.pool.Exec(ctx, sql, pq.Array(webhookIDs), pq.Array(keys), pq.Array(eventTypes), pq.Array(bodies))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot enqueue webhook tasks", zap.Int("tasks", len(tasks)), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ClaimTasks returns due unfinished tasks with their webhooks and postpones their next attempt by lease,
+// so that concurrent dispatchers skip them and tasks of crashed dispatcher are retried when lease expires
+func (r WebhookRepository) ClaimTasks(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookTask, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:ClaimTasks")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.webhook_task t SET next_attempt_at = now() + make_interval(secs => $2)
+			FROM financial_bot.user_webhook w
+			WHERE w.id = t.webhook_id AND t.id IN (
+				SELECT id FROM financial_bot.webhook_task
+				WHERE finished_at IS NULL AND next_attempt_at <= now()
+				ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING t.id, t.idempotency_key::text, t.event_type, t.body, t.attempts,
+				w.id, w.user_id, w.url, w.secret, w.event_types, w.created_at`
+	span.SetTag("sql", sql)
+	rows, err := r.pool.Query(ctx, sql, limit, lease.Seconds())
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot claim webhook tasks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	tasks := make([]model.WebhookTask, 0, limit)
+	for rows.Next() {
+		var t model.WebhookTask
+		var body string
+		err = rows.Scan(&t.ID, &t.IdempotencyKey, &t.EventType, &body, &t.Attempts,
+			&t.Webhook.ID, &t.Webhook.UserID, &t.Webhook.URL, &t.Webhook.Secret, &t.Webhook.EventTypes, &t.Webhook.CreatedAt)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot scan webhook task", zap.Error(err))
+			return nil, err
+		}
+		t.Body = []byte(body)
+		tasks = append(tasks, t)
+	}
+	// UPDATE does not keep order of subquery
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, rows.Err()
+}
+
+// FinishTask stops delivery of task after it is accepted by webhook or given up
+func (r WebhookRepository) FinishTask(ctx context.Context, taskID int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:FinishTask")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.webhook_task SET attempts = attempts + 1, finished_at = now() WHERE id = $1`
+	span.SetTag("sql", sql)
+	if _, err := r.pool.Exec(ctx, sql, taskID); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot finish webhook task", zap.Int64("taskID", taskID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RetryTask schedules next attempt of task after delay
+func (r WebhookRepository) RetryTask(ctx context.Context, taskID int64, delay time.Duration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:RetryTask")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `UPDATE financial_bot.webhook_task
+			SET attempts = attempts + 1, next_attempt_at = now() + make_interval(secs => $2)
+			WHERE id = $1`
+	span.SetTag("sql", sql)
+	if _, err := r.pool.Exec(ctx, sql, taskID, delay.Seconds()); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot retry webhook task", zap.Int64("taskID", taskID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// PurgeFinishedTasks deletes tasks finished before the moment and returns their number
+func (r WebhookRepository) PurgeFinishedTasks(ctx context.Context, before time.Time) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "db:PurgeFinishedTasks")
+	defer span.Finish()
+
+	// language=SQL
+	sql := `DELETE FROM financial_bot.webhook_task WHERE finished_at < $1`
+	span.SetTag("sql", sql)
+	tag, err := r.pool.Exec(ctx, sql, before)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot purge finished webhook tasks", zap.Error(err))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestWebhookRepo(t *testing.T) {
+	ctx := context.Background()
+	dbContainer, connPool := SetupTestDatabase()
+	defer dbContainer.Terminate(ctx) // nolint
+
+	repository := NewWebhookRepository(connPool)
+	userID := int64(7654321)
+	assert.NoError(t, NewUserRepository(connPool).SetUserCurrency(ctx, userID, "RUB"))
+	const eventKey = "0b6f3c1e-2f7a-4c55-9d55-0e0e4c9b9a10"
+
+	var webhookID int64
+	t.Run("adding webhook", func(t *testing.T) {
+		var err error
+		webhookID, err = repository.AddWebhook(ctx, model.Webhook{
+			UserID:     userID,
+			URL:        "https://example.com/hook",
+			Secret:     "whsec_secret",
+			EventTypes: []string{model.LimitExceededEvent},
+		})
+		require.NoError(t, err)
+
+		webhooks, err := repository.GetUserWebhooks(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		assert.Equal(t, "https://example.com/hook", webhooks[0].URL)
+		assert.Equal(t, []string{model.LimitExceededEvent}, webhooks[0].EventTypes)
+	})
+
+	t.Run("logging deliveries", func(t *testing.T) {
+		require.NoError(t, repository.AddDelivery(ctx, model.WebhookDelivery{
+			WebhookID: webhookID, IdempotencyKey: eventKey, EventType: model.LimitExceededEvent, Attempt: 1,
+			StatusCode: 503, Error: "webhook responded with status 503",
+		}))
+		require.NoError(t, repository.AddDelivery(ctx, model.WebhookDelivery{
+			WebhookID: webhookID, IdempotencyKey: eventKey, EventType: model.LimitExceededEvent, Attempt: 2,
+			StatusCode: 200, Delivered: true,
+		}))
+
+		deliveries, err := repository.GetUserDeliveries(ctx, userID, webhookID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, eventKey, deliveries[1].IdempotencyKey)
+		assert.False(t, deliveries[1].Delivered)
+
+		deliveries, err = repository.GetUserDeliveries(ctx, userID, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.True(t, deliveries[0].Delivered)
+
+		deliveries, err = repository.GetUserDeliveries(ctx, userID+1, webhookID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("queueing tasks", func(t *testing.T) {
+		task := model.WebhookTask{
+			Webhook:        model.Webhook{ID: webhookID},
+			IdempotencyKey: eventKey,
+			EventType:      model.LimitExceededEvent,
+			Body:           []byte(`{"type":"LimitExceeded"}`),
+		}
+		require.NoError(t, repository.EnqueueTasks(ctx, []model.WebhookTask{task}))
+		require.NoError(t, repository.EnqueueTasks(ctx, []model.WebhookTask{task})) // relayed again
+
+		tasks, err := repository.ClaimTasks(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "https://example.com/hook", tasks[0].Webhook.URL)
+		assert.Equal(t, "whsec_secret", tasks[0].Webhook.Secret)
+		assert.Equal(t, task.Body, tasks[0].Body)
+
+		tasks, err = repository.ClaimTasks(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, tasks, "claimed task is leased")
+	})
+
+	t.Run("retrying and finishing tasks", func(t *testing.T) {
+		_, err := connPool.Exec(ctx, `UPDATE financial_bot.webhook_task SET next_attempt_at = now()`)
+		require.NoError(t, err)
+		tasks, err := repository.ClaimTasks(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+
+		require.NoError(t, repository.RetryTask(ctx, tasks[0].ID, 0))
+		tasks, err = repository.ClaimTasks(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, 1, tasks[0].Attempts)
+
+		require.NoError(t, repository.FinishTask(ctx, tasks[0].ID))
+		purged, err := repository.PurgeFinishedTasks(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+
+	t.Run("deleting webhook of another user", func(t *testing.T) {
+		err := repository.DeleteWebhook(ctx, userID+1, webhookID)
+		assert.ErrorIs(t, err, constants.WebhookNotFoundErr)
+	})
+
+	t.Run("deleting webhook", func(t *testing.T) {
+		assert.NoError(t, repository.DeleteWebhook(ctx, userID, webhookID))
+		webhooks, err := repository.GetUserWebhooks(ctx, userID)
+		assert.NoError(t, err)
+		assert.Empty(t, webhooks)
+		deliveries, err := repository.GetUserDeliveries(ctx, userID, webhookID, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"go.uber.org/zap"
+)
+
+// apiTokenPrefix makes tokens recognizable e.g. by secret scanners
+const apiTokenPrefix = "fb_"
+
+const apiTokenBytes = 32
+
+type APITokenStore interface {
+	AddToken(ctx context.Context, userID int64, tokenHash string) error
+	GetUserByToken(ctx context.Context, tokenHash string) (int64, error)
+	RevokeTokens(ctx context.Context, userID int64) (int64, error)
+}
+
+// apiTokenService issues per-user tokens of REST API, only their hashes are persisted
+type apiTokenService struct {
+	tokenRepo APITokenStore
+}
+
+func NewAPITokenService(tokenRepo APITokenStore) *apiTokenService {
+	return &apiTokenService{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Issue creates new token of user, the token cannot be recovered later
+func (s *apiTokenService) Issue(ctx context.Context, userID int64) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "IssueAPIToken")
+	defer span.Finish()
+
+	raw := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot generate api token", zap.Int64("userID", userID), zap.Error(err))
+		return "", errors.Wrap(err, "cannot generate api token")
+	}
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	if err := s.tokenRepo.AddToken(ctx, userID, hashAPIToken(token)); err != nil {
+		span.SetTag("error", err.Error())
+		return "", errors.Wrap(err, "cannot store api token")
+	}
+	return token, nil
+}
+
+// Authenticate returns owner of token, constants.TokenNotFoundErr is returned for unknown token
+func (s *apiTokenService) Authenticate(ctx context.Context, token string) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "AuthenticateAPIToken")
+	defer span.Finish()
+
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return 0, constants.TokenNotFoundErr
+	}
+	userID, err := s.tokenRepo.GetUserByToken(ctx, hashAPIToken(token))
+	if err != nil {
+		if !errors.Is(err, constants.TokenNotFoundErr) {
+			span.SetTag("error", err.Error())
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// RevokeAll deletes every token of user and returns their number
+func (s *apiTokenService) RevokeAll(ctx context.Context, userID int64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RevokeAPITokens")
+	defer span.Finish()
+
+	revoked, err := s.tokenRepo.RevokeTokens(ctx, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0, errors.Wrap(err, "cannot revoke api tokens")
+	}
+	return revoked, nil
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
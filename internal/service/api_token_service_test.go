@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+)
+
+func TestAPITokenService_IssuedTokenAuthenticatesByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	repo := serviceMocks.NewMockAPITokenStore(ctrl)
+	s := NewAPITokenService(repo)
+
+	var storedHash string
+	repo.EXPECT().AddToken(gomock.Any(), int64(42), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int64, hash string) error {
+			storedHash = hash
+			return nil
+		})
+	token, err := s.Issue(ctx, 42)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, apiTokenPrefix))
+	assert.NotEqual(t, token, storedHash)
+	assert.Len(t, storedHash, 64)
+
+	repo.EXPECT().GetUserByToken(gomock.Any(), storedHash).Return(int64(42), nil)
+	userID, err := s.Authenticate(ctx, token)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, userID)
+
+	_, err = s.Authenticate(ctx, "not-a-token")
+	assert.ErrorIs(t, err, constants.TokenNotFoundErr)
+}
+
+func TestAPITokenService_RevokeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := serviceMocks.NewMockAPITokenStore(ctrl)
+	s := NewAPITokenService(repo)
+
+	repo.EXPECT().RevokeTokens(gomock.Any(), int64(42)).Return(int64(2), nil)
+	revoked, err := s.RevokeAll(context.Background(), 42)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, revoked)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+	"go.uber.org/zap"
+)
+
+// AssetRateRouter loads rates of crypto currencies and precious metals from asset provider
+// and rates of fiat currencies from fiat providers, so fiat providers never see codes they do not quote
+type AssetRateRouter struct {
+	fiat    CurrencyExtractor
+	assets  RateProvider
+	isAsset func(code string) bool
+}
+
+func NewAssetRateRouter(fiat CurrencyExtractor, assets RateProvider) *AssetRateRouter {
+	return &AssetRateRouter{
+		fiat:    fiat,
+		assets:  assets,
+		isAsset: iso4217.IsAsset,
+	}
+}
+
+func (r *AssetRateRouter) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	return r.route(ctx, "GetLiveCurrency", currencies,
+		func(e CurrencyExtractor, codes []string) (map[string]model.Quote, error) {
+			return e.GetLiveCurrency(ctx, codes)
+		})
+}
+
+func (r *AssetRateRouter) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	return r.route(ctx, "GetHistoricalCurrency", currencies,
+		func(e CurrencyExtractor, codes []string) (map[string]model.Quote, error) {
+			return e.GetHistoricalCurrency(ctx, day, codes)
+		})
+}
+
+// route fails only when fiat rates fail, assets without rates are treated as missing rates
+// unless nothing but assets was requested
+func (r *AssetRateRouter) route(ctx context.Context, method string, currencies []string,
+	call func(e CurrencyExtractor, codes []string) (map[string]model.Quote, error)) (map[string]model.Quote, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "AssetRateRouter:"+method)
+	defer span.Finish()
+
+	var fiatCodes, assetCodes []string
+	for _, currency := range currencies {
+		if r.isAsset(currency) {
+			assetCodes = append(assetCodes, currency)
+			continue
+		}
+		fiatCodes = append(fiatCodes, currency)
+	}
+
+	rates := make(map[string]model.Quote, len(currencies))
+	if len(fiatCodes) > 0 {
+		fiatRates, err := call(r.fiat, fiatCodes)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			return nil, err
+		}
+		for currency, rate := range fiatRates {
+			rates[currency] = rate
+		}
+	}
+	if len(assetCodes) > 0 {
+		assetRates, err := call(r.assets, assetCodes)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			if len(fiatCodes) == 0 {
+				return nil, err
+			}
+			logger.Warn("cannot load asset rates", zap.Strings("assets", assetCodes), zap.String("method", method), zap.Error(err))
+			return rates, nil
+		}
+		metrics.RatesSourceCounter.WithLabelValues(r.assets.Name()).Inc()
+		for currency, rate := range assetRates {
+			rates[currency] = rate
+		}
+	}
+	return rates, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+)
+
+func TestAssetRateRouter_SplitsFiatAndAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	fiat := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	assets := serviceMocks.NewMockRateProvider(ctrl)
+	assets.EXPECT().Name().Return("coingecko").AnyTimes()
+	r := NewAssetRateRouter(fiat, assets)
+	day := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	fiat.EXPECT().GetHistoricalCurrency(ctx, day, []string{"USD", "EUR"}).
+		Return(quotesOf(map[string]decimal.Decimal{"USD": decimal.RequireFromString("0.016"), "EUR": decimal.RequireFromString("0.0165")}), nil)
+	assets.EXPECT().GetHistoricalCurrency(ctx, day, []string{"BTC", "XAU"}).
+		Return(quotesOf(map[string]decimal.Decimal{"BTC": decimal.RequireFromString("0.000000625")}), nil)
+
+	rates, err := r.GetHistoricalCurrency(ctx, day, []string{"USD", "BTC", "EUR", "XAU"})
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 3)
+	assert.Equal(t, "0.000000625", rates["BTC"].Multiplier.String())
+}
+
+func TestAssetRateRouter_AssetFailureKeepsFiatRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	fiat := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	assets := serviceMocks.NewMockRateProvider(ctrl)
+	r := NewAssetRateRouter(fiat, assets)
+	providerErr := errors.New("coingecko is down")
+
+	fiat.EXPECT().GetLiveCurrency(ctx, []string{"USD"}).Return(quotesOf(map[string]decimal.Decimal{"USD": decimal.RequireFromString("0.016")}), nil)
+	assets.EXPECT().GetLiveCurrency(ctx, []string{"BTC"}).Return(nil, providerErr).Times(2)
+
+	rates, err := r.GetLiveCurrency(ctx, []string{"USD", "BTC"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"USD"}, lo.Keys(rates))
+
+	_, err = r.GetLiveCurrency(ctx, []string{"BTC"})
+	assert.ErrorIs(t, err, providerErr)
+}
@@ -2,80 +2,101 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils"
 
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/cache"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 	"go.uber.org/zap"
 
 	"github.com/shopspring/decimal"
 )
 
 type TransactionStore interface {
-	CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string) (map[string]decimal.Decimal, error)
+	CalcAmountByPeriod(ctx context.Context, userID int64, moment time.Time, currencyID string,
+		maxStaleness time.Duration) (model.ReportData, error)
 }
 
-type CurrencyExchanger interface {
-	GetMultiplier(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error)
+// RateBackfiller loads missing historical rates in background
+type RateBackfiller interface {
+	Trigger()
+}
+
+// ReportGenerations versions cached reports of user, bumping generation invalidates all of them
+type ReportGenerations interface {
+	Get(userID int64) string
+	Bump(userID int64) error
 }
 
 type CalculatorConfig interface {
 	CalcCacheDefaultExpiration() time.Duration
+	RateMaxStaleness() time.Duration
 }
 
 type calculatorService struct {
 	transactionRepo TransactionStore
 	rateRepo        RateStore
-	rateService     CurrencyExchanger
+	backfill        RateBackfiller
 	reportCache     Cache
+	generations     ReportGenerations
 	config          CalculatorConfig
 }
 
-func NewCalculatorService(config CalculatorConfig, transactionRepo TransactionStore, rateRepo RateStore, rateService CurrencyExchanger, reportCache Cache) *calculatorService {
+func NewCalculatorService(config CalculatorConfig, transactionRepo TransactionStore, rateRepo RateStore, backfill RateBackfiller,
+	reportCache Cache, generations ReportGenerations) *calculatorService {
 	return &calculatorService{
 		config:          config,
 		transactionRepo: transactionRepo,
 		rateRepo:        rateRepo,
-		rateService:     rateService,
+		backfill:        backfill,
 		reportCache:     reportCache,
+		generations:     generations,
+	}
+}
+
+// InvalidateReports drops cached reports of user in all currencies and periods
+func (c *calculatorService) InvalidateReports(userID int64) error {
+	if err := c.generations.Bump(userID); err != nil {
+		logger.Warn("cannot invalidate cached reports", zap.Int64("userID", userID), zap.Error(err))
+		return err
 	}
+	return nil
 }
 
-func (c *calculatorService) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+func (c *calculatorService) CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
 	return c.calcBy(ctx, "CalcByCurrentWeek", userID, 7, currency)
 }
 
-func (c *calculatorService) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+func (c *calculatorService) CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
 	return c.calcBy(ctx, "CalcByCurrentMonth", userID, 30, currency)
 }
 
-func (c *calculatorService) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (map[string]decimal.Decimal, error) {
+func (c *calculatorService) CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error) {
 	return c.calcBy(ctx, "CalcByCurrentYear", userID, 365, currency)
 }
 
-func (c *calculatorService) CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (map[string]decimal.Decimal, error) {
+func (c *calculatorService) CalcSinceStartOfMonth(ctx context.Context, userID int64, currency string, days int64) (model.ReportData, error) {
 	return c.calcBy(ctx, "CalcSinceStartOfMonth", userID, days, currency)
 }
 
 func (c *calculatorService) calcBy(ctx context.Context, operationName string,
-	userID, days int64, currency string) (map[string]decimal.Decimal, error) {
+	userID, days int64, currency string) (model.ReportData, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, operationName)
 	defer span.Finish()
 
-	cacheKey := utils.GetCalcCacheKey(userID, currency, days)
-	if res, ok := c.reportCache.Get(cacheKey); ok {
-		var temp map[string]decimal.Decimal
-		if err := json.Unmarshal([]byte(res), &temp); err == nil {
-			return temp, nil
-		}
+	cacheKey := utils.GetCalcCacheKey(userID, c.generations.Get(userID), currency, days)
+	if expenses, ok := cache.GetJSON[map[string]decimal.Decimal](c.reportCache, cacheKey); ok {
+		return model.ReportData{Expenses: expenses}, nil
 	}
 
 	momentInThePast := time.Now().Add(-time.Hour * 24 * time.Duration(days))
+	var pendingRates int
 	if currency != constants.ServerCurrency {
 		dates, err := c.rateRepo.GetDatesWithoutRate(ctx, userID, momentInThePast)
 		if err != nil {
@@ -85,14 +106,21 @@ func (c *calculatorService) calcBy(ctx context.Context, operationName string,
 				zap.Int64("days", days),
 				zap.String("currency", currency),
 				zap.Error(err))
-			return nil, err
+			return model.ReportData{}, err
 		}
-		for i := range dates { // try load new rates and persist if needed
-			c.rateService.GetMultiplier(ctx, currency, dates[i]) // nolint
+		if pendingRates = len(dates); pendingRates > 0 { // report does not wait for external api
+			span.SetTag("pending rates", pendingRates)
+			c.backfill.Trigger()
 		}
 	}
 
-	expenses, err := c.transactionRepo.CalcAmountByPeriod(ctx, userID, momentInThePast, currency)
+	report, err := c.transactionRepo.CalcAmountByPeriod(ctx, userID, momentInThePast, currency, c.config.RateMaxStaleness())
+	if pendingRates > 0 && errors.Is(err, constants.MissingRateErr) {
+		// rates are being loaded, so transactions without them are left out of partial report until backfill ends
+		span.SetTag("partial", true)
+		report.PendingRates = pendingRates
+		return report, nil
+	}
 	if err != nil {
 		span.SetTag("error", err.Error())
 		logger.Error("cannot get amount from database for period",
@@ -100,14 +128,15 @@ func (c *calculatorService) calcBy(ctx context.Context, operationName string,
 			zap.String("currency", currency),
 			zap.Time("afterDate", momentInThePast),
 			zap.Error(err))
-		return nil, err
+		return model.ReportData{}, err
 	}
 
-	if b, err := json.Marshal(expenses); err == nil {
-		err2 := c.reportCache.Add(cacheKey, string(b), c.config.CalcCacheDefaultExpiration())
-		if err2 != nil {
-			logger.Warn("cannot save calculated report to cache while requesting report", zap.Error(err2))
-		}
+	report.PendingRates = pendingRates
+	if pendingRates > 0 || report.StaleRates > 0 { // incomplete report must not be cached
+		return report, nil
+	}
+	if err = cache.SetJSON(c.reportCache, cacheKey, report.Expenses, c.config.CalcCacheDefaultExpiration()); err != nil {
+		logger.Warn("cannot save calculated report to cache while requesting report", zap.Error(err))
 	}
-	return expenses, nil
+	return report, nil
 }
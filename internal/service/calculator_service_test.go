@@ -8,10 +8,12 @@ import (
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
 	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 const (
@@ -20,6 +22,15 @@ const (
 	ClothesCategoryID   = "CLOTHES"
 )
 
+// generation is returned by generations mock for every user
+const generation = "lc2q1b"
+
+func newGenerationsMock(ctrl *gomock.Controller, userID int64) *serviceMocks.MockReportGenerations {
+	m := serviceMocks.NewMockReportGenerations(ctrl)
+	m.EXPECT().Get(userID).Return(generation).AnyTimes()
+	return m
+}
+
 type MockConfig struct {
 }
 
@@ -27,6 +38,10 @@ func (t *MockConfig) CalcCacheDefaultExpiration() time.Duration {
 	return defaultExpires
 }
 
+func (t *MockConfig) RateMaxStaleness() time.Duration {
+	return 7 * 24 * time.Hour
+}
+
 func TestFinanceCalculatorService_CalcByCurrentWeek(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -37,20 +52,14 @@ func TestFinanceCalculatorService_CalcByCurrentWeek(t *testing.T) {
 		EducationCategoryID: decimal.NewFromInt(2000),
 	}
 	currencyID := "RUB"
-	defaultExpiration := time.Hour * 24 * 30
-	cleanupInterval := time.Hour
-	simpleCache := NewSimpleCache(ctx, defaultExpiration, cleanupInterval)
-	currencyExchangeClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
 	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
 	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
-	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID).Return(weekExpensesExpected, nil)
-	currencyExchangeClientMock.EXPECT().GetLiveCurrency(ctx).Times(1)
-	rateRepoMock.EXPECT().GetBatch(ctx, gomock.Any(), gomock.Any()).Times(1)
-	rateRepoMock.EXPECT().SaveAll(ctx, gomock.Any(), gomock.Any())
-	exchangeRatesService := NewCurrencyExchangeService(ctx, currencyExchangeClientMock, simpleCache, rateRepoMock)
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(model.ReportData{Expenses: weekExpensesExpected}, nil)
+	backfillMock := serviceMocks.NewMockRateBackfiller(ctrl)
 	reportCacheMock := serviceMocks.NewMockCache(ctrl)
-	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, currencyID, 7))
-	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, currencyID, 7), "{\"EDUCATION\":\"2000\"}", defaultExpires)
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 7))
+	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, generation, currencyID, 7), "{\"EDUCATION\":\"2000\"}", defaultExpires)
 
 	type args struct {
 		userID   int64
@@ -74,10 +83,10 @@ func TestFinanceCalculatorService_CalcByCurrentWeek(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, exchangeRatesService, reportCacheMock)
+			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, backfillMock, reportCacheMock, newGenerationsMock(ctrl, userID))
 			got, err := f.CalcByCurrentWeek(ctx, tt.args.userID, tt.args.currency)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, got, "CalcByCurrentWeek: got = %v, want %v", got, tt.want)
+			assert.Equal(t, tt.want, got.Expenses, "CalcByCurrentWeek: got = %v, want %v", got.Expenses, tt.want)
 		})
 	}
 }
@@ -93,20 +102,14 @@ func TestFinanceCalculatorService_CalcByCurrentMonth(t *testing.T) {
 		EducationCategoryID: decimal.NewFromInt(7000),
 		ClothesCategoryID:   decimal.NewFromInt(2132134),
 	}
-	defaultExpiration := time.Hour * 24 * 30
-	cleanupInterval := time.Hour
-	simpleCache := NewSimpleCache(ctx, defaultExpiration, cleanupInterval)
-	currencyExchangeClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
 	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
 	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
-	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID).Return(monthExpensesExpected, nil)
-	currencyExchangeClientMock.EXPECT().GetLiveCurrency(ctx).Times(1)
-	rateRepoMock.EXPECT().GetBatch(ctx, gomock.Any(), gomock.Any()).Times(1)
-	rateRepoMock.EXPECT().SaveAll(ctx, gomock.Any(), gomock.Any())
-	exchangeRatesService := NewCurrencyExchangeService(ctx, currencyExchangeClientMock, simpleCache, rateRepoMock)
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(model.ReportData{Expenses: monthExpensesExpected}, nil)
+	backfillMock := serviceMocks.NewMockRateBackfiller(ctrl)
 	reportCacheMock := serviceMocks.NewMockCache(ctrl)
-	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, currencyID, 30))
-	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, currencyID, 30),
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 30))
+	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, generation, currencyID, 30),
 		"{\"CLOTHES\":\"2132134\",\"EDUCATION\":\"7000\"}", defaultExpires)
 
 	type args struct {
@@ -131,10 +134,10 @@ func TestFinanceCalculatorService_CalcByCurrentMonth(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, exchangeRatesService, reportCacheMock)
+			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, backfillMock, reportCacheMock, newGenerationsMock(ctrl, userID))
 			got, err := f.CalcByCurrentMonth(ctx, tt.args.userID, constants.ServerCurrency)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, got, "CalcByCurrentMonth: got = %v, want %v", got, tt.want)
+			assert.Equal(t, tt.want, got.Expenses, "CalcByCurrentMonth: got = %v, want %v", got.Expenses, tt.want)
 		})
 	}
 }
@@ -151,20 +154,14 @@ func TestFinanceCalculatorService_CalcByCurrentYear(t *testing.T) {
 		ClothesCategoryID:   decimal.NewFromInt(2132134),
 		BeautyCategoryID:    decimal.NewFromInt(13000),
 	}
-	defaultExpiration := time.Hour * 24 * 30
-	cleanupInterval := time.Hour
-	simpleCache := NewSimpleCache(ctx, defaultExpiration, cleanupInterval)
-	currencyExchangeClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
 	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
 	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
-	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID).Return(yearExpensesExpected, nil)
-	currencyExchangeClientMock.EXPECT().GetLiveCurrency(ctx).Times(1)
-	rateRepoMock.EXPECT().GetBatch(ctx, gomock.Any(), gomock.Any()).Times(1)
-	rateRepoMock.EXPECT().SaveAll(ctx, gomock.Any(), gomock.Any())
-	exchangeRatesService := NewCurrencyExchangeService(ctx, currencyExchangeClientMock, simpleCache, rateRepoMock)
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(model.ReportData{Expenses: yearExpensesExpected}, nil)
+	backfillMock := serviceMocks.NewMockRateBackfiller(ctrl)
 	reportCacheMock := serviceMocks.NewMockCache(ctrl)
-	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, currencyID, 365))
-	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, currencyID, 365),
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 365))
+	reportCacheMock.EXPECT().Add(utils.GetCalcCacheKey(userID, generation, currencyID, 365),
 		"{\"BEAUTY\":\"13000\",\"CLOTHES\":\"2132134\",\"EDUCATION\":\"7000\"}", defaultExpires)
 
 	type args struct {
@@ -189,10 +186,119 @@ func TestFinanceCalculatorService_CalcByCurrentYear(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, exchangeRatesService, reportCacheMock)
+			f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, backfillMock, reportCacheMock, newGenerationsMock(ctrl, userID))
 			got, err := f.CalcByCurrentYear(ctx, tt.args.userID, constants.ServerCurrency)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, got, "CalcByCurrentYear: got = %v, want %v", got, tt.want)
+			assert.Equal(t, tt.want, got.Expenses, "CalcByCurrentYear: got = %v, want %v", got.Expenses, tt.want)
 		})
 	}
 }
+
+func TestFinanceCalculatorService_CalcWithPendingRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	cfg := &MockConfig{}
+	userID := int64(12345)
+	currencyID := "USD"
+	expensesExpected := map[string]decimal.Decimal{
+		EducationCategoryID: decimal.NewFromInt(20),
+	}
+	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	backfillMock := serviceMocks.NewMockRateBackfiller(ctrl)
+	reportCacheMock := serviceMocks.NewMockCache(ctrl)
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 7))
+	rateRepoMock.EXPECT().GetDatesWithoutRate(gomock.Any(), userID, gomock.Any()).
+		Return([]time.Time{time.Now().Add(-48 * time.Hour), time.Now().Add(-24 * time.Hour)}, nil)
+	backfillMock.EXPECT().Trigger()
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(model.ReportData{Expenses: expensesExpected}, nil)
+
+	f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, backfillMock, reportCacheMock, newGenerationsMock(ctrl, userID))
+	got, err := f.CalcByCurrentWeek(ctx, userID, currencyID)
+	assert.NoError(t, err)
+	assert.Equal(t, expensesExpected, got.Expenses)
+	assert.Equal(t, 2, got.PendingRates)
+}
+
+func TestFinanceCalculatorService_CalcWithMissingRateWhileRatesArePending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	userID := int64(12345)
+	currencyID := "USD"
+	partial := model.ReportData{Expenses: map[string]decimal.Decimal{EducationCategoryID: decimal.NewFromInt(20)}}
+	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	backfillMock := serviceMocks.NewMockRateBackfiller(ctrl)
+	reportCacheMock := serviceMocks.NewMockCache(ctrl) // partial report is not cached
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 30))
+	rateRepoMock.EXPECT().GetDatesWithoutRate(gomock.Any(), userID, gomock.Any()).
+		Return([]time.Time{time.Now().Add(-20 * 24 * time.Hour)}, nil)
+	backfillMock.EXPECT().Trigger()
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(partial, errors.Wrap(constants.MissingRateErr, "1 transactions in USD"))
+
+	f := NewCalculatorService(&MockConfig{}, transactionRepoMock, rateRepoMock, backfillMock, reportCacheMock, newGenerationsMock(ctrl, userID))
+	got, err := f.CalcByCurrentMonth(ctx, userID, currencyID)
+	assert.NoError(t, err)
+	assert.Equal(t, partial.Expenses, got.Expenses)
+	assert.Equal(t, 1, got.PendingRates)
+}
+
+func TestFinanceCalculatorService_CalcWithStaleRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	cfg := &MockConfig{}
+	userID := int64(12345)
+	currencyID := "USD"
+	reportExpected := model.ReportData{
+		Expenses:   map[string]decimal.Decimal{ClothesCategoryID: decimal.NewFromInt(35)},
+		StaleRates: 3,
+	}
+	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	reportCacheMock := serviceMocks.NewMockCache(ctrl)
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 30))
+	rateRepoMock.EXPECT().GetDatesWithoutRate(gomock.Any(), userID, gomock.Any()).Return(nil, nil)
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, cfg.RateMaxStaleness()).
+		Return(reportExpected, nil)
+
+	f := NewCalculatorService(cfg, transactionRepoMock, rateRepoMock, serviceMocks.NewMockRateBackfiller(ctrl), reportCacheMock, newGenerationsMock(ctrl, userID))
+	got, err := f.CalcByCurrentMonth(ctx, userID, currencyID)
+	assert.NoError(t, err)
+	assert.Equal(t, reportExpected, got)
+}
+
+func TestFinanceCalculatorService_CalcWithMissingRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	userID := int64(12345)
+	currencyID := "USD"
+	transactionRepoMock := serviceMocks.NewMockTransactionStore(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	reportCacheMock := serviceMocks.NewMockCache(ctrl)
+	reportCacheMock.EXPECT().Get(utils.GetCalcCacheKey(userID, generation, currencyID, 365))
+	rateRepoMock.EXPECT().GetDatesWithoutRate(gomock.Any(), userID, gomock.Any()).Return(nil, nil)
+	transactionRepoMock.EXPECT().CalcAmountByPeriod(gomock.Any(), userID, gomock.Any(), currencyID, gomock.Any()).
+		Return(model.ReportData{}, constants.MissingRateErr)
+
+	f := NewCalculatorService(&MockConfig{}, transactionRepoMock, rateRepoMock, serviceMocks.NewMockRateBackfiller(ctrl), reportCacheMock, newGenerationsMock(ctrl, userID))
+	_, err := f.CalcByCurrentYear(ctx, userID, currencyID)
+	assert.ErrorIs(t, err, constants.MissingRateErr)
+}
+
+func TestFinanceCalculatorService_InvalidateReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userID := int64(12345)
+	generationsMock := serviceMocks.NewMockReportGenerations(ctrl)
+	generationsMock.EXPECT().Bump(userID).Return(errors.New("cache is unavailable"))
+
+	f := NewCalculatorService(&MockConfig{}, serviceMocks.NewMockTransactionStore(ctrl), serviceMocks.NewMockRateStore(ctrl),
+		serviceMocks.NewMockRateBackfiller(ctrl), serviceMocks.NewMockCache(ctrl), generationsMock)
+
+	assert.Error(t, f.InvalidateReports(userID))
+}
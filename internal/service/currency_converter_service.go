@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// RateGetter provides rate of currency on date with its source
+type RateGetter interface {
+	GetRate(ctx context.Context, currency string, date time.Time) (model.Rate, error)
+}
+
+// ConversionCurrencyStore provides enabled currencies with their precision
+type ConversionCurrencyStore interface {
+	GetEnabledCurrencies(ctx context.Context) ([]string, error)
+	GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error)
+}
+
+const (
+	ratePrecision = 4
+	// rateSignificantDigits are kept for rates too small for ratePrecision, e.g. RUB to BTC
+	rateSignificantDigits = 3
+)
+
+// currencyConverterService converts amounts between enabled currencies by cross rate through server currency
+type currencyConverterService struct {
+	rateService  RateGetter
+	currencyRepo ConversionCurrencyStore
+	now          func() time.Time
+}
+
+func NewCurrencyConverterService(rateService RateGetter, currencyRepo ConversionCurrencyStore) *currencyConverterService {
+	return &currencyConverterService{
+		rateService:  rateService,
+		currencyRepo: currencyRepo,
+		now:          time.Now,
+	}
+}
+
+func (s *currencyConverterService) Convert(ctx context.Context, amount decimal.Decimal, from, to string,
+	date time.Time) (model.Conversion, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Convert")
+	defer span.Finish()
+
+	if date.After(s.now()) {
+		return model.Conversion{}, constants.FutureDateErr
+	}
+	enabled, err := s.currencyRepo.GetEnabledCurrencies(ctx)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get enabled currencies for conversion", zap.Error(err))
+		return model.Conversion{}, err
+	}
+	if !lo.Contains(enabled, from) || !lo.Contains(enabled, to) {
+		return model.Conversion{}, constants.UndefinedCurrencyErr
+	}
+
+	fromCurrency, err := s.currencyRepo.GetCurrency(ctx, from)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.Conversion{}, err
+	}
+	toCurrency, err := s.currencyRepo.GetCurrency(ctx, to)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.Conversion{}, err
+	}
+
+	conversion := model.Conversion{Amount: amount, From: fromCurrency, To: toCurrency, Date: date}
+	multipliers := make(map[string]decimal.Decimal, 2)
+	for _, currency := range lo.Uniq([]string{from, to}) {
+		rate, err := s.rateService.GetRate(ctx, currency, date)
+		if err != nil {
+			span.SetTag("error", err.Error())
+			logger.Error("cannot get rate for conversion",
+				zap.String("currency", currency),
+				zap.Time("date", date),
+				zap.Error(err))
+			return model.Conversion{}, err
+		}
+		if rate.Multiplier.IsZero() {
+			return model.Conversion{}, constants.UndefinedCurrencyErr
+		}
+		multipliers[currency] = rate.Multiplier
+		if currency != constants.ServerCurrency {
+			conversion.Legs = append(conversion.Legs, rate)
+		}
+	}
+
+	// rates are units of currency per one server currency unit
+	crossRate := multipliers[to].Div(multipliers[from])
+	conversion.Rate = roundRate(crossRate)
+	conversion.Result = amount.Mul(crossRate).Round(toCurrency.Decimals())
+	return conversion, nil
+}
+
+// roundRate keeps four decimals, tiny rates keep three significant digits instead
+func roundRate(rate decimal.Decimal) decimal.Decimal {
+	if rate.IsZero() || rate.Abs().GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return rate.Round(ratePrecision)
+	}
+	leadingZeros := -rate.Exponent() - int32(len(rate.Abs().Coefficient().String()))
+	places := leadingZeros + rateSignificantDigits
+	if places < ratePrecision {
+		places = ratePrecision
+	}
+	return rate.Round(places)
+}
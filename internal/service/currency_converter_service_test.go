@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestCurrencyConverterService_Convert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	date := time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)
+
+	rateServiceMock := serviceMocks.NewMockRateGetter(ctrl)
+	eur := model.Rate{Currency: "EUR", Multiplier: decimal.RequireFromString("0.0125"), Date: date, Provider: "cbr", EffectiveDate: date}
+	usd := model.Rate{Currency: "USD", Multiplier: decimal.RequireFromString("0.0136"), Date: date, Provider: "cbr", EffectiveDate: date}
+	rateServiceMock.EXPECT().GetRate(gomock.Any(), "EUR", date).Return(eur, nil).AnyTimes()
+	rateServiceMock.EXPECT().GetRate(gomock.Any(), "USD", date).Return(usd, nil).AnyTimes()
+	rateServiceMock.EXPECT().GetRate(gomock.Any(), "RUB", date).
+		Return(model.Rate{Currency: "RUB", Multiplier: decimal.NewFromInt(1), Date: date}, nil).AnyTimes()
+	currencyRepoMock := serviceMocks.NewMockConversionCurrencyStore(ctrl)
+	currencyRepoMock.EXPECT().GetEnabledCurrencies(gomock.Any()).Return([]string{"BTC", "EUR", "RUB", "USD"}, nil).AnyTimes()
+	for _, currency := range []model.CurrencyData{
+		{ID: "BTC", Symbol: "₿", Precision: 8},
+		{ID: "EUR", Symbol: "€", Precision: 2},
+		{ID: "RUB", Symbol: "₽", Precision: 2},
+		{ID: "USD", Symbol: "$", Precision: 2},
+	} {
+		currencyRepoMock.EXPECT().GetCurrency(gomock.Any(), currency.ID).Return(currency, nil).AnyTimes()
+	}
+	btc := model.Rate{Currency: "BTC", Multiplier: decimal.RequireFromString("0.000000625"), Date: date, Provider: "coingecko", EffectiveDate: date}
+	rateServiceMock.EXPECT().GetRate(gomock.Any(), "BTC", date).Return(btc, nil).AnyTimes()
+	s := NewCurrencyConverterService(rateServiceMock, currencyRepoMock)
+
+	t.Run("foreign to server currency", func(t *testing.T) {
+		got, err := s.Convert(ctx, decimal.NewFromInt(120), "EUR", constants.ServerCurrency, date)
+		assert.NoError(t, err)
+		assert.Equal(t, "9600", got.Result.String())
+		assert.Equal(t, "80", got.Rate.String())
+		assert.Equal(t, []model.Rate{eur}, got.Legs)
+	})
+
+	t.Run("cross rate through server currency", func(t *testing.T) {
+		got, err := s.Convert(ctx, decimal.NewFromInt(100), "EUR", "USD", date)
+		assert.NoError(t, err)
+		assert.Equal(t, "108.8", got.Result.String())
+		assert.Equal(t, "1.088", got.Rate.String())
+		assert.Equal(t, []model.Rate{eur, usd}, got.Legs)
+	})
+
+	t.Run("crypto keeps its precision", func(t *testing.T) {
+		got, err := s.Convert(ctx, decimal.NewFromInt(10000), constants.ServerCurrency, "BTC", date)
+		assert.NoError(t, err)
+		assert.Equal(t, "0.00625", got.Result.String())
+		assert.Equal(t, "0.000000625", got.Rate.String())
+
+		got, err = s.Convert(ctx, decimal.RequireFromString("0.0015"), "BTC", "USD", date)
+		assert.NoError(t, err)
+		assert.Equal(t, "32.64", got.Result.String())
+	})
+
+	t.Run("currency is not enabled", func(t *testing.T) {
+		_, err := s.Convert(ctx, decimal.NewFromInt(100), "GEL", "USD", date)
+		assert.ErrorIs(t, err, constants.UndefinedCurrencyErr)
+	})
+
+	t.Run("date in the future", func(t *testing.T) {
+		_, err := s.Convert(ctx, decimal.NewFromInt(100), "EUR", "USD", time.Now().Add(48*time.Hour))
+		assert.ErrorIs(t, err, constants.FutureDateErr)
+	})
+}
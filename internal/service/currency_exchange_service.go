@@ -2,9 +2,9 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/cache"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
 
 	"github.com/opentracing/opentracing-go"
@@ -13,25 +13,39 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
 type RateStore interface {
 	GetDatesWithoutRate(ctx context.Context, id int64, startedFrom time.Time) ([]time.Time, error)
-	GetBatch(ctx context.Context, dates []time.Time, currencies []string) (rates map[string]map[string]decimal.Decimal, err error)
-	SaveAll(ctx context.Context, rates map[string]decimal.Decimal, date time.Time) error
+	GetBatch(ctx context.Context, dates []time.Time, currencies []string) (rates map[string]map[string]model.Quote, err error)
+	SaveAll(ctx context.Context, rates map[string]model.Quote, date time.Time) error
 }
 
 type currencyExchangeService struct {
-	client    CurrencyExtractor
-	rateRepo  RateStore
-	rateCache Cache
+	client       CurrencyExtractor
+	rateRepo     RateStore
+	rateCache    Cache
+	currencyRepo CurrencyRegistry
 }
 
+// CurrencyExtractor loads rates of requested currencies, rates are units of currency per one constants.ServerCurrency
 type CurrencyExtractor interface {
-	GetLiveCurrency(ctx context.Context) (map[string]decimal.Decimal, error)
-	GetHistoricalCurrency(ctx context.Context, day time.Time) (map[string]decimal.Decimal, error)
+	GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error)
+	GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error)
+}
+
+// RateListener is told about every new live rates stored by the service
+type RateListener interface {
+	OnLiveRates(ctx context.Context, rates map[string]decimal.Decimal)
+}
+
+// CurrencyRegistry provides currencies enabled by admin or config
+type CurrencyRegistry interface {
+	GetEnabledCurrencies(ctx context.Context) ([]string, error)
 }
 
 const cacheTimeFormat = "2006-01-02"
@@ -39,26 +53,34 @@ const dbTimeFormat = "2006-01-02"
 const defaultExpires = time.Hour * 24 * 30
 
 func (s currencyExchangeService) GetMultiplier(ctx context.Context, currency string, inputDate time.Time) (decimal.Decimal, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "GetMultiplier")
+	rate, err := s.GetRate(ctx, currency, inputDate)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return rate.Multiplier, nil
+}
+
+// GetRate returns multiplier of currency on date along with provider which published it and date it was published for
+func (s currencyExchangeService) GetRate(ctx context.Context, currency string, inputDate time.Time) (model.Rate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "GetRate")
 	defer span.Finish()
 
+	rate := model.Rate{Currency: currency, Date: inputDate, EffectiveDate: inputDate}
 	if currency == constants.ServerCurrency {
 		span.SetTag("result", "returned value=1 for default currency")
-		return decimal.NewFromInt(1), nil
+		rate.Multiplier = decimal.NewFromInt(1)
+		return rate, nil
 	}
 
 	// check cache
 	key := getCurrencyCacheKey(inputDate)
-	if v, ok := s.rateCache.Get(key); ok {
-		metrics.RatesSourceCounter.WithLabelValues(metrics.CacheLabel).Inc()
-		metrics.CacheHitCounter.WithLabelValues(metrics.HitLabel).Inc()
-		var temp map[string]decimal.Decimal
-		err := json.Unmarshal([]byte(v), &temp)
-		if err == nil && len(temp) > 0 {
+	if rates, ok := cache.GetJSON[map[string]model.Quote](s.rateCache, key); ok {
+		if quote, found := rates[currency]; found { // newly enabled currency may be missing
+			metrics.RatesSourceCounter.WithLabelValues(metrics.CacheLabel).Inc()
+			metrics.CacheHitCounter.WithLabelValues(metrics.HitLabel).Inc()
 			span.SetTag("result", "returned value from cache")
-			return temp[currency], nil
+			return withQuote(rate, quote), nil
 		}
-		logger.Error("cannot unmarshal extracted value from cache", zap.Error(err))
 	}
 	metrics.CacheHitCounter.WithLabelValues(metrics.MissLabel).Inc()
 
@@ -70,32 +92,41 @@ func (s currencyExchangeService) GetMultiplier(ctx context.Context, currency str
 			zap.Time("inputDate", inputDate),
 			zap.String("currency", currency),
 			zap.Error(err))
-		return decimal.Decimal{}, errors.New(constants.InternalServerErrorMsg)
+		return model.Rate{}, errors.Wrap(err, "cannot get batch rates from db")
 	}
 	saveToCache(s.rateCache, res)
-	if v, ok := res[inputDate.Format(dbTimeFormat)]; ok {
+	if quote, ok := res[inputDate.Format(dbTimeFormat)][currency]; ok {
 		metrics.RatesSourceCounter.WithLabelValues(metrics.DBLabel).Inc()
 		span.SetTag("result", "extracted value from database")
-		return v[currency], nil
+		return withQuote(rate, quote), nil
 	}
 
-	// load new rates
-	var rates map[string]decimal.Decimal
+	// load new rates of all enabled currencies
+	currencies, err := getRateCurrencies(ctx, s.currencyRepo)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.Rate{}, err
+	}
+	if !lo.Contains(currencies, currency) {
+		span.SetTag("error", constants.UndefinedCurrencyErr.Error())
+		return model.Rate{}, constants.UndefinedCurrencyErr
+	}
+	var rates map[string]model.Quote
 	var callType string
 	callTypeStatus := "ok"
 	if inputDate.Format(cacheTimeFormat) == time.Now().Format(cacheTimeFormat) {
 		callType = metrics.LiveCallTypeLabel
-		rates, err = s.client.GetLiveCurrency(ctx)
+		rates, err = s.client.GetLiveCurrency(ctx, currencies)
 	} else {
 		callType = metrics.HistoricalCallTypeLabel
-		rates, err = s.client.GetHistoricalCurrency(ctx, inputDate)
+		rates, err = s.client.GetHistoricalCurrency(ctx, inputDate, currencies)
 	}
 	if err != nil {
 		callTypeStatus = "error"
 		metrics.RatesAPICallCounter.WithLabelValues(callType, callTypeStatus).Inc()
 		span.SetTag("error", err.Error())
 		logger.Error("cannot get rates from external currency api", zap.Error(err))
-		return decimal.Decimal{}, err
+		return model.Rate{}, err
 	}
 	metrics.RatesAPICallCounter.WithLabelValues(callType, callTypeStatus).Inc()
 
@@ -105,18 +136,34 @@ func (s currencyExchangeService) GetMultiplier(ctx context.Context, currency str
 		span.SetTag("error", err.Error())
 		logger.Error("cannot save loaded rates to database", zap.Error(err))
 	}
-	metrics.RatesSourceCounter.WithLabelValues(metrics.APILabel).Inc()
+	saveToCache(s.rateCache, map[string]map[string]model.Quote{inputDate.Format(cacheTimeFormat): rates})
 
-	multiplier, ok := rates[currency]
+	quote, ok := rates[currency]
 	if !ok {
-		err = errors.New(constants.UndefinedCurrencyMsg)
+		err = constants.UndefinedCurrencyErr
 		span.SetTag("error", err.Error())
 		logger.Error("cannot load correct rate for currency", zap.String("currency", currency), zap.Error(err))
-		return decimal.Decimal{}, errors.New(constants.UndefinedCurrencyMsg)
+		return model.Rate{}, err
 	}
 
 	span.SetTag("result", "got value by http request to external rates api")
-	return multiplier, nil
+	return withQuote(rate, quote), nil
+}
+
+// withQuote fills rate from loaded quote, rates stored before providers were recorded are dated by requested date
+func withQuote(rate model.Rate, quote model.Quote) model.Rate {
+	rate.Multiplier, rate.Provider = quote.Multiplier, quote.Provider
+	if !quote.Date.IsZero() {
+		rate.EffectiveDate = quote.Date
+	}
+	return rate
+}
+
+// multipliers drops providers and dates of quotes
+func multipliers(quotes map[string]model.Quote) map[string]decimal.Decimal {
+	return lo.MapValues(quotes, func(quote model.Quote, _ string) decimal.Decimal {
+		return quote.Multiplier
+	})
 }
 
 func getCurrencyCacheKey(date time.Time) string {
@@ -127,11 +174,24 @@ func getCurrencyCacheKeyFromStr(date string) string {
 	return "CURRENCY_" + date
 }
 
-func loadPersistedRates(ctx context.Context, rateCache Cache, rateRepo RateStore) {
+// getRateCurrencies returns enabled currencies except server currency which rates are expressed in
+func getRateCurrencies(ctx context.Context, currencyRepo CurrencyRegistry) ([]string, error) {
+	enabled, err := currencyRepo.GetEnabledCurrencies(ctx)
+	if err != nil {
+		logger.Error("cannot get enabled currencies", zap.Error(err))
+		return nil, errors.Wrap(err, "cannot get enabled currencies")
+	}
+	return lo.Without(enabled, constants.ServerCurrency), nil
+}
+
+func loadPersistedRates(ctx context.Context, rateCache Cache, rateRepo RateStore, currencyRepo CurrencyRegistry) {
+	currencies, err := getRateCurrencies(ctx, currencyRepo)
+	if err != nil {
+		return
+	}
 	dates := []time.Time{
 		time.Now(),
 	}
-	currencies := []string{"USD", "EUR", "CNY"}
 	rates, err := rateRepo.GetBatch(ctx, dates, currencies)
 	if err != nil {
 		logger.Error("cannot load persisted rates from database", zap.Error(err))
@@ -141,30 +201,35 @@ func loadPersistedRates(ctx context.Context, rateCache Cache, rateRepo RateStore
 	saveToCache(rateCache, rates)
 }
 
-func saveToCache(rateCache Cache, rates map[string]map[string]decimal.Decimal) {
+func saveToCache(rateCache Cache, rates map[string]map[string]model.Quote) {
 	if len(rates) == 0 {
 		return
 	}
 	for k, ratesForDay := range rates {
 		key := getCurrencyCacheKeyFromStr(k)
-		err := rateCache.Add(key, toJson(ratesForDay), defaultExpires)
+		err := cache.SetJSON(rateCache, key, ratesForDay, defaultExpires)
 		if err != nil {
 			logger.Error("cannot interact with cache", zap.Error(err))
 		}
 	}
 }
 
-func loadNewRates(ctx context.Context, ratesCache Cache, rateRepo RateStore, client CurrencyExtractor) {
+func loadNewRates(ctx context.Context, ratesCache Cache, rateRepo RateStore, currencyRepo CurrencyRegistry,
+	client CurrencyExtractor, listener RateListener) {
+	currencies, err := getRateCurrencies(ctx, currencyRepo)
+	if err != nil || len(currencies) == 0 {
+		return
+	}
 	key := getCurrencyCacheKey(time.Now())
-	if _, ok := ratesCache.Get(key); !ok { // first initialization of currencies
-		rates, err := client.GetLiveCurrency(ctx)
+	if !cachedAll(ratesCache, key, currencies) { // first initialization or newly enabled currencies
+		rates, err := client.GetLiveCurrency(ctx, currencies)
 		if err != nil {
 			logger.Error("cannot get rates from external currency api", zap.Error(err))
 			return
 		}
 
-		// save to mem
-		err = ratesCache.Add(key, toJson(rates), defaultExpires)
+		// save to cache
+		err = cache.SetJSON(ratesCache, key, rates, defaultExpires)
 		if err != nil {
 			logger.Error("cannot interact with cache", zap.Error(err))
 		}
@@ -173,17 +238,15 @@ func loadNewRates(ctx context.Context, ratesCache Cache, rateRepo RateStore, cli
 		err = rateRepo.SaveAll(ctx, rates, time.Now())
 		if err != nil {
 			logger.Error("cannot save loaded rates to database", zap.Error(err))
+			return
 		}
+		listener.OnLiveRates(ctx, multipliers(rates))
 	}
 }
 
-func toJson(input interface{}) string {
-	b, err := json.Marshal(input)
-	if err != nil {
-		logger.Error("cannot marshal to json")
-		return ""
-	}
-	return string(b)
+func cachedAll(ratesCache Cache, key string, currencies []string) bool {
+	rates, ok := cache.GetJSON[map[string]model.Quote](ratesCache, key)
+	return ok && lo.Every(lo.Keys(rates), currencies)
 }
 
 type Cache interface {
@@ -192,9 +255,9 @@ type Cache interface {
 }
 
 func NewCurrencyExchangeService(ctx context.Context, currencyClient CurrencyExtractor, rateCache Cache,
-	rateRepo RateStore) *currencyExchangeService {
-	loadPersistedRates(ctx, rateCache, rateRepo)
-	loadNewRates(ctx, rateCache, rateRepo, currencyClient) // for first run
+	rateRepo RateStore, currencyRepo CurrencyRegistry, listener RateListener) *currencyExchangeService {
+	loadPersistedRates(ctx, rateCache, rateRepo, currencyRepo)
+	loadNewRates(ctx, rateCache, rateRepo, currencyRepo, currencyClient, listener) // for first run
 	ticker := time.NewTicker(5 * time.Second)
 	go func() {
 		for {
@@ -203,14 +266,15 @@ func NewCurrencyExchangeService(ctx context.Context, currencyClient CurrencyExtr
 				logger.Info("graceful shutdown")
 				break
 			case <-ticker.C:
-				loadNewRates(ctx, rateCache, rateRepo, currencyClient)
+				loadNewRates(ctx, rateCache, rateRepo, currencyRepo, currencyClient, listener)
 			}
 		}
 	}()
 
 	return &currencyExchangeService{
-		client:    currencyClient,
-		rateRepo:  rateRepo,
-		rateCache: rateCache,
+		client:       currencyClient,
+		rateRepo:     rateRepo,
+		rateCache:    rateCache,
+		currencyRepo: currencyRepo,
 	}
 }
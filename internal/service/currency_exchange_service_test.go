@@ -6,32 +6,49 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/cache"
 	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
 )
 
+// quotesOf wraps multipliers into quotes of the Bank of Russia
+func quotesOf(multipliers map[string]decimal.Decimal) map[string]model.Quote {
+	return lo.MapValues(multipliers, func(multiplier decimal.Decimal, _ string) model.Quote {
+		return model.Quote{Multiplier: multiplier, Provider: "cbr"}
+	})
+}
+
+func newCurrencyRegistryMock(ctrl *gomock.Controller) *serviceMocks.MockCurrencyRegistry {
+	m := serviceMocks.NewMockCurrencyRegistry(ctrl)
+	m.EXPECT().GetEnabledCurrencies(gomock.Any()).Return([]string{"RUB", "USD", "EUR", "CNY"}, nil).AnyTimes()
+	return m
+}
+
 func Test_currencyExchangeService_GetMultiplier_CustomCurrency(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := context.Background()
 
 	currencyClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
 	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
-	defaultExpiration := time.Hour * 24 * 30
-	cleanupInterval := time.Hour
-	simpleCache := NewSimpleCache(ctx, defaultExpiration, cleanupInterval)
+	currencyRepoMock := newCurrencyRegistryMock(ctrl)
+	localCache := cache.NewLocal(100)
+	listenerMock := serviceMocks.NewMockRateListener(ctrl)
+	listenerMock.EXPECT().OnLiveRates(gomock.Any(), gomock.Any()).AnyTimes()
 
-	rates := map[string]decimal.Decimal{
+	rates := quotesOf(map[string]decimal.Decimal{
 		"USD": decimal.NewFromFloat(0.03),
-	}
+	})
 	currencies := []string{"USD", "EUR", "CNY"}
 	firstDateStr := "2022-10-18"
 	firstDate, _ := time.Parse("2006-01-02", firstDateStr)
 	rateUSDValue := decimal.NewFromFloat(0.03)
-	currencyClientMock.EXPECT().GetLiveCurrency(ctx).Return(rates, nil).AnyTimes()
+	currencyClientMock.EXPECT().GetLiveCurrency(ctx, gomock.Any()).Return(rates, nil).AnyTimes()
 	rateRepoMock.EXPECT().SaveAll(ctx, rates, gomock.Any()).Return(nil).AnyTimes()
 	rateRepoMock.EXPECT().GetBatch(ctx, gomock.Any(), currencies).Return(
-		map[string]map[string]decimal.Decimal{
+		map[string]map[string]model.Quote{
 			firstDateStr: rates,
 		}, nil,
 	)
@@ -55,7 +72,7 @@ func Test_currencyExchangeService_GetMultiplier_CustomCurrency(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewCurrencyExchangeService(ctx, currencyClientMock, simpleCache, rateRepoMock)
+			s := NewCurrencyExchangeService(ctx, currencyClientMock, localCache, rateRepoMock, currencyRepoMock, listenerMock)
 			got, err := s.GetMultiplier(ctx, tt.args.currency, tt.args.inputDate)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got, "GetMultiplier: got = %v, want %v", got, tt.want)
@@ -69,19 +86,20 @@ func Test_currencyExchangeService_GetMultiplier_ServerCurrency(t *testing.T) {
 
 	currencyClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
 	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
-	defaultExpiration := time.Hour * 24 * 30
-	cleanupInterval := time.Hour
-	simpleCache := NewSimpleCache(ctx, defaultExpiration, cleanupInterval)
+	currencyRepoMock := newCurrencyRegistryMock(ctrl)
+	localCache := cache.NewLocal(100)
+	listenerMock := serviceMocks.NewMockRateListener(ctrl)
+	listenerMock.EXPECT().OnLiveRates(gomock.Any(), gomock.Any()).AnyTimes()
 
 	firstDateStr := "2022-10-18"
 	firstDate, _ := time.Parse("2006-01-02", firstDateStr)
 	rateRUBValue := decimal.NewFromInt(1)
-	currencyClientMock.EXPECT().GetLiveCurrency(ctx).AnyTimes()
+	currencyClientMock.EXPECT().GetLiveCurrency(ctx, gomock.Any()).AnyTimes()
 	rateRepoMock.EXPECT().SaveAll(ctx, gomock.Any(), gomock.Any()).AnyTimes()
 	rateRepoMock.EXPECT().GetBatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(
-		map[string]map[string]decimal.Decimal{
+		map[string]map[string]model.Quote{
 			firstDateStr: {
-				"CNY": decimal.NewFromFloat(0.3),
+				"CNY": {Multiplier: decimal.NewFromFloat(0.3)},
 			},
 		}, nil,
 	)
@@ -105,10 +123,74 @@ func Test_currencyExchangeService_GetMultiplier_ServerCurrency(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewCurrencyExchangeService(ctx, currencyClientMock, simpleCache, rateRepoMock)
+			s := NewCurrencyExchangeService(ctx, currencyClientMock, localCache, rateRepoMock, currencyRepoMock, listenerMock)
 			got, err := s.GetMultiplier(ctx, tt.args.currency, tt.args.inputDate)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got, "GetMultiplier: got = %v, want %v", got, tt.want)
 		})
 	}
 }
+
+func Test_currencyExchangeService_GetMultiplier_NewlyEnabledCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	currencyClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	currencyRepoMock := serviceMocks.NewMockCurrencyRegistry(ctrl)
+	localCache := cache.NewLocal(100)
+	listenerMock := serviceMocks.NewMockRateListener(ctrl)
+	listenerMock.EXPECT().OnLiveRates(gomock.Any(), gomock.Any()).AnyTimes()
+
+	day := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+	currencies := []string{"USD", "GEL"}
+	_ = localCache.Add(getCurrencyCacheKey(day), `{"USD":{"multiplier":"0.016"}}`, time.Hour)
+	rates := quotesOf(map[string]decimal.Decimal{"USD": decimal.NewFromFloat(0.016), "GEL": decimal.NewFromFloat(0.043)})
+
+	currencyRepoMock.EXPECT().GetEnabledCurrencies(gomock.Any()).Return([]string{"RUB", "USD", "GEL"}, nil).AnyTimes()
+	rateRepoMock.EXPECT().GetBatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	currencyClientMock.EXPECT().GetLiveCurrency(gomock.Any(), currencies).Return(rates, nil).AnyTimes()
+	currencyClientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), day, currencies).Return(rates, nil)
+	rateRepoMock.EXPECT().SaveAll(gomock.Any(), rates, gomock.Any()).AnyTimes()
+
+	s := NewCurrencyExchangeService(ctx, currencyClientMock, localCache, rateRepoMock, currencyRepoMock, listenerMock)
+	got, err := s.GetMultiplier(ctx, "GEL", day)
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(0.043).Equal(got))
+}
+
+func Test_currencyExchangeService_GetRate_ProviderAndEffectiveDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	currencyClientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	rateRepoMock := serviceMocks.NewMockRateStore(ctrl)
+	localCache := cache.NewLocal(100)
+	listenerMock := serviceMocks.NewMockRateListener(ctrl)
+	listenerMock.EXPECT().OnLiveRates(gomock.Any(), gomock.Any()).AnyTimes()
+
+	sunday := time.Date(2022, 10, 16, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2022, 10, 14, 0, 0, 0, 0, time.UTC)
+	currencyClientMock.EXPECT().GetLiveCurrency(gomock.Any(), gomock.Any()).Return(quotesOf(map[string]decimal.Decimal{
+		"USD": decimal.NewFromFloat(0.016), "EUR": decimal.NewFromFloat(0.0165), "CNY": decimal.NewFromFloat(0.11),
+	}), nil).AnyTimes()
+	rateRepoMock.EXPECT().SaveAll(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	rateRepoMock.EXPECT().GetBatch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	rateRepoMock.EXPECT().GetBatch(gomock.Any(), []time.Time{sunday}, []string{"USD"}).Return(
+		map[string]map[string]model.Quote{"2022-10-16": {
+			"USD": {Multiplier: decimal.NewFromFloat(0.016), Provider: "cbr", Date: friday},
+		}}, nil)
+
+	s := NewCurrencyExchangeService(ctx, currencyClientMock, localCache, rateRepoMock, newCurrencyRegistryMock(ctrl), listenerMock)
+	rate, err := s.GetRate(ctx, "USD", sunday)
+	assert.NoError(t, err)
+	assert.Equal(t, "cbr", rate.Provider)
+	assert.Equal(t, friday, rate.EffectiveDate)
+	assert.Equal(t, sunday, rate.Date)
+
+	cached, err := s.GetRate(ctx, "USD", sunday)
+	assert.NoError(t, err)
+	assert.Equal(t, rate.Provider, cached.Provider)
+	assert.True(t, friday.Equal(cached.EffectiveDate))
+}
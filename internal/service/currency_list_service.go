@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+	"go.uber.org/zap"
+)
+
+type CurrencyListStore interface {
+	EnableCurrency(ctx context.Context, currency iso4217.Currency, symbol string) (string, error)
+	DisableCurrency(ctx context.Context, currencyID string) error
+}
+
+// currencyListService manages set of currencies which users can choose and rates are loaded for
+type currencyListService struct {
+	currencyRepo CurrencyListStore
+	adminIDs     map[int64]struct{}
+}
+
+func NewCurrencyListService(currencyRepo CurrencyListStore, adminIDs []int64) *currencyListService {
+	admins := make(map[int64]struct{}, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = struct{}{}
+	}
+	return &currencyListService{
+		currencyRepo: currencyRepo,
+		adminIDs:     admins,
+	}
+}
+
+func (s *currencyListService) IsAdmin(userID int64) bool {
+	_, ok := s.adminIDs[userID]
+	return ok
+}
+
+// EnableCurrency adds currency or asset from catalog, currency outside of catalog needs explicit symbol
+func (s *currencyListService) EnableCurrency(ctx context.Context, code, symbol string) (iso4217.Currency, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EnableCurrency")
+	defer span.Finish()
+
+	code = strings.ToUpper(code)
+	if !iso4217.ValidAssetCode(code) {
+		return iso4217.Currency{}, constants.InvalidCurrencyCodeErr
+	}
+	currency, ok := iso4217.Lookup(code)
+	if !ok {
+		if symbol == "" {
+			return iso4217.Currency{}, constants.UnknownCurrencySymbolErr
+		}
+		currency = iso4217.Currency{Code: code, Name: code, Precision: iso4217.DefaultPrecision}
+	}
+	saved, err := s.currencyRepo.EnableCurrency(ctx, currency, symbol)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return iso4217.Currency{}, errors.Wrap(err, "cannot enable currency")
+	}
+	currency.Symbol = saved
+	logger.Info("currency enabled", zap.String("currency", code))
+	return currency, nil
+}
+
+func (s *currencyListService) DisableCurrency(ctx context.Context, code string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DisableCurrency")
+	defer span.Finish()
+
+	code = strings.ToUpper(code)
+	if code == constants.ServerCurrency {
+		return constants.ServerCurrencyErr
+	}
+	if !iso4217.ValidAssetCode(code) {
+		return constants.InvalidCurrencyCodeErr
+	}
+	if err := s.currencyRepo.DisableCurrency(ctx, code); err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot disable currency")
+	}
+	logger.Info("currency disabled", zap.String("currency", code))
+	return nil
+}
+
+// EnableConfigured makes sure currencies from config are present and enabled
+func (s *currencyListService) EnableConfigured(ctx context.Context, codes []string) error {
+	for _, code := range codes {
+		if _, err := s.EnableCurrency(ctx, code, ""); err != nil {
+			return errors.Wrap(err, code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/iso4217"
+)
+
+func TestCurrencyListService_EnableCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	repo := serviceMocks.NewMockCurrencyListStore(ctrl)
+	s := NewCurrencyListService(repo, []int64{1})
+
+	gel := iso4217.Currency{Code: "GEL", Name: "лари", Symbol: "₾", Precision: 2}
+	repo.EXPECT().EnableCurrency(gomock.Any(), gel, "").Return("₾", nil)
+	currency, err := s.EnableCurrency(ctx, "gel", "")
+	assert.NoError(t, err)
+	assert.Equal(t, gel, currency)
+
+	repo.EXPECT().EnableCurrency(gomock.Any(), gel, "GEL").Return("GEL", nil)
+	currency, err = s.EnableCurrency(ctx, "GEL", "GEL")
+	assert.NoError(t, err)
+	assert.Equal(t, "GEL", currency.Symbol)
+
+	repo.EXPECT().EnableCurrency(gomock.Any(), iso4217.Currency{Code: "MNT", Name: "MNT", Precision: 2}, "₮").Return("₮", nil)
+	_, err = s.EnableCurrency(ctx, "MNT", "₮")
+	assert.NoError(t, err)
+
+	btc, _ := iso4217.Lookup("BTC")
+	repo.EXPECT().EnableCurrency(gomock.Any(), btc, "").Return(btc.Symbol, nil)
+	currency, err = s.EnableCurrency(ctx, "btc", "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 8, currency.Precision)
+
+	_, err = s.EnableCurrency(ctx, "MNT", "")
+	assert.ErrorIs(t, err, constants.UnknownCurrencySymbolErr)
+
+	_, err = s.EnableCurrency(ctx, "DOLLAR", "$")
+	assert.ErrorIs(t, err, constants.InvalidCurrencyCodeErr)
+}
+
+func TestCurrencyListService_DisableCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	repo := serviceMocks.NewMockCurrencyListStore(ctrl)
+	s := NewCurrencyListService(repo, []int64{1})
+
+	repo.EXPECT().DisableCurrency(gomock.Any(), "GEL")
+	assert.NoError(t, s.DisableCurrency(ctx, "gel"))
+	assert.ErrorIs(t, s.DisableCurrency(ctx, "RUB"), constants.ServerCurrencyErr)
+	assert.True(t, s.IsAdmin(1))
+	assert.False(t, s.IsAdmin(2))
+}
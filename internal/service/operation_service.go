@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils"
+)
+
+type OperationStore interface {
+	AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal, createdAt time.Time) (int64, error)
+}
+
+type OperationCategoryStore interface {
+	ResolveCategories(ctx context.Context, lang string, IDs []string) (category map[string]model.CategoryData, err error)
+}
+
+type OperationLimitStore interface {
+	AddLimit(ctx context.Context, userID int64, categoryID string, upperBorder decimal.Decimal, untilDate time.Time) error
+}
+
+type OperationUserStore interface {
+	GetUserCurrency(ctx context.Context, userID int64) (string, error)
+}
+
+type EnabledCurrencyStore interface {
+	GetEnabledCurrencies(ctx context.Context) ([]string, error)
+}
+
+type MultiplierGetter interface {
+	GetMultiplier(ctx context.Context, currency string, date time.Time) (decimal.Decimal, error)
+}
+
+type ReportInvalidator interface {
+	InvalidateReports(userID int64) error
+}
+
+// operationService adds expenses and limits given in any enabled currency for programmatic clients,
+// amounts are converted to server currency as in the bot
+type operationService struct {
+	transactionRepo OperationStore
+	categoryRepo    OperationCategoryStore
+	limitationRepo  OperationLimitStore
+	userRepo        OperationUserStore
+	currencyRepo    EnabledCurrencyStore
+	rateService     MultiplierGetter
+	reports         ReportInvalidator
+}
+
+func NewOperationService(transactionRepo OperationStore, categoryRepo OperationCategoryStore,
+	limitationRepo OperationLimitStore, userRepo OperationUserStore, currencyRepo EnabledCurrencyStore,
+	rateService MultiplierGetter, reports ReportInvalidator) *operationService {
+	return &operationService{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		limitationRepo:  limitationRepo,
+		userRepo:        userRepo,
+		currencyRepo:    currencyRepo,
+		rateService:     rateService,
+		reports:         reports,
+	}
+}
+
+// AddOperation persists expense converted to server currency by rate of its date,
+// empty currency means currency of user
+func (s *operationService) AddOperation(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal,
+	currency string, date time.Time) (model.Transaction, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "AddOperation")
+	defer span.Finish()
+
+	if !amount.IsPositive() {
+		return model.Transaction{}, constants.NotPositiveAmountErr
+	}
+	if date.After(time.Now()) {
+		return model.Transaction{}, constants.FutureDateErr
+	}
+	multiplier, err := s.prepare(ctx, userID, categoryID, currency, date)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+
+	transaction := model.Transaction{
+		Amount:     amount.Div(multiplier),
+		CategoryID: categoryID,
+		Date:       date,
+	}
+	transaction.ID, err = s.transactionRepo.AddOperation(ctx, userID, categoryID, transaction.Amount, date)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.Transaction{}, errors.Wrap(err, "cannot add operation")
+	}
+	_ = s.reports.InvalidateReports(userID)
+	return transaction, nil
+}
+
+// SetLimit sets limit of category until the end of current month, empty currency means currency of user
+func (s *operationService) SetLimit(ctx context.Context, userID int64, categoryID string, amount decimal.Decimal,
+	currency string) (model.Limit, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "SetLimit")
+	defer span.Finish()
+
+	if !amount.IsPositive() {
+		return model.Limit{}, constants.NotPositiveAmountErr
+	}
+	now := time.Now()
+	multiplier, err := s.prepare(ctx, userID, categoryID, currency, now)
+	if err != nil {
+		return model.Limit{}, err
+	}
+
+	limit := model.Limit{
+		CategoryID:  categoryID,
+		UpperBorder: amount.Div(multiplier),
+		UntilDate:   utils.EndOfMonth(now),
+	}
+	if err = s.limitationRepo.AddLimit(ctx, userID, categoryID, limit.UpperBorder, limit.UntilDate); err != nil {
+		span.SetTag("error", err.Error())
+		return model.Limit{}, errors.Wrap(err, "cannot set limit")
+	}
+	_ = s.reports.InvalidateReports(userID)
+	return limit, nil
+}
+
+// ResolveCurrency returns requested currency if it is enabled, currency of user is used when nothing is requested
+func (s *operationService) ResolveCurrency(ctx context.Context, userID int64, requested string) (string, error) {
+	if requested == "" {
+		if currency, err := s.userRepo.GetUserCurrency(ctx, userID); err == nil && currency != "" {
+			return currency, nil
+		}
+		return constants.ServerCurrency, nil
+	}
+	requested = strings.ToUpper(requested)
+	if requested == constants.ServerCurrency {
+		return requested, nil
+	}
+	enabled, err := s.currencyRepo.GetEnabledCurrencies(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot get enabled currencies")
+	}
+	for _, currency := range enabled {
+		if currency == requested {
+			return currency, nil
+		}
+	}
+	return "", errors.Wrap(constants.DisabledCurrencyErr, requested)
+}
+
+// prepare validates category and currency and returns multiplier of currency on date
+func (s *operationService) prepare(ctx context.Context, userID int64, categoryID, currency string,
+	date time.Time) (decimal.Decimal, error) {
+	categories, err := s.categoryRepo.ResolveCategories(ctx, string(i18n.DefaultLang), []string{categoryID})
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "cannot resolve category")
+	}
+	if _, ok := categories[categoryID]; !ok {
+		return decimal.Zero, errors.Wrap(constants.UnknownCategoryErr, categoryID)
+	}
+	if currency, err = s.ResolveCurrency(ctx, userID, currency); err != nil {
+		return decimal.Zero, err
+	}
+	multiplier, err := s.rateService.GetMultiplier(ctx, currency, date)
+	if err != nil {
+		return decimal.Zero, errors.Wrapf(err, "cannot get rate of %s", currency)
+	}
+	if multiplier.IsZero() {
+		multiplier = decimal.NewFromInt(1)
+	}
+	return multiplier, nil
+}
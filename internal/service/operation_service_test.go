@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+type operationMocks struct {
+	transactionRepo *serviceMocks.MockOperationStore
+	categoryRepo    *serviceMocks.MockOperationCategoryStore
+	limitationRepo  *serviceMocks.MockOperationLimitStore
+	userRepo        *serviceMocks.MockOperationUserStore
+	currencyRepo    *serviceMocks.MockEnabledCurrencyStore
+	rateService     *serviceMocks.MockMultiplierGetter
+	reports         *serviceMocks.MockReportInvalidator
+}
+
+func newOperationService(t *testing.T) (*operationService, *operationMocks) {
+	ctrl := gomock.NewController(t)
+	m := &operationMocks{
+		transactionRepo: serviceMocks.NewMockOperationStore(ctrl),
+		categoryRepo:    serviceMocks.NewMockOperationCategoryStore(ctrl),
+		limitationRepo:  serviceMocks.NewMockOperationLimitStore(ctrl),
+		userRepo:        serviceMocks.NewMockOperationUserStore(ctrl),
+		currencyRepo:    serviceMocks.NewMockEnabledCurrencyStore(ctrl),
+		rateService:     serviceMocks.NewMockMultiplierGetter(ctrl),
+		reports:         serviceMocks.NewMockReportInvalidator(ctrl),
+	}
+	return NewOperationService(m.transactionRepo, m.categoryRepo, m.limitationRepo, m.userRepo, m.currencyRepo,
+		m.rateService, m.reports), m
+}
+
+func (m *operationMocks) expectCategory(categoryID string) {
+	m.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "ru", []string{categoryID}).Return(
+		map[string]model.CategoryData{"FOOD": {ID: "FOOD", Name: "Еда"}}, nil)
+}
+
+func TestOperationService_AddOperationConvertsToServerCurrency(t *testing.T) {
+	s, m := newOperationService(t)
+	date := time.Now().Add(-time.Hour)
+
+	m.expectCategory("FOOD")
+	m.currencyRepo.EXPECT().GetEnabledCurrencies(gomock.Any()).Return([]string{"EUR", "RUB", "USD"}, nil)
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "USD", date).Return(decimal.RequireFromString("0.01"), nil)
+	m.transactionRepo.EXPECT().AddOperation(gomock.Any(), int64(1), "FOOD", gomock.Any(), date).Return(int64(42), nil)
+	m.reports.EXPECT().InvalidateReports(int64(1))
+
+	transaction, err := s.AddOperation(context.Background(), 1, "FOOD", decimal.NewFromInt(12), "usd", date)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 42, transaction.ID)
+	assert.Equal(t, "1200", transaction.Amount.String())
+}
+
+func TestOperationService_AddOperationValidatesInput(t *testing.T) {
+	s, m := newOperationService(t)
+	ctx := context.Background()
+
+	_, err := s.AddOperation(ctx, 1, "FOOD", decimal.NewFromInt(-1), "", time.Now())
+	assert.ErrorIs(t, err, constants.NotPositiveAmountErr)
+
+	_, err = s.AddOperation(ctx, 1, "FOOD", decimal.NewFromInt(1), "", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, constants.FutureDateErr)
+
+	m.expectCategory("TOYS")
+	_, err = s.AddOperation(ctx, 1, "TOYS", decimal.NewFromInt(1), "", time.Now())
+	assert.ErrorIs(t, err, constants.UnknownCategoryErr)
+
+	m.expectCategory("FOOD")
+	m.currencyRepo.EXPECT().GetEnabledCurrencies(gomock.Any()).Return([]string{"RUB", "USD"}, nil)
+	_, err = s.AddOperation(ctx, 1, "FOOD", decimal.NewFromInt(1), "GEL", time.Now())
+	assert.ErrorIs(t, err, constants.DisabledCurrencyErr)
+}
+
+func TestOperationService_SetLimitInUserCurrency(t *testing.T) {
+	s, m := newOperationService(t)
+
+	m.expectCategory("FOOD")
+	m.userRepo.EXPECT().GetUserCurrency(gomock.Any(), int64(1)).Return("EUR", nil)
+	m.rateService.EXPECT().GetMultiplier(gomock.Any(), "EUR", gomock.Any()).Return(decimal.RequireFromString("0.01"), nil)
+	m.limitationRepo.EXPECT().AddLimit(gomock.Any(), int64(1), "FOOD", gomock.Any(), gomock.Any())
+	m.reports.EXPECT().InvalidateReports(int64(1))
+
+	limit, err := s.SetLimit(context.Background(), 1, "FOOD", decimal.NewFromInt(500), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "50000", limit.UpperBorder.String())
+	assert.True(t, limit.UntilDate.After(time.Now().Add(-24*time.Hour)))
+}
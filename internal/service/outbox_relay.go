@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+type OutboxStore interface {
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error)
+	MarkPublished(ctx context.Context, eventID int64) error
+	MarkFailed(ctx context.Context, eventID int64, reason string, delay time.Duration) error
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventSink delivers domain events outside of bot
+type EventSink interface {
+	Name() string
+	Deliver(ctx context.Context, event model.Event) error
+}
+
+type OutboxConfig interface {
+	OutboxPollInterval() time.Duration
+	OutboxBatchSize() int
+	OutboxRetention() time.Duration
+}
+
+const (
+	// outboxLease is time claimed events are hidden from other relays, it covers delivery of the whole batch
+	outboxLease         = 5 * time.Minute
+	outboxMaxRetryDelay = time.Hour
+	outboxPurgeInterval = time.Hour
+)
+
+// outboxRelay publishes events written to outbox to all sinks at least once: event is delivered again to every sink
+// until all of them accept it, so sinks receive duplicates which are recognized by idempotency key
+type outboxRelay struct {
+	store     OutboxStore
+	sinks     []EventSink
+	config    OutboxConfig
+	lastPurge time.Time
+}
+
+func NewOutboxRelay(config OutboxConfig, store OutboxStore, sinks ...EventSink) *outboxRelay {
+	return &outboxRelay{
+		store:  store,
+		sinks:  sinks,
+		config: config,
+	}
+}
+
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.OutboxPollInterval())
+	defer ticker.Stop()
+	for {
+		r.relayAll(ctx)
+		r.purge(ctx)
+		select {
+		case <-ctx.Done():
+			logger.Info("outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayAll delivers batches while they are full, full batch means there are more due events
+func (r *outboxRelay) relayAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		if r.relay(ctx) < r.config.OutboxBatchSize() {
+			return
+		}
+	}
+}
+
+// relay delivers one batch of due events and returns its size
+func (r *outboxRelay) relay(ctx context.Context) int {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OutboxRelay")
+	defer span.Finish()
+
+	events, err := r.store.ClaimEvents(ctx, r.config.OutboxBatchSize(), outboxLease)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0
+	}
+	span.SetTag("events", len(events))
+	for _, event := range events {
+		if err = r.deliver(ctx, event); err != nil {
+			delay := r.retryDelay(event.Attempts)
+			logger.Warn("cannot deliver outbox event, retrying later",
+				zap.Int64("eventID", event.ID),
+				zap.String("eventType", event.Type),
+				zap.Int("attempts", event.Attempts+1),
+				zap.Duration("delay", delay),
+				zap.Error(err))
+			_ = r.store.MarkFailed(ctx, event.ID, err.Error(), delay)
+			continue
+		}
+		_ = r.store.MarkPublished(ctx, event.ID)
+	}
+	return len(events)
+}
+
+// deliver passes event to every sink, the first failure is returned after all sinks are tried
+func (r *outboxRelay) deliver(ctx context.Context, event model.Event) error {
+	var firstErr error
+	for _, sink := range r.sinks {
+		status := "ok"
+		if err := sink.Deliver(ctx, event); err != nil {
+			status = "error"
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "sink %s", sink.Name())
+			}
+		}
+		metrics.OutboxDeliveryCounter.WithLabelValues(sink.Name(), event.Type, status).Inc()
+	}
+	return firstErr
+}
+
+// retryDelay grows exponentially from poll interval up to outboxMaxRetryDelay
+func (r *outboxRelay) retryDelay(attempts int) time.Duration {
+	delay := r.config.OutboxPollInterval() << attempts
+	if delay <= 0 || delay > outboxMaxRetryDelay || attempts > 32 {
+		return outboxMaxRetryDelay
+	}
+	return delay
+}
+
+// purge deletes events published longer than retention ago, at most once in outboxPurgeInterval
+func (r *outboxRelay) purge(ctx context.Context) {
+	now := time.Now()
+	if now.Sub(r.lastPurge) < outboxPurgeInterval {
+		return
+	}
+	r.lastPurge = now
+	purged, err := r.store.PurgePublished(ctx, now.Add(-r.config.OutboxRetention()))
+	if err == nil && purged > 0 {
+		logger.Info("published outbox events purged", zap.Int64("purged", purged))
+	}
+}
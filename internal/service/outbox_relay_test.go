@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+type testOutboxConfig struct {
+	batchSize int
+}
+
+func (c testOutboxConfig) OutboxPollInterval() time.Duration { return time.Second }
+func (c testOutboxConfig) OutboxBatchSize() int              { return c.batchSize }
+func (c testOutboxConfig) OutboxRetention() time.Duration    { return time.Hour }
+
+func newTestSink(ctrl *gomock.Controller, name string) *serviceMocks.MockEventSink {
+	sink := serviceMocks.NewMockEventSink(ctrl)
+	sink.EXPECT().Name().Return(name).AnyTimes()
+	return sink
+}
+
+func TestOutboxRelay_DeliversEventsToAllSinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := serviceMocks.NewMockOutboxStore(ctrl)
+	logSink, webhookSink := newTestSink(ctrl, "log"), newTestSink(ctrl, "webhook")
+	r := NewOutboxRelay(testOutboxConfig{batchSize: 2}, store, logSink, webhookSink)
+
+	first := model.Event{ID: 1, IdempotencyKey: "a", Type: model.TransactionCreatedEvent}
+	second := model.Event{ID: 2, IdempotencyKey: "b", Type: model.LimitExceededEvent}
+	third := model.Event{ID: 3, IdempotencyKey: "c", Type: model.LimitSetEvent}
+	gomock.InOrder(
+		store.EXPECT().ClaimEvents(gomock.Any(), 2, outboxLease).Return([]model.Event{first, second}, nil),
+		store.EXPECT().ClaimEvents(gomock.Any(), 2, outboxLease).Return([]model.Event{third}, nil),
+	)
+	for _, e := range []model.Event{first, second, third} {
+		logSink.EXPECT().Deliver(gomock.Any(), e)
+		webhookSink.EXPECT().Deliver(gomock.Any(), e)
+		store.EXPECT().MarkPublished(gomock.Any(), e.ID)
+	}
+
+	r.relayAll(context.Background())
+}
+
+func TestOutboxRelay_RetriesEventRejectedByAnySink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := serviceMocks.NewMockOutboxStore(ctrl)
+	logSink, webhookSink := newTestSink(ctrl, "log"), newTestSink(ctrl, "webhook")
+	r := NewOutboxRelay(testOutboxConfig{batchSize: 10}, store, webhookSink, logSink)
+
+	event := model.Event{ID: 7, IdempotencyKey: "a", Type: model.LimitSetEvent, Attempts: 3}
+	store.EXPECT().ClaimEvents(gomock.Any(), 10, outboxLease).Return([]model.Event{event}, nil)
+	webhookSink.EXPECT().Deliver(gomock.Any(), event).Return(errors.New("connection refused"))
+	logSink.EXPECT().Deliver(gomock.Any(), event)
+	store.EXPECT().MarkFailed(gomock.Any(), int64(7), "sink webhook: connection refused", 8*time.Second)
+
+	assert.Equal(t, 1, r.relay(context.Background()))
+}
+
+func TestOutboxRelay_RetryDelayIsCapped(t *testing.T) {
+	r := NewOutboxRelay(testOutboxConfig{batchSize: 10}, nil)
+
+	assert.Equal(t, time.Second, r.retryDelay(0))
+	assert.Equal(t, 32*time.Second, r.retryDelay(5))
+	assert.Equal(t, outboxMaxRetryDelay, r.retryDelay(20))
+	assert.Equal(t, outboxMaxRetryDelay, r.retryDelay(100))
+}
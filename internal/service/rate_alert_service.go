@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+type RateAlertStore interface {
+	AddAlert(ctx context.Context, alert model.RateAlert) (int64, error)
+	GetUserAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error)
+	GetAlertsByCurrencies(ctx context.Context, currencies []string) ([]model.RateAlert, error)
+	DeleteAlert(ctx context.Context, userID, alertID int64) error
+}
+
+// RateAlertNotifier tells user that watched price was crossed
+type RateAlertNotifier interface {
+	NotifyRateAlert(ctx context.Context, alert model.RateAlert, price decimal.Decimal) error
+}
+
+// maxAlertsPerUser keeps check of live rates cheap
+const maxAlertsPerUser = 10
+
+// rateAlertService keeps one-shot alerts on prices of currencies and checks them against new live rates
+type rateAlertService struct {
+	alertRepo    RateAlertStore
+	currencyRepo CurrencyRegistry
+	notifier     RateAlertNotifier
+}
+
+func NewRateAlertService(alertRepo RateAlertStore, currencyRepo CurrencyRegistry, notifier RateAlertNotifier) *rateAlertService {
+	return &rateAlertService{
+		alertRepo:    alertRepo,
+		currencyRepo: currencyRepo,
+		notifier:     notifier,
+	}
+}
+
+func (s *rateAlertService) AddAlert(ctx context.Context, alert model.RateAlert) (model.RateAlert, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "AddAlert")
+	defer span.Finish()
+
+	currencies, err := getRateCurrencies(ctx, s.currencyRepo)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.RateAlert{}, err
+	}
+	if !lo.Contains(currencies, alert.Currency) {
+		return model.RateAlert{}, constants.UndefinedCurrencyErr
+	}
+	alerts, err := s.alertRepo.GetUserAlerts(ctx, alert.UserID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.RateAlert{}, err
+	}
+	if len(alerts) >= maxAlertsPerUser {
+		return model.RateAlert{}, constants.TooManyAlertsErr
+	}
+	if alert.ID, err = s.alertRepo.AddAlert(ctx, alert); err != nil {
+		span.SetTag("error", err.Error())
+		return model.RateAlert{}, err
+	}
+	return alert, nil
+}
+
+func (s *rateAlertService) GetAlerts(ctx context.Context, userID int64) ([]model.RateAlert, error) {
+	return s.alertRepo.GetUserAlerts(ctx, userID)
+}
+
+func (s *rateAlertService) DeleteAlert(ctx context.Context, userID, alertID int64) error {
+	return s.alertRepo.DeleteAlert(ctx, userID, alertID)
+}
+
+// OnLiveRates notifies owners of crossed alerts and removes these alerts
+func (s *rateAlertService) OnLiveRates(ctx context.Context, rates map[string]decimal.Decimal) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CheckRateAlerts")
+	defer span.Finish()
+
+	alerts, err := s.alertRepo.GetAlertsByCurrencies(ctx, lo.Keys(rates))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return
+	}
+	for _, alert := range alerts {
+		price := model.RatePoint{Multiplier: rates[alert.Currency]}.Price()
+		if price.IsZero() || !alert.Triggered(price) {
+			continue
+		}
+		if err = s.notifier.NotifyRateAlert(ctx, alert, price); err != nil {
+			logger.Error("cannot notify about rate alert", zap.Int64("alertID", alert.ID), zap.Error(err))
+			continue // alert is kept to notify on the next rate
+		}
+		if err = s.alertRepo.DeleteAlert(ctx, alert.UserID, alert.ID); err != nil {
+			logger.Error("cannot delete triggered rate alert", zap.Int64("alertID", alert.ID), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestRateAlertService_AddAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	alertRepoMock := serviceMocks.NewMockRateAlertStore(ctrl)
+	s := NewRateAlertService(alertRepoMock, newCurrencyRegistryMock(ctrl), serviceMocks.NewMockRateAlertNotifier(ctrl))
+	alert := model.RateAlert{UserID: 1, Currency: "USD", Direction: model.AlertBelow, Threshold: decimal.NewFromInt(85)}
+
+	t.Run("alert is saved", func(t *testing.T) {
+		alertRepoMock.EXPECT().GetUserAlerts(gomock.Any(), int64(1)).Return(nil, nil)
+		alertRepoMock.EXPECT().AddAlert(gomock.Any(), alert).Return(int64(7), nil)
+
+		got, err := s.AddAlert(ctx, alert)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), got.ID)
+	})
+
+	t.Run("server currency cannot be watched", func(t *testing.T) {
+		_, err := s.AddAlert(ctx, model.RateAlert{UserID: 1, Currency: "RUB", Threshold: decimal.NewFromInt(1)})
+		assert.ErrorIs(t, err, constants.UndefinedCurrencyErr)
+	})
+
+	t.Run("alerts are limited", func(t *testing.T) {
+		alertRepoMock.EXPECT().GetUserAlerts(gomock.Any(), int64(1)).Return(make([]model.RateAlert, maxAlertsPerUser), nil)
+
+		_, err := s.AddAlert(ctx, alert)
+		assert.ErrorIs(t, err, constants.TooManyAlertsErr)
+	})
+}
+
+func TestRateAlertService_OnLiveRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	below := model.RateAlert{ID: 1, UserID: 10, Currency: "USD", Direction: model.AlertBelow, Threshold: decimal.NewFromInt(85)}
+	above := model.RateAlert{ID: 2, UserID: 11, Currency: "USD", Direction: model.AlertAbove, Threshold: decimal.NewFromInt(95)}
+	failing := model.RateAlert{ID: 3, UserID: 12, Currency: "EUR", Direction: model.AlertAbove, Threshold: decimal.NewFromInt(1)}
+	rates := map[string]decimal.Decimal{
+		"USD": decimal.RequireFromString("0.0125"), // 80 RUB
+		"EUR": decimal.RequireFromString("0.01"),
+	}
+
+	alertRepoMock := serviceMocks.NewMockRateAlertStore(ctrl)
+	notifierMock := serviceMocks.NewMockRateAlertNotifier(ctrl)
+	alertRepoMock.EXPECT().GetAlertsByCurrencies(gomock.Any(), gomock.Any()).Return([]model.RateAlert{below, above, failing}, nil)
+	notifierMock.EXPECT().NotifyRateAlert(gomock.Any(), below, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ model.RateAlert, price decimal.Decimal) error {
+			assert.True(t, price.Equal(decimal.NewFromInt(80)))
+			return nil
+		})
+	alertRepoMock.EXPECT().DeleteAlert(gomock.Any(), int64(10), int64(1))
+	notifierMock.EXPECT().NotifyRateAlert(gomock.Any(), failing, gomock.Any()).Return(errors.New("bot was blocked"))
+
+	s := NewRateAlertService(alertRepoMock, newCurrencyRegistryMock(ctrl), notifierMock)
+	s.OnLiveRates(ctx, rates)
+}
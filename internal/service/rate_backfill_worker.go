@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+type RateGapStore interface {
+	GetAllDatesWithoutRates(ctx context.Context, startedFrom time.Time, serverCurrency string) ([]time.Time, error)
+	SaveAll(ctx context.Context, rates map[string]model.Quote, date time.Time) error
+}
+
+type BackfillConfig interface {
+	RateBackfillInterval() time.Duration
+	RateBackfillBatchSize() int
+	RateBackfillRequestInterval() time.Duration
+	RateBackfillRetries() int
+}
+
+const (
+	backfillLookback     = 366 * 24 * time.Hour // the longest report period
+	backfillRetryBackoff = 2 * time.Second
+	backfillMaxPostpone  = 7 * 24 * time.Hour
+)
+
+// failedDate tracks a date whose rates could not be loaded, so it does not occupy the batch every cycle
+type failedDate struct {
+	attempts int
+	nextTry  time.Time
+}
+
+// rateBackfillWorker loads historical rates for dates of transactions which lack them,
+// so reports do not wait for external api
+type rateBackfillWorker struct {
+	client       CurrencyExtractor
+	rateRepo     RateGapStore
+	currencyRepo CurrencyRegistry
+	rateCache    Cache
+	config       BackfillConfig
+	trigger      chan struct{}
+	retryBackoff time.Duration
+	failures     map[string]failedDate // accessed only from Run loop
+}
+
+func NewRateBackfillWorker(config BackfillConfig, client CurrencyExtractor, rateRepo RateGapStore,
+	currencyRepo CurrencyRegistry, rateCache Cache) *rateBackfillWorker {
+	return &rateBackfillWorker{
+		client:       client,
+		rateRepo:     rateRepo,
+		currencyRepo: currencyRepo,
+		rateCache:    rateCache,
+		config:       config,
+		trigger:      make(chan struct{}, 1),
+		retryBackoff: backfillRetryBackoff,
+		failures:     make(map[string]failedDate),
+	}
+}
+
+// Trigger asks worker to start next batch without waiting for interval, never blocks
+func (w *rateBackfillWorker) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (w *rateBackfillWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.RateBackfillInterval())
+	defer ticker.Stop()
+	for {
+		w.backfill(ctx)
+		select {
+		case <-ctx.Done():
+			logger.Info("rate backfill worker stopped")
+			return
+		case <-ticker.C:
+		case <-w.trigger:
+		}
+	}
+}
+
+// backfill loads one batch of missing dates, the latest dates go first as they are in most reports,
+// dates which failed recently are postponed so older gaps are reached too
+func (w *rateBackfillWorker) backfill(ctx context.Context) int {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RateBackfill")
+	defer span.Finish()
+
+	dates, err := w.rateRepo.GetAllDatesWithoutRates(ctx, time.Now().Add(-backfillLookback), constants.ServerCurrency)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0
+	}
+	metrics.RatesBackfillPendingGauge.Set(float64(len(dates)))
+	dates = w.dueDates(dates, time.Now())
+	if len(dates) == 0 {
+		return 0
+	}
+	currencies, err := getRateCurrencies(ctx, w.currencyRepo)
+	if err != nil || len(currencies) == 0 {
+		return 0
+	}
+	if batchSize := w.config.RateBackfillBatchSize(); batchSize > 0 && len(dates) > batchSize {
+		dates = dates[:batchSize]
+	}
+
+	loaded := 0
+	for i, date := range dates {
+		if i > 0 && !sleep(ctx, w.config.RateBackfillRequestInterval()) { // external api rate limit
+			break
+		}
+		if err = w.loadDate(ctx, date, currencies); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			metrics.RatesBackfillCounter.WithLabelValues("error").Inc()
+			logger.Error("cannot backfill rates", zap.Time("date", date), zap.Error(err))
+			w.postpone(date, time.Now())
+			continue
+		}
+		metrics.RatesBackfillCounter.WithLabelValues("ok").Inc()
+		delete(w.failures, date.Format(cacheTimeFormat))
+		loaded++
+	}
+	span.SetTag("loaded", loaded)
+	return loaded
+}
+
+// dueDates drops dates postponed after failures and forgets failures of dates which are not missing anymore
+func (w *rateBackfillWorker) dueDates(dates []time.Time, now time.Time) []time.Time {
+	pending := make(map[string]struct{}, len(dates))
+	due := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		key := date.Format(cacheTimeFormat)
+		pending[key] = struct{}{}
+		if failed, ok := w.failures[key]; ok && now.Before(failed.nextTry) {
+			continue
+		}
+		due = append(due, date)
+	}
+	for key := range w.failures {
+		if _, ok := pending[key]; !ok {
+			delete(w.failures, key)
+		}
+	}
+	return due
+}
+
+// postpone doubles delay before next try of the date with every failure up to backfillMaxPostpone
+func (w *rateBackfillWorker) postpone(date time.Time, now time.Time) {
+	key := date.Format(cacheTimeFormat)
+	failed := w.failures[key]
+	failed.attempts++
+	delay := w.config.RateBackfillInterval()
+	for i := 1; i < failed.attempts && delay < backfillMaxPostpone; i++ {
+		delay *= 2
+	}
+	if delay > backfillMaxPostpone {
+		delay = backfillMaxPostpone
+	}
+	failed.nextTry = now.Add(delay)
+	w.failures[key] = failed
+}
+
+func (w *rateBackfillWorker) loadDate(ctx context.Context, date time.Time, currencies []string) error {
+	var rates map[string]model.Quote
+	var err error
+	for attempt := 0; attempt <= w.config.RateBackfillRetries(); attempt++ {
+		if attempt > 0 && !sleep(ctx, w.retryBackoff<<(attempt-1)) {
+			return ctx.Err()
+		}
+		rates, err = w.client.GetHistoricalCurrency(ctx, date, currencies)
+		if err == nil {
+			break
+		}
+		logger.Warn("backfill attempt failed", zap.Time("date", date), zap.Int("attempt", attempt), zap.Error(err))
+	}
+	if err != nil {
+		return err
+	}
+	if err = w.rateRepo.SaveAll(ctx, rates, date); err != nil {
+		return errors.Wrap(err, "cannot save backfilled rates")
+	}
+	saveToCache(w.rateCache, map[string]map[string]model.Quote{date.Format(cacheTimeFormat): rates})
+	return nil
+}
+
+// sleep waits for duration and reports false if context is cancelled earlier
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
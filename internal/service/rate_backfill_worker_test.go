@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+)
+
+type backfillTestConfig struct {
+	batchSize int
+	retries   int
+}
+
+func (c backfillTestConfig) RateBackfillInterval() time.Duration        { return time.Minute }
+func (c backfillTestConfig) RateBackfillBatchSize() int                 { return c.batchSize }
+func (c backfillTestConfig) RateBackfillRequestInterval() time.Duration { return time.Millisecond }
+func (c backfillTestConfig) RateBackfillRetries() int                   { return c.retries }
+
+func TestRateBackfillWorker_Backfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	today := time.Now()
+	yesterday := today.Add(-24 * time.Hour)
+	rates := quotesOf(map[string]decimal.Decimal{"USD": decimal.NewFromFloat(0.016)})
+
+	clientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	gapStoreMock := serviceMocks.NewMockRateGapStore(ctrl)
+	cacheMock := serviceMocks.NewMockCache(ctrl)
+	gapStoreMock.EXPECT().GetAllDatesWithoutRates(gomock.Any(), gomock.Any(), constants.ServerCurrency).
+		Return([]time.Time{today, yesterday, today.Add(-48 * time.Hour)}, nil)
+	gomock.InOrder(
+		clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), today, []string{"USD", "EUR", "CNY"}).
+			Return(nil, errors.New("too many requests")),
+		clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), today, gomock.Any()).Return(rates, nil),
+		clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), yesterday, gomock.Any()).Return(rates, nil),
+	)
+	gapStoreMock.EXPECT().SaveAll(gomock.Any(), rates, today)
+	gapStoreMock.EXPECT().SaveAll(gomock.Any(), rates, yesterday)
+	cacheMock.EXPECT().Add(getCurrencyCacheKey(today), gomock.Any(), defaultExpires)
+	cacheMock.EXPECT().Add(getCurrencyCacheKey(yesterday), gomock.Any(), defaultExpires)
+
+	w := NewRateBackfillWorker(backfillTestConfig{batchSize: 2, retries: 1}, clientMock, gapStoreMock,
+		newCurrencyRegistryMock(ctrl), cacheMock)
+	w.retryBackoff = time.Millisecond
+	assert.Equal(t, 2, w.backfill(ctx))
+}
+
+func TestRateBackfillWorker_BackfillRetriesExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	day := time.Now().Add(-24 * time.Hour)
+	clientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	gapStoreMock := serviceMocks.NewMockRateGapStore(ctrl)
+	gapStoreMock.EXPECT().GetAllDatesWithoutRates(gomock.Any(), gomock.Any(), constants.ServerCurrency).
+		Return([]time.Time{day}, nil)
+	clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), day, gomock.Any()).
+		Return(nil, errors.New("service unavailable")).Times(3)
+
+	w := NewRateBackfillWorker(backfillTestConfig{batchSize: 10, retries: 2}, clientMock, gapStoreMock,
+		newCurrencyRegistryMock(ctrl), serviceMocks.NewMockCache(ctrl))
+	w.retryBackoff = time.Millisecond
+	assert.Equal(t, 0, w.backfill(ctx))
+}
+
+func TestRateBackfillWorker_BackfillPostponesFailingDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	day := time.Now().Truncate(24 * time.Hour)
+	dates := []time.Time{day, day.Add(-24 * time.Hour), day.Add(-48 * time.Hour), day.Add(-72 * time.Hour)}
+	rates := quotesOf(map[string]decimal.Decimal{"USD": decimal.NewFromFloat(0.016)})
+
+	clientMock := serviceMocks.NewMockCurrencyExtractor(ctrl)
+	gapStoreMock := serviceMocks.NewMockRateGapStore(ctrl)
+	cacheMock := serviceMocks.NewMockCache(ctrl)
+	gapStoreMock.EXPECT().GetAllDatesWithoutRates(gomock.Any(), gomock.Any(), constants.ServerCurrency).
+		Return(dates, nil).Times(2)
+	for _, date := range dates[:2] { // no quote for these dates at all
+		clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), date, gomock.Any()).
+			Return(nil, errors.New("no rates for the date")).Times(1)
+	}
+	for _, date := range dates[2:] {
+		clientMock.EXPECT().GetHistoricalCurrency(gomock.Any(), date, gomock.Any()).Return(rates, nil)
+		gapStoreMock.EXPECT().SaveAll(gomock.Any(), rates, date)
+		cacheMock.EXPECT().Add(getCurrencyCacheKey(date), gomock.Any(), defaultExpires)
+	}
+
+	w := NewRateBackfillWorker(backfillTestConfig{batchSize: 2}, clientMock, gapStoreMock,
+		newCurrencyRegistryMock(ctrl), cacheMock)
+	assert.Equal(t, 0, w.backfill(ctx))
+	assert.Equal(t, 2, w.backfill(ctx))
+}
+
+func TestRateBackfillWorker_PostponeBacksOff(t *testing.T) {
+	w := NewRateBackfillWorker(backfillTestConfig{}, nil, nil, nil, nil)
+	day := time.Date(2022, 10, 14, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	w.postpone(day, now)
+	assert.Equal(t, []time.Time{}, w.dueDates([]time.Time{day}, now))
+	assert.Equal(t, []time.Time{day}, w.dueDates([]time.Time{day}, now.Add(time.Minute)))
+
+	w.postpone(day, now)
+	assert.Equal(t, now.Add(2*time.Minute), w.failures[day.Format(cacheTimeFormat)].nextTry)
+	for i := 0; i < 20; i++ {
+		w.postpone(day, now)
+	}
+	assert.Equal(t, now.Add(backfillMaxPostpone), w.failures[day.Format(cacheTimeFormat)].nextTry)
+
+	w.dueDates(nil, now)
+	assert.Empty(t, w.failures)
+}
+
+func TestRateBackfillWorker_TriggerDoesNotBlock(t *testing.T) {
+	w := NewRateBackfillWorker(backfillTestConfig{}, nil, nil, nil, nil)
+	w.Trigger()
+	w.Trigger()
+	assert.Len(t, w.trigger, 1)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+type RateHistoryStore interface {
+	GetHistory(ctx context.Context, currencies []string, startedFrom time.Time) (map[string][]model.RatePoint, error)
+}
+
+const (
+	oneDay = 24 * time.Hour
+	// historyGap covers weekends and holidays when looking for rate at or before the reference date
+	historyGap      = 7 * oneDay
+	minHistoryDays  = 2 // sparkline needs at least two points
+	maxHistoryDays  = 365
+	changePrecision = 2
+)
+
+// rateHistoryService shows stored rates of enabled currencies in server currency
+type rateHistoryService struct {
+	rateRepo     RateHistoryStore
+	currencyRepo CurrencyRegistry
+	now          func() time.Time
+}
+
+func NewRateHistoryService(rateRepo RateHistoryStore, currencyRepo CurrencyRegistry) *rateHistoryService {
+	return &rateHistoryService{
+		rateRepo:     rateRepo,
+		currencyRepo: currencyRepo,
+		now:          time.Now,
+	}
+}
+
+// Overview returns the latest price of each enabled currency with its change over 7 and 30 days
+func (s *rateHistoryService) Overview(ctx context.Context) ([]model.RateChange, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RatesOverview")
+	defer span.Finish()
+
+	currencies, err := getRateCurrencies(ctx, s.currencyRepo)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return nil, err
+	}
+	history, err := s.rateRepo.GetHistory(ctx, currencies, s.now().Add(-30*oneDay-historyGap))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get rate history for overview", zap.Error(err))
+		return nil, err
+	}
+
+	changes := make([]model.RateChange, 0, len(currencies))
+	for _, currency := range currencies {
+		points := history[currency]
+		if len(points) == 0 {
+			continue
+		}
+		latest := points[len(points)-1]
+		changes = append(changes, model.RateChange{
+			Currency: currency,
+			Date:     latest.Date,
+			Price:    latest.Price(),
+			Change7:  changeSince(points, latest, 7*oneDay),
+			Change30: changeSince(points, latest, 30*oneDay),
+		})
+	}
+	return changes, nil
+}
+
+// History returns stored rates of currency for the last days, period is limited to one year
+func (s *rateHistoryService) History(ctx context.Context, currency string, days int) ([]model.RatePoint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RatesHistory")
+	defer span.Finish()
+
+	currencies, err := getRateCurrencies(ctx, s.currencyRepo)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return nil, err
+	}
+	if !lo.Contains(currencies, currency) {
+		return nil, constants.UndefinedCurrencyErr
+	}
+	days = lo.Clamp(days, minHistoryDays, maxHistoryDays)
+	history, err := s.rateRepo.GetHistory(ctx, []string{currency}, s.now().Add(-time.Duration(days)*oneDay))
+	if err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot get rate history", zap.String("currency", currency), zap.Error(err))
+		return nil, err
+	}
+	return history[currency], nil
+}
+
+// changeSince finds the latest point at or before period ago and returns price change in percents
+func changeSince(points []model.RatePoint, latest model.RatePoint, period time.Duration) decimal.NullDecimal {
+	reference := latest.Date.Add(-period)
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Date.After(reference) {
+			continue
+		}
+		if reference.Sub(points[i].Date) > historyGap {
+			break
+		}
+		base := points[i].Price()
+		if base.IsZero() {
+			break
+		}
+		change := latest.Price().Div(base).Sub(decimal.NewFromInt(1)).Mul(decimal.NewFromInt(100))
+		return decimal.NewNullDecimal(change.Round(changePrecision))
+	}
+	return decimal.NullDecimal{}
+}
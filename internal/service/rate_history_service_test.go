@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestRateHistoryService_Overview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	point := func(daysAgo int, price string) model.RatePoint {
+		return model.RatePoint{
+			Date:       today.Add(-time.Duration(daysAgo) * oneDay),
+			Multiplier: decimal.NewFromInt(1).Div(decimal.RequireFromString(price)),
+		}
+	}
+
+	historyRepoMock := serviceMocks.NewMockRateHistoryStore(ctrl)
+	historyRepoMock.EXPECT().GetHistory(gomock.Any(), []string{"USD", "EUR", "CNY"}, today.Add(-37*oneDay)).Return(
+		map[string][]model.RatePoint{
+			// weekend before 7 days ago has no rate, the previous friday is used
+			"USD": {point(31, "100"), point(9, "80"), point(0, "88")},
+			"EUR": {point(0, "90")},
+		}, nil)
+	s := NewRateHistoryService(historyRepoMock, newCurrencyRegistryMock(ctrl))
+	s.now = func() time.Time { return today }
+
+	got, err := s.Overview(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "USD", got[0].Currency)
+	assert.Equal(t, "88", got[0].Price.String())
+	assert.Equal(t, "10", got[0].Change7.Decimal.String())
+	assert.Equal(t, "-12", got[0].Change30.Decimal.String())
+	assert.Equal(t, "EUR", got[1].Currency)
+	assert.False(t, got[1].Change7.Valid)
+}
+
+func TestRateHistoryService_History(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	historyRepoMock := serviceMocks.NewMockRateHistoryStore(ctrl)
+	historyRepoMock.EXPECT().GetHistory(gomock.Any(), []string{"USD"}, today.Add(-365*oneDay)).Return(
+		map[string][]model.RatePoint{"USD": {{Date: today, Multiplier: decimal.RequireFromString("0.0125")}}}, nil)
+	s := NewRateHistoryService(historyRepoMock, newCurrencyRegistryMock(ctrl))
+	s.now = func() time.Time { return today }
+
+	got, err := s.History(ctx, "USD", 5000)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = s.History(ctx, "RUB", 30)
+	assert.ErrorIs(t, err, constants.UndefinedCurrencyErr)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// RateProvider is a named external source of rates, rates are units of currency per one constants.ServerCurrency
+type RateProvider interface {
+	CurrencyExtractor
+	Name() string
+}
+
+const (
+	defaultFailureThreshold = 3
+	defaultProviderCooldown = 5 * time.Minute
+)
+
+var NoRateProvidersErr = errors.New("no rate providers configured")
+
+type providerHealth struct {
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// ProviderChain tries providers in priority order, provider which failed several times in a row
+// is skipped until cooldown passes and is used only when all healthy providers fail
+type ProviderChain struct {
+	providers        []RateProvider
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	mu     sync.Mutex
+	health map[string]*providerHealth
+}
+
+func NewProviderChain(failureThreshold int, cooldown time.Duration, providers ...RateProvider) *ProviderChain {
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultProviderCooldown
+	}
+	health := make(map[string]*providerHealth, len(providers))
+	for _, p := range providers {
+		health[p.Name()] = &providerHealth{}
+		metrics.RatesProviderHealthGauge.WithLabelValues(p.Name()).Set(1)
+	}
+	return &ProviderChain{
+		providers:        providers,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+		health:           health,
+	}
+}
+
+func (c *ProviderChain) GetLiveCurrency(ctx context.Context, currencies []string) (map[string]model.Quote, error) {
+	return c.fetch(ctx, "GetLiveCurrency", func(p RateProvider) (map[string]model.Quote, error) {
+		return p.GetLiveCurrency(ctx, currencies)
+	})
+}
+
+func (c *ProviderChain) GetHistoricalCurrency(ctx context.Context, day time.Time, currencies []string) (map[string]model.Quote, error) {
+	return c.fetch(ctx, "GetHistoricalCurrency", func(p RateProvider) (map[string]model.Quote, error) {
+		return p.GetHistoricalCurrency(ctx, day, currencies)
+	})
+}
+
+// Healthy reports whether provider is currently used in priority order
+func (c *ProviderChain) Healthy(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.health[name]
+	return ok && !c.now().Before(h.unhealthyUntil)
+}
+
+func (c *ProviderChain) fetch(ctx context.Context, method string,
+	call func(p RateProvider) (map[string]model.Quote, error)) (map[string]model.Quote, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "ProviderChain:"+method)
+	defer span.Finish()
+
+	if len(c.providers) == 0 {
+		span.SetTag("error", NoRateProvidersErr.Error())
+		return nil, NoRateProvidersErr
+	}
+	var lastErr error
+	for _, p := range c.ordered() {
+		rates, err := call(p)
+		if err == nil && len(rates) == 0 {
+			err = constants.EmptyRatesErr
+		}
+		if err != nil {
+			logger.Warn("rate provider failed", zap.String("provider", p.Name()), zap.String("method", method), zap.Error(err))
+			c.markFailure(p.Name())
+			lastErr = errors.Wrap(err, p.Name())
+			continue
+		}
+		c.markSuccess(p.Name())
+		metrics.RatesSourceCounter.WithLabelValues(p.Name()).Inc()
+		span.SetTag("provider", p.Name())
+		return rates, nil
+	}
+	span.SetTag("error", lastErr.Error())
+	return nil, errors.Wrap(lastErr, "all rate providers failed")
+}
+
+// ordered returns healthy providers in priority order followed by unhealthy ones as last resort
+func (c *ProviderChain) ordered() []RateProvider {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	healthy := make([]RateProvider, 0, len(c.providers))
+	var unhealthy []RateProvider
+	for _, p := range c.providers {
+		if now.Before(c.health[p.Name()].unhealthyUntil) {
+			unhealthy = append(unhealthy, p)
+			continue
+		}
+		healthy = append(healthy, p)
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (c *ProviderChain) markFailure(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h := c.health[name]
+	h.failures++
+	if h.failures >= c.failureThreshold {
+		h.unhealthyUntil = c.now().Add(c.cooldown)
+		metrics.RatesProviderHealthGauge.WithLabelValues(name).Set(0)
+	}
+}
+
+func (c *ProviderChain) markSuccess(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.health[name] = &providerHealth{}
+	metrics.RatesProviderHealthGauge.WithLabelValues(name).Set(1)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+var currencies = []string{"USD", "EUR", "CNY"}
+
+func newProviderMock(ctrl *gomock.Controller, name string) *serviceMocks.MockRateProvider {
+	p := serviceMocks.NewMockRateProvider(ctrl)
+	p.EXPECT().Name().Return(name).AnyTimes()
+	return p
+}
+
+func TestProviderChain_FallsBackToNextProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	rates := quotesOf(map[string]decimal.Decimal{"USD": decimal.NewFromFloat(0.016)})
+
+	cbr := newProviderMock(ctrl, "cbr")
+	ecb := newProviderMock(ctrl, "ecb")
+	cbr.EXPECT().GetLiveCurrency(ctx, currencies).Return(nil, errors.New("timeout"))
+	ecb.EXPECT().GetLiveCurrency(ctx, currencies).Return(rates, nil)
+
+	chain := NewProviderChain(3, time.Minute, cbr, ecb)
+	got, err := chain.GetLiveCurrency(ctx, currencies)
+
+	assert.NoError(t, err)
+	assert.Equal(t, rates, got)
+	assert.True(t, chain.Healthy("cbr"))
+}
+
+func TestProviderChain_SkipsUnhealthyProviderUntilCooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	day := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+	rates := quotesOf(map[string]decimal.Decimal{"USD": decimal.NewFromFloat(0.016)})
+
+	cbr := newProviderMock(ctrl, "cbr")
+	ecb := newProviderMock(ctrl, "ecb")
+	chain := NewProviderChain(1, time.Minute, cbr, ecb)
+	now := time.Now()
+	chain.now = func() time.Time { return now }
+
+	cbr.EXPECT().GetHistoricalCurrency(ctx, day, currencies).Return(map[string]model.Quote{}, nil)
+	ecb.EXPECT().GetHistoricalCurrency(ctx, day, currencies).Return(rates, nil).Times(2)
+	_, err := chain.GetHistoricalCurrency(ctx, day, currencies)
+	assert.NoError(t, err)
+	assert.False(t, chain.Healthy("cbr"))
+
+	_, err = chain.GetHistoricalCurrency(ctx, day, currencies) // cbr is not called while it cools down
+	assert.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	cbr.EXPECT().GetHistoricalCurrency(ctx, day, currencies).Return(rates, nil)
+	_, err = chain.GetHistoricalCurrency(ctx, day, currencies)
+	assert.NoError(t, err)
+	assert.True(t, chain.Healthy("cbr"))
+}
+
+func TestProviderChain_AllProvidersFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	cbr := newProviderMock(ctrl, "cbr")
+	cbr.EXPECT().GetLiveCurrency(ctx, currencies).Return(nil, errors.New("timeout"))
+
+	_, err := NewProviderChain(3, time.Minute, cbr).GetLiveCurrency(ctx, currencies)
+
+	assert.Error(t, err)
+}
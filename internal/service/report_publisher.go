@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+)
+
+// Publisher sends message to topic of message queue
+type Publisher interface {
+	Publish(ctx context.Context, topic string, msg queue.Message) error
+}
+
+// reportPublisher hands calculation of reports over to report worker
+type reportPublisher struct {
+	publisher Publisher
+	topic     string
+}
+
+func NewReportPublisher(publisher Publisher, topic string) *reportPublisher {
+	return &reportPublisher{
+		publisher: publisher,
+		topic:     topic,
+	}
+}
+
+// RequestReport publishes "report requested" event keyed by user, so that reports of one user are sent in order
+func (p *reportPublisher) RequestReport(ctx context.Context, request model.ReportRequest) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "RequestReport")
+	defer span.Finish()
+
+	value, err := json.Marshal(request)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot encode report request")
+	}
+	msg := queue.Message{
+		Key:   strconv.FormatInt(request.UserID, 10),
+		Value: value,
+	}
+	if err = p.publisher.Publish(ctx, p.topic, msg); err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot publish report request")
+	}
+	return nil
+}
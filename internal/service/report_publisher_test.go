@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+)
+
+func TestReportPublisher_KeysRequestByUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	publisher := serviceMocks.NewMockPublisher(ctrl)
+	p := NewReportPublisher(publisher, "reports")
+
+	publisher.EXPECT().Publish(gomock.Any(), "reports", queue.Message{
+		Key:   "42",
+		Value: []byte(`{"user_id":42,"period":"year","currency":"RUB","language":"ru"}`),
+	})
+	err := p.RequestReport(context.Background(),
+		model.ReportRequest{UserID: 42, Period: "year", Currency: "RUB", Language: "ru"})
+
+	assert.NoError(t, err)
+}
+
+func TestReportPublisher_ReturnsPublishError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	publisher := serviceMocks.NewMockPublisher(ctrl)
+	p := NewReportPublisher(publisher, "reports")
+
+	publisher.EXPECT().Publish(gomock.Any(), "reports", gomock.Any()).Return(errors.New("broker is down"))
+	err := p.RequestReport(context.Background(), model.ReportRequest{UserID: 42, Period: "year"})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/expenses"
+	"go.uber.org/zap"
+)
+
+// ReportSender delivers text of report to user
+type ReportSender interface {
+	SendMessage(text string, userID int64) error
+}
+
+type ReportCalculator interface {
+	CalcByCurrentWeek(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentMonth(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+	CalcByCurrentYear(ctx context.Context, userID int64, currency string) (model.ReportData, error)
+}
+
+type ReportCategoryStore interface {
+	ResolveCategories(ctx context.Context, lang string, IDs []string) (category map[string]model.CategoryData, err error)
+}
+
+type ReportCurrencyStore interface {
+	GetCurrency(ctx context.Context, currencyID string) (model.CurrencyData, error)
+}
+
+// reportWorker consumes "report requested" events, calculates reports and sends them to users
+type reportWorker struct {
+	sender       ReportSender
+	calcService  ReportCalculator
+	categoryRepo ReportCategoryStore
+	currencyRepo ReportCurrencyStore
+}
+
+func NewReportWorker(sender ReportSender, calcService ReportCalculator, categoryRepo ReportCategoryStore,
+	currencyRepo ReportCurrencyStore) *reportWorker {
+	return &reportWorker{
+		sender:       sender,
+		calcService:  calcService,
+		categoryRepo: categoryRepo,
+		currencyRepo: currencyRepo,
+	}
+}
+
+// Handle is queue.Handler of report requests, user is told about failures of calculation
+func (w *reportWorker) Handle(ctx context.Context, msg queue.Message) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "HandleReportRequest")
+	defer span.Finish()
+
+	status := "ok"
+	start := time.Now()
+	defer func() {
+		tookTime := time.Since(start).Seconds()
+		metrics.IncomingRequestsTotalCounter.WithLabelValues("queue", constants.ShowReport, status).Inc()
+		metrics.IncomingRequestsHistogramResponseTime.WithLabelValues("queue", constants.ShowReport, status).Observe(tookTime)
+	}()
+
+	var request model.ReportRequest
+	if err := json.Unmarshal(msg.Value, &request); err != nil {
+		status = "error"
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot decode report request")
+	}
+	span.SetTag("userID", request.UserID)
+
+	if err := w.sender.SendMessage(w.makeReport(ctx, request), request.UserID); err != nil {
+		status = "error"
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot send report")
+	}
+	return nil
+}
+
+// makeReport returns text of report or message about failure which is already logged
+func (w *reportWorker) makeReport(ctx context.Context, request model.ReportRequest) string {
+	userID, period, selectedCurrency := request.UserID, request.Period, request.Currency
+	lang := i18n.Resolve(request.Language, "")
+
+	var res model.ReportData
+	var err error
+	switch period {
+	case constants.WeekPeriod:
+		res, err = w.calcService.CalcByCurrentWeek(ctx, userID, selectedCurrency)
+	case constants.MonthPeriod:
+		res, err = w.calcService.CalcByCurrentMonth(ctx, userID, selectedCurrency)
+	case constants.YearPeriod:
+		res, err = w.calcService.CalcByCurrentYear(ctx, userID, selectedCurrency)
+	default:
+		err = errors.Errorf("unknown period '%s'", period)
+	}
+	if err != nil {
+		logger.Error("cannot make report",
+			zap.Int64("userID", userID),
+			zap.String("currency", selectedCurrency),
+			zap.String("period", period),
+			zap.Error(err))
+		if errors.Is(err, constants.MissingRateErr) {
+			return i18n.T(lang, i18n.MissingRate)
+		}
+		return i18n.T(lang, i18n.InternalServerError)
+	}
+	categoryIDs := make([]string, 0, len(res.Expenses))
+	for k := range res.Expenses {
+		categoryIDs = append(categoryIDs, k)
+	}
+	categories, err := w.categoryRepo.ResolveCategories(ctx, string(lang), categoryIDs)
+	if err != nil {
+		logger.Error("cannot make report because of resolving categories problem",
+			zap.Int64("userID", userID),
+			zap.String("currency", selectedCurrency),
+			zap.String("period", period),
+			zap.Error(err))
+		return i18n.T(lang, i18n.InternalServerError)
+	}
+	text := expenses.Format(lang, res.Expenses, categories, period, w.getCurrencyData(ctx, selectedCurrency))
+	if res.PendingRates > 0 {
+		text += "\n\n" + i18n.T(lang, i18n.RatesStillLoading, res.PendingRates)
+	}
+	if res.StaleRates > 0 {
+		text += "\n\n" + i18n.T(lang, i18n.StaleRatesUsed, res.StaleRates)
+	}
+	return text
+}
+
+// getCurrencyData returns currency with symbol for formatting, falls back on bare ISO code
+func (w *reportWorker) getCurrencyData(ctx context.Context, currencyID string) model.CurrencyData {
+	currency, err := w.currencyRepo.GetCurrency(ctx, currencyID)
+	if err != nil {
+		logger.Warn("cannot get currency symbol, fallback on currency code",
+			zap.String("currencyID", currencyID),
+			zap.Error(err))
+		return model.CurrencyData{ID: currencyID}
+	}
+	return currency
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	serviceMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/service"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+)
+
+type testReportWorker struct {
+	worker       *reportWorker
+	sender       *serviceMocks.MockReportSender
+	calcService  *serviceMocks.MockReportCalculator
+	categoryRepo *serviceMocks.MockReportCategoryStore
+	currencyRepo *serviceMocks.MockReportCurrencyStore
+}
+
+func newTestReportWorker(t *testing.T) *testReportWorker {
+	ctrl := gomock.NewController(t)
+	w := &testReportWorker{
+		sender:       serviceMocks.NewMockReportSender(ctrl),
+		calcService:  serviceMocks.NewMockReportCalculator(ctrl),
+		categoryRepo: serviceMocks.NewMockReportCategoryStore(ctrl),
+		currencyRepo: serviceMocks.NewMockReportCurrencyStore(ctrl),
+	}
+	w.worker = NewReportWorker(w.sender, w.calcService, w.categoryRepo, w.currencyRepo)
+	return w
+}
+
+func TestReportWorker_SendsReportRequestedThroughQueue(t *testing.T) {
+	w := newTestReportWorker(t)
+	memoryQueue := queue.NewMemory(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = memoryQueue.Consume(ctx, "reports", w.worker.Handle)
+	}()
+
+	w.calcService.EXPECT().CalcByCurrentMonth(gomock.Any(), int64(1), "USD").Return(model.ReportData{
+		Expenses:   map[string]decimal.Decimal{"FOOD": decimal.RequireFromString("12.5")},
+		StaleRates: 1,
+	}, nil)
+	w.categoryRepo.EXPECT().ResolveCategories(gomock.Any(), "en", []string{"FOOD"}).
+		Return(map[string]model.CategoryData{"FOOD": {ID: "FOOD", Name: "Food"}}, nil)
+	w.currencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(model.CurrencyData{ID: "USD", Symbol: "$"}, nil)
+	sent := make(chan string, 1)
+	w.sender.EXPECT().SendMessage(gomock.Any(), int64(1)).DoAndReturn(func(text string, _ int64) error {
+		sent <- text
+		return nil
+	})
+
+	err := NewReportPublisher(memoryQueue, "reports").RequestReport(context.Background(),
+		model.ReportRequest{UserID: 1, Period: constants.MonthPeriod, Currency: "USD", Language: "en"})
+	require.NoError(t, err)
+
+	select {
+	case text := <-sent:
+		assert.True(t, strings.Contains(text, "Food"), text)
+		assert.True(t, strings.HasSuffix(text, i18n.T(i18n.EN, i18n.StaleRatesUsed, 1)), text)
+	case <-time.After(time.Second):
+		t.Fatal("report is not sent")
+	}
+}
+
+func TestReportWorker_TellsUserAboutMissingRate(t *testing.T) {
+	w := newTestReportWorker(t)
+
+	w.calcService.EXPECT().CalcByCurrentWeek(gomock.Any(), int64(1), "EUR").
+		Return(model.ReportData{}, errors.Wrap(constants.MissingRateErr, "EUR"))
+	w.sender.EXPECT().SendMessage(i18n.T(i18n.RU, i18n.MissingRate), int64(1))
+
+	err := w.worker.Handle(context.Background(), queue.Message{
+		Value: []byte(`{"user_id":1,"period":"week","currency":"EUR","language":"ru"}`),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestReportWorker_RejectsMalformedRequest(t *testing.T) {
+	w := newTestReportWorker(t)
+
+	err := w.worker.Handle(context.Background(), queue.Message{Value: []byte("not json")})
+
+	assert.Error(t, err)
+}
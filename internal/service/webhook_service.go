@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/netguard"
+	"go.uber.org/zap"
+)
+
+type WebhookStore interface {
+	AddWebhook(ctx context.Context, webhook model.Webhook) (int64, error)
+	GetUserWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID int64) error
+	GetUserDeliveries(ctx context.Context, userID, webhookID int64, limit int) ([]model.WebhookDelivery, error)
+}
+
+// webhookSecretPrefix makes secrets recognizable e.g. by secret scanners
+const webhookSecretPrefix = "whsec_"
+
+const (
+	webhookSecretBytes   = 32
+	maxWebhooksPerUser   = 5
+	webhookDeliveryLimit = 10
+)
+
+// webhookService registers webhooks of users, events are posted to them by sinks.UserWebhooks
+type webhookService struct {
+	webhookRepo WebhookStore
+}
+
+func NewWebhookService(webhookRepo WebhookStore) *webhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+	}
+}
+
+// AddWebhook registers public HTTPS url receiving events of given types, all events are posted when types are omitted.
+// Returned webhook holds secret signing its payloads, it is shown to user once
+func (s *webhookService) AddWebhook(ctx context.Context, userID int64, rawURL string, eventTypes []string) (model.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "AddWebhook")
+	defer span.Finish()
+
+	webhookURL, err := parseWebhookURL(rawURL)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	types, err := parseEventTypes(eventTypes)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	webhooks, err := s.webhookRepo.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return model.Webhook{}, err
+	}
+	if len(webhooks) >= maxWebhooksPerUser {
+		return model.Webhook{}, constants.TooManyWebhooksErr
+	}
+
+	raw := make([]byte, webhookSecretBytes)
+	if _, err = rand.Read(raw); err != nil {
+		span.SetTag("error", err.Error())
+		logger.Error("cannot generate webhook secret", zap.Int64("userID", userID), zap.Error(err))
+		return model.Webhook{}, errors.Wrap(err, "cannot generate webhook secret")
+	}
+	webhook := model.Webhook{
+		UserID:     userID,
+		URL:        webhookURL,
+		Secret:     webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(raw),
+		EventTypes: types,
+	}
+	if webhook.ID, err = s.webhookRepo.AddWebhook(ctx, webhook); err != nil {
+		span.SetTag("error", err.Error())
+		return model.Webhook{}, errors.Wrap(err, "cannot store webhook")
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error) {
+	return s.webhookRepo.GetUserWebhooks(ctx, userID)
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	return s.webhookRepo.DeleteWebhook(ctx, userID, webhookID)
+}
+
+// GetDeliveries returns the latest deliveries to webhooks of user, zero webhookID means all webhooks
+func (s *webhookService) GetDeliveries(ctx context.Context, userID, webhookID int64) ([]model.WebhookDelivery, error) {
+	return s.webhookRepo.GetUserDeliveries(ctx, userID, webhookID, webhookDeliveryLimit)
+}
+
+// parseWebhookURL accepts https urls of public hosts, hosts resolved into internal network are refused on delivery
+func parseWebhookURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return "", errors.Wrap(constants.InvalidWebhookURLErr, rawURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") ||
+		strings.HasSuffix(host, ".internal") {
+		return "", errors.Wrap(constants.InvalidWebhookURLErr, rawURL)
+	}
+	if ip := net.ParseIP(host); ip != nil && !netguard.IsPublic(ip) {
+		return "", errors.Wrap(constants.InvalidWebhookURLErr, rawURL)
+	}
+	return u.String(), nil
+}
+
+// parseEventTypes resolves case-insensitive names of events into model.EventTypes
+func parseEventTypes(names []string) ([]string, error) {
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		eventType, ok := lo.Find(model.EventTypes, func(t string) bool { return strings.EqualFold(t, name) })
+		if !ok {
+			return nil, errors.Wrap(constants.UnknownEventTypeErr, name)
+		}
+		if !lo.Contains(types, eventType) {
+			types = append(types, eventType)
+		}
+	}
+	return types, nil
+}
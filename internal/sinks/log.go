@@ -0,0 +1,32 @@
+package sinks
+
+import (
+	"context"
+
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"go.uber.org/zap"
+)
+
+// LogName is name of sink writing events to application log
+const LogName = "log"
+
+// Log writes events to application log, it is useful for debugging of other sinks
+type Log struct{}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) Name() string {
+	return LogName
+}
+
+func (l *Log) Deliver(_ context.Context, event model.Event) error {
+	logger.Info("domain event",
+		zap.String("type", event.Type),
+		zap.String("idempotencyKey", event.IdempotencyKey),
+		zap.Int64("userID", event.UserID),
+		zap.ByteString("payload", event.Payload))
+	return nil
+}
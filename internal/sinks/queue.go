@@ -0,0 +1,51 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/queue"
+)
+
+// QueueName is name of sink publishing events to message queue
+const QueueName = "queue"
+
+// Publisher sends message to topic of message queue
+type Publisher interface {
+	Publish(ctx context.Context, topic string, msg queue.Message) error
+}
+
+// Queue publishes events keyed by user, so that events of one user get to the same partition
+type Queue struct {
+	publisher Publisher
+	topic     string
+}
+
+func NewQueue(publisher Publisher, topic string) *Queue {
+	return &Queue{
+		publisher: publisher,
+		topic:     topic,
+	}
+}
+
+func (q *Queue) Name() string {
+	return QueueName
+}
+
+func (q *Queue) Deliver(ctx context.Context, event model.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode event")
+	}
+	return q.publisher.Publish(ctx, q.topic, queue.Message{
+		Key:   strconv.FormatInt(event.UserID, 10),
+		Value: value,
+		Headers: map[string]string{
+			"idempotency-key": event.IdempotencyKey,
+			"event-type":      event.Type,
+		},
+	})
+}
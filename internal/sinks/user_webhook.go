@@ -0,0 +1,276 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/netguard"
+	"go.uber.org/zap"
+)
+
+// UserWebhookName is name of sink posting events to webhooks registered by users
+const UserWebhookName = "user_webhook"
+
+const (
+	// MaxUserWebhookAttempts is the number of deliveries of event to webhook before it is given up
+	MaxUserWebhookAttempts = 8
+	maxDeliveryErrorLength = 200
+	userWebhookBatchSize   = 32
+	userWebhookMaxDelay    = time.Hour
+	userWebhookPurgePeriod = time.Hour
+)
+
+type UserWebhookStore interface {
+	GetUserWebhooks(ctx context.Context, userID int64) ([]model.Webhook, error)
+	EnqueueTasks(ctx context.Context, tasks []model.WebhookTask) error
+	ClaimTasks(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookTask, error)
+	FinishTask(ctx context.Context, taskID int64) error
+	RetryTask(ctx context.Context, taskID int64, delay time.Duration) error
+	PurgeFinishedTasks(ctx context.Context, before time.Time) (int64, error)
+	AddDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+}
+
+type UserWebhookConfig interface {
+	OutboxPollInterval() time.Duration
+	OutboxRetention() time.Duration
+	OutboxWebhookTimeout() time.Duration
+}
+
+// UserWebhooks queues events of user for webhooks of this user subscribed to them and posts queued events
+// in Run, every webhook is retried on its own, so that failing or slow webhooks delay neither other webhooks
+// nor other sinks of outbox
+type UserWebhooks struct {
+	store     UserWebhookStore
+	config    UserWebhookConfig
+	http      *http.Client
+	lease     time.Duration
+	lastPurge time.Time
+}
+
+// NewUserWebhooks creates sink which connects to public addresses only and does not follow redirects,
+// so that users cannot reach internal network with their urls
+func NewUserWebhooks(config UserWebhookConfig, store UserWebhookStore) *UserWebhooks {
+	timeout := config.OutboxWebhookTimeout()
+	dialer := &net.Dialer{Timeout: timeout, Control: netguard.Control}
+	return &UserWebhooks{
+		store:  store,
+		config: config,
+		http: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		// tasks of batch are posted concurrently, so batch takes about one timeout
+		lease: 2*timeout + time.Minute,
+	}
+}
+
+func (w *UserWebhooks) Name() string {
+	return UserWebhookName
+}
+
+// Deliver queues event for every subscribed webhook, it fails only when the queue cannot be written
+func (w *UserWebhooks) Deliver(ctx context.Context, event model.Event) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "EnqueueUserWebhooks")
+	defer span.Finish()
+
+	webhooks, err := w.store.GetUserWebhooks(ctx, event.UserID)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot get webhooks of user")
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode event")
+	}
+	tasks := make([]model.WebhookTask, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.Subscribed(event.Type) {
+			tasks = append(tasks, model.WebhookTask{
+				Webhook:        webhook,
+				IdempotencyKey: event.IdempotencyKey,
+				EventType:      event.Type,
+				Body:           body,
+			})
+		}
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+	if err = w.store.EnqueueTasks(ctx, tasks); err != nil {
+		span.SetTag("error", err.Error())
+		return errors.Wrap(err, "cannot enqueue webhook tasks")
+	}
+	return nil
+}
+
+// Run posts queued events until ctx is done
+func (w *UserWebhooks) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.OutboxPollInterval())
+	defer ticker.Stop()
+	for {
+		w.dispatchAll(ctx)
+		w.purge(ctx)
+		select {
+		case <-ctx.Done():
+			logger.Info("user webhooks dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchAll posts batches while they are full, full batch means there are more due tasks
+func (w *UserWebhooks) dispatchAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		if w.dispatch(ctx) < userWebhookBatchSize {
+			return
+		}
+	}
+}
+
+// dispatch posts one batch of due tasks concurrently and returns its size
+func (w *UserWebhooks) dispatch(ctx context.Context) int {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "DispatchUserWebhooks")
+	defer span.Finish()
+
+	tasks, err := w.store.ClaimTasks(ctx, userWebhookBatchSize, w.lease)
+	if err != nil {
+		span.SetTag("error", err.Error())
+		return 0
+	}
+	span.SetTag("tasks", len(tasks))
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task model.WebhookTask) {
+			defer wg.Done()
+			w.attempt(ctx, task)
+		}(task)
+	}
+	wg.Wait()
+	return len(tasks)
+}
+
+// attempt posts task once and logs the attempt, task is finished when accepted or given up and retried otherwise
+func (w *UserWebhooks) attempt(ctx context.Context, task model.WebhookTask) {
+	delivery := w.post(ctx, task)
+	if ctx.Err() != nil { // shutdown is not an attempt, task is retried when lease expires
+		return
+	}
+	delivery.Attempt = task.Attempts + 1
+	if err := w.store.AddDelivery(ctx, delivery); err != nil {
+		logger.Error("cannot log webhook delivery", zap.Int64("taskID", task.ID), zap.Error(err))
+	}
+
+	var err error
+	switch {
+	case delivery.Delivered:
+		err = w.store.FinishTask(ctx, task.ID)
+	case delivery.Attempt >= MaxUserWebhookAttempts:
+		logger.Warn("webhook is given up",
+			zap.Int64("webhookID", task.Webhook.ID),
+			zap.String("idempotencyKey", task.IdempotencyKey),
+			zap.String("reason", delivery.Error))
+		err = w.store.FinishTask(ctx, task.ID)
+	default:
+		err = w.store.RetryTask(ctx, task.ID, w.retryDelay(task.Attempts))
+	}
+	if err != nil {
+		logger.Error("cannot update webhook task", zap.Int64("taskID", task.ID), zap.Error(err))
+	}
+}
+
+// retryDelay grows exponentially from poll interval up to userWebhookMaxDelay
+func (w *UserWebhooks) retryDelay(attempts int) time.Duration {
+	delay := w.config.OutboxPollInterval() << attempts
+	if delay <= 0 || delay > userWebhookMaxDelay || attempts > 32 {
+		return userWebhookMaxDelay
+	}
+	return delay
+}
+
+// purge deletes tasks finished longer than retention ago, at most once in userWebhookPurgePeriod
+func (w *UserWebhooks) purge(ctx context.Context) {
+	now := time.Now()
+	if now.Sub(w.lastPurge) < userWebhookPurgePeriod {
+		return
+	}
+	w.lastPurge = now
+	purged, err := w.store.PurgeFinishedTasks(ctx, now.Add(-w.config.OutboxRetention()))
+	if err == nil && purged > 0 {
+		logger.Info("finished webhook tasks purged", zap.Int64("purged", purged))
+	}
+}
+
+// post sends event signed with secret of webhook, result is returned as delivery to log
+func (w *UserWebhooks) post(ctx context.Context, task model.WebhookTask) model.WebhookDelivery {
+	delivery := model.WebhookDelivery{
+		WebhookID:      task.Webhook.ID,
+		IdempotencyKey: task.IdempotencyKey,
+		EventType:      task.EventType,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.Webhook.URL, bytes.NewReader(task.Body))
+	if err != nil {
+		delivery.Error = truncate(err.Error())
+		return delivery
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", task.IdempotencyKey)
+	req.Header.Set("X-Event-Type", task.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", Sign(task.Webhook.Secret, timestamp, task.Body))
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		delivery.Error = truncate(err.Error())
+		return delivery
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	delivery.StatusCode = resp.StatusCode
+	delivery.Delivered = resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+	if !delivery.Delivered {
+		delivery.Error = fmt.Sprintf("webhook responded with status %d", resp.StatusCode)
+	}
+	return delivery
+}
+
+// Sign returns value of X-Webhook-Signature header: HMAC-SHA256 of timestamp and body joined by dot,
+// receivers compute it with their secret and reject requests with stale timestamp to prevent replays
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func truncate(message string) string {
+	runes := []rune(message)
+	if len(runes) <= maxDeliveryErrorLength {
+		return message
+	}
+	return string(runes[:maxDeliveryErrorLength]) + "…"
+}
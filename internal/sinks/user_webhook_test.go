@@ -0,0 +1,173 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sinksMocks "gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/mocks/sinks"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+var limitExceeded = model.Event{
+	IdempotencyKey: "0b6f3c1e-2f7a-4c55-9d55-0e0e4c9b9a10",
+	Type:           model.LimitExceededEvent,
+	UserID:         42,
+	Payload:        json.RawMessage(`{"category_id":"FOOD"}`),
+	CreatedAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+}
+
+type userWebhookTestConfig struct{}
+
+func (userWebhookTestConfig) OutboxPollInterval() time.Duration   { return time.Second }
+func (userWebhookTestConfig) OutboxRetention() time.Duration      { return time.Hour }
+func (userWebhookTestConfig) OutboxWebhookTimeout() time.Duration { return time.Second }
+
+func newTask(t *testing.T, id int64, webhook model.Webhook, attempts int) model.WebhookTask {
+	body, err := json.Marshal(limitExceeded)
+	require.NoError(t, err)
+	return model.WebhookTask{
+		ID:             id,
+		Webhook:        webhook,
+		IdempotencyKey: limitExceeded.IdempotencyKey,
+		EventType:      limitExceeded.Type,
+		Body:           body,
+		Attempts:       attempts,
+	}
+}
+
+func TestUserWebhooks_DeliverQueuesEventForSubscribedWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := sinksMocks.NewMockUserWebhookStore(ctrl)
+	webhooks := []model.Webhook{
+		{ID: 1, URL: "https://example.com/1", Secret: "whsec_1"},
+		{ID: 2, URL: "https://example.com/2", Secret: "whsec_2", EventTypes: []string{model.TransactionCreatedEvent}},
+		{ID: 3, URL: "https://example.com/3", Secret: "whsec_3", EventTypes: []string{model.LimitExceededEvent}},
+	}
+
+	store.EXPECT().GetUserWebhooks(gomock.Any(), int64(42)).Return(webhooks, nil)
+	store.EXPECT().EnqueueTasks(gomock.Any(), []model.WebhookTask{
+		newTask(t, 0, webhooks[0], 0),
+		newTask(t, 0, webhooks[2], 0),
+	})
+
+	err := NewUserWebhooks(userWebhookTestConfig{}, store).Deliver(context.Background(), limitExceeded)
+
+	assert.NoError(t, err)
+}
+
+func TestUserWebhooks_DeliverSkipsUnsubscribedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := sinksMocks.NewMockUserWebhookStore(ctrl)
+	store.EXPECT().GetUserWebhooks(gomock.Any(), int64(42)).Return([]model.Webhook{
+		{ID: 2, EventTypes: []string{model.TransactionCreatedEvent}},
+	}, nil)
+
+	err := NewUserWebhooks(userWebhookTestConfig{}, store).Deliver(context.Background(), limitExceeded)
+
+	assert.NoError(t, err)
+}
+
+func TestUserWebhooks_DispatchPostsSignedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := sinksMocks.NewMockUserWebhookStore(ctrl)
+
+	var signature, timestamp string
+	var body []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature, timestamp = r.Header.Get("X-Webhook-Signature"), r.Header.Get("X-Webhook-Timestamp")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store.EXPECT().ClaimTasks(gomock.Any(), userWebhookBatchSize, gomock.Any()).Return([]model.WebhookTask{
+		newTask(t, 10, model.Webhook{ID: 1, URL: server.URL, Secret: "whsec_1"}, 0),
+	}, nil)
+	store.EXPECT().AddDelivery(gomock.Any(), model.WebhookDelivery{
+		WebhookID:      1,
+		IdempotencyKey: limitExceeded.IdempotencyKey,
+		EventType:      model.LimitExceededEvent,
+		Attempt:        1,
+		StatusCode:     http.StatusNoContent,
+		Delivered:      true,
+	})
+	store.EXPECT().FinishTask(gomock.Any(), int64(10))
+
+	sink := NewUserWebhooks(userWebhookTestConfig{}, store)
+	sink.http = server.Client()
+	assert.Equal(t, 1, sink.dispatch(context.Background()))
+
+	assert.Equal(t, Sign("whsec_1", timestamp, body), signature)
+	var received model.Event
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, limitExceeded, received)
+}
+
+func TestUserWebhooks_DispatchRetriesFailedWebhookOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := sinksMocks.NewMockUserWebhookStore(ctrl)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store.EXPECT().ClaimTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.WebhookTask{
+		newTask(t, 10, model.Webhook{ID: 1, URL: server.URL + "/failing"}, 2),
+		newTask(t, 11, model.Webhook{ID: 2, URL: server.URL + "/ok"}, 0),
+		newTask(t, 12, model.Webhook{ID: 3, URL: server.URL + "/failing"}, MaxUserWebhookAttempts-1),
+	}, nil)
+	store.EXPECT().AddDelivery(gomock.Any(), gomock.Any()).Do(func(_ context.Context, d model.WebhookDelivery) {
+		switch d.WebhookID {
+		case 1:
+			assert.Equal(t, 3, d.Attempt)
+			assert.Equal(t, http.StatusServiceUnavailable, d.StatusCode)
+			assert.Equal(t, "webhook responded with status 503", d.Error)
+		case 2:
+			assert.True(t, d.Delivered)
+		case 3:
+			assert.Equal(t, MaxUserWebhookAttempts, d.Attempt)
+		}
+	}).Times(3)
+	store.EXPECT().RetryTask(gomock.Any(), int64(10), 4*time.Second)
+	store.EXPECT().FinishTask(gomock.Any(), int64(11))
+	store.EXPECT().FinishTask(gomock.Any(), int64(12)) // given up
+
+	sink := NewUserWebhooks(userWebhookTestConfig{}, store)
+	sink.http = server.Client()
+	assert.Equal(t, 3, sink.dispatch(context.Background()))
+}
+
+func TestUserWebhooks_RefusesInternalAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := sinksMocks.NewMockUserWebhookStore(ctrl)
+	requested := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	store.EXPECT().ClaimTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.WebhookTask{
+		newTask(t, 10, model.Webhook{ID: 1, URL: server.URL}, 0),
+	}, nil)
+	store.EXPECT().AddDelivery(gomock.Any(), gomock.Any()).Do(func(_ context.Context, d model.WebhookDelivery) {
+		assert.False(t, d.Delivered)
+		assert.Contains(t, d.Error, "non-public address")
+	})
+	store.EXPECT().RetryTask(gomock.Any(), int64(10), time.Second)
+
+	NewUserWebhooks(userWebhookTestConfig{}, store).dispatch(context.Background())
+
+	assert.False(t, requested)
+}
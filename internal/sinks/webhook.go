@@ -0,0 +1,58 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// WebhookName is name of sink posting events to HTTP endpoint
+const WebhookName = "webhook"
+
+// Webhook posts every event as JSON to configured url, event is accepted by any 2xx status
+type Webhook struct {
+	url  string
+	http *http.Client
+}
+
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		url:  url,
+		http: &http.Client{Timeout: timeout},
+	}
+}
+
+func (w *Webhook) Name() string {
+	return WebhookName
+}
+
+func (w *Webhook) Deliver(ctx context.Context, event model.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.IdempotencyKey)
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot post event")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
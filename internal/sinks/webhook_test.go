@@ -0,0 +1,50 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestWebhook_PostsEventWithIdempotencyKey(t *testing.T) {
+	var received model.Event
+	var key, eventType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, eventType = r.Header.Get("Idempotency-Key"), r.Header.Get("X-Event-Type")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	event := model.Event{
+		IdempotencyKey: "0b6f3c1e-2f7a-4c55-9d55-0e0e4c9b9a10",
+		Type:           model.CurrencyChangedEvent,
+		UserID:         42,
+		Payload:        json.RawMessage(`{"currency":"USD"}`),
+		CreatedAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+	err := NewWebhook(server.URL, time.Second).Deliver(context.Background(), event)
+
+	require.NoError(t, err)
+	assert.Equal(t, event.IdempotencyKey, key)
+	assert.Equal(t, model.CurrencyChangedEvent, eventType)
+	assert.Equal(t, event, received)
+}
+
+func TestWebhook_FailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, time.Second).Deliver(context.Background(), model.Event{Type: model.LimitSetEvent})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier passes span context in gRPC metadata, keys of metadata are lowercase
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Set(key, val string) {
+	key = strings.ToLower(key)
+	c[key] = append(c[key], val)
+}
+
+func (c metadataCarrier) ForeachKey(handler func(key, val string) error) error {
+	for key, values := range c {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnaryServerInterceptor continues trace of caller with span "grpc:<method>" which spans of services
+// and repositories are children of
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		tracer := opentracing.GlobalTracer()
+		md, _ := metadata.FromIncomingContext(ctx)
+		parent, _ := tracer.Extract(opentracing.TextMap, metadataCarrier(md.Copy()))
+
+		span := tracer.StartSpan("grpc:"+methodName(info.FullMethod), ext.RPCServerOption(parent))
+		defer span.Finish()
+		span.SetTag("method", info.FullMethod)
+
+		resp, err := handler(opentracing.ContextWithSpan(ctx, span), req)
+		if err != nil {
+			span.SetTag("error", err.Error())
+		}
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor starts client span and passes its context to server in metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		span, ctx := opentracing.StartSpanFromContext(ctx, "grpc:"+methodName(method), ext.SpanKindRPCClient)
+		defer span.Finish()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		if err := span.Tracer().Inject(span.Context(), opentracing.TextMap, metadataCarrier(md)); err != nil {
+			span.SetTag("inject_error", err.Error())
+		}
+		err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+		if err != nil {
+			span.SetTag("error", err.Error())
+		}
+		return err
+	}
+}
+
+// methodName trims service from full method, e.g. "/financial_bot.v1.FinancialBot/GetReport"
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
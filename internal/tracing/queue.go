@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// InjectHeaders starts producer span "publish:<topic>" and puts its context into message headers
+func InjectHeaders(ctx context.Context, topic string, headers map[string]string) opentracing.Span {
+	span, _ := opentracing.StartSpanFromContext(ctx, "publish:"+topic, ext.SpanKindProducer)
+	if err := span.Tracer().Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(headers)); err != nil {
+		span.SetTag("inject_error", err.Error())
+	}
+	return span
+}
+
+// StartConsumerSpan starts span "consume:<topic>" which follows producer span passed in message headers,
+// message is handled after producer is done so the span is not its child
+func StartConsumerSpan(ctx context.Context, topic string, headers map[string]string) (opentracing.Span, context.Context) {
+	tracer := opentracing.GlobalTracer()
+	opts := []opentracing.StartSpanOption{ext.SpanKindConsumer}
+	if producer, err := tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier(headers)); err == nil {
+		opts = append(opts, opentracing.FollowsFrom(producer))
+	}
+	span := tracer.StartSpan("consume:"+topic, opts...)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
@@ -0,0 +1,215 @@
+package arithmetic
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	DivisionByZeroErr = errors.New("division by zero")
+	InvalidSyntaxErr  = errors.New("invalid expression")
+)
+
+// operators which users type or press on keypad, mapped to canonical ascii form
+var replacer = strings.NewReplacer(
+	" ", "",
+	"×", "*",
+	"x", "*",
+	"X", "*",
+	"÷", "/",
+	":", "/",
+	"−", "-",
+	"–", "-",
+)
+
+// Normalize brings typed expression to canonical ascii form which is stored and evaluated. Separator is decided
+// for every number: single comma is decimal point, commas before dot separate thousands as in "1,234.50".
+// Other commas are kept, so that ambiguous numbers like "1.234,5" are rejected by Evaluate
+func Normalize(expr string) string {
+	expr = replacer.Replace(strings.TrimSpace(expr))
+	var b strings.Builder
+	start := -1
+	for i := 0; i <= len(expr); i++ {
+		if i < len(expr) && isNumberChar(expr[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			b.WriteString(normalizeNumber(expr[start:i]))
+			start = -1
+		}
+		if i < len(expr) {
+			b.WriteByte(expr[i])
+		}
+	}
+	return b.String()
+}
+
+func isNumberChar(c byte) bool {
+	return c >= '0' && c <= '9' || c == '.' || c == ','
+}
+
+func normalizeNumber(number string) string {
+	commas := strings.Count(number, ",")
+	if commas == 0 {
+		return number
+	}
+	integer := number
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		integer = number[:dot]
+	} else if commas == 1 {
+		return strings.Replace(number, ",", ".", 1)
+	}
+	if strings.Count(integer, ",") != commas || !groupedByThousands(integer) {
+		return number
+	}
+	return strings.ReplaceAll(number, ",", "")
+}
+
+// groupedByThousands reports whether commas split number into groups of three digits after the first one
+func groupedByThousands(integer string) bool {
+	groups := strings.Split(integer, ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// Pretty renders canonical expression with typographic operators for messages
+func Pretty(expr string) string {
+	return strings.NewReplacer("*", "×", "/", "÷", "-", "−").Replace(expr)
+}
+
+// IsExpression reports whether canonical input contains operations rather than single number
+func IsExpression(expr string) bool {
+	return strings.ContainsAny(expr, "+-*/()")
+}
+
+// Evaluate calculates expression with + - * / and parentheses using decimal arithmetic,
+// result is rounded to precision of the currency, e.g. to cents for fiat
+func Evaluate(expr string, places int32) (decimal.Decimal, error) {
+	p := &parser{input: Normalize(expr)}
+	if p.input == "" {
+		return decimal.Zero, InvalidSyntaxErr
+	}
+	result, err := p.parseExpression()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if p.pos != len(p.input) {
+		return decimal.Zero, InvalidSyntaxErr
+	}
+	return result.Round(places), nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// expression := term (('+' | '-') term)*
+func (p *parser) parseExpression() (decimal.Decimal, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if op == '+' {
+			left = left.Add(right)
+		} else {
+			left = left.Sub(right)
+		}
+	}
+	return left, nil
+}
+
+// term := factor (('*' | '/') factor)*
+func (p *parser) parseTerm() (decimal.Decimal, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if op == '*' {
+			left = left.Mul(right)
+			continue
+		}
+		if right.IsZero() {
+			return decimal.Zero, DivisionByZeroErr
+		}
+		left = left.Div(right)
+	}
+	return left, nil
+}
+
+// factor := '-' factor | '(' expression ')' | number
+func (p *parser) parseFactor() (decimal.Decimal, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		value, err := p.parseFactor()
+		return value.Neg(), err
+	case '(':
+		p.pos++
+		value, err := p.parseExpression()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if p.peek() != ')' {
+			return decimal.Zero, InvalidSyntaxErr
+		}
+		p.pos++
+		return value, nil
+	}
+	return p.parseNumber()
+}
+
+func (p *parser) parseNumber() (decimal.Decimal, error) {
+	start := p.pos
+	seenPoint := false
+	for ; p.pos < len(p.input); p.pos++ {
+		c := p.input[p.pos]
+		if c == '.' && !seenPoint {
+			seenPoint = true
+			continue
+		}
+		if c < '0' || c > '9' {
+			break
+		}
+	}
+	number := strings.TrimSuffix(p.input[start:p.pos], ".")
+	if number == "" {
+		return decimal.Zero, InvalidSyntaxErr
+	}
+	value, err := decimal.NewFromString(number)
+	if err != nil {
+		return decimal.Zero, InvalidSyntaxErr
+	}
+	return value, nil
+}
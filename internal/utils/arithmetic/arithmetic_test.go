@@ -0,0 +1,67 @@
+package arithmetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr error
+	}{
+		{expr: "1500", want: "1500"},
+		{expr: "1200/3", want: "400"},
+		{expr: "450+320+99", want: "869"},
+		{expr: "100 ÷ 3", want: "33.33"},
+		{expr: "2 × (10 − 2.5)", want: "15"},
+		{expr: "12,5*2", want: "25"},
+		{expr: "1234,50", want: "1234.5"},
+		{expr: "1,234.50", want: "1234.5"},
+		{expr: "1,234,567.5+0.5", want: "1234568"},
+		{expr: "1,5+2.5", want: "4"},
+		{expr: "2.5+1,5", want: "4"},
+		{expr: "1,234.5+1,5", want: "1236"},
+		{expr: "1,234,567", want: "1234567"},
+		{expr: "1.234,5", wantErr: InvalidSyntaxErr},
+		{expr: "12,34.5", wantErr: InvalidSyntaxErr},
+		{expr: "1,5,5", wantErr: InvalidSyntaxErr},
+		{expr: "10-2*3", want: "4"},
+		{expr: "(1+2)*(3+4)", want: "21"},
+		{expr: "-5+10", want: "5"},
+		{expr: "12.", want: "12"},
+		{expr: "5/0", wantErr: DivisionByZeroErr},
+		{expr: "5/(2-2)", wantErr: DivisionByZeroErr},
+		{expr: "", wantErr: InvalidSyntaxErr},
+		{expr: "1+", wantErr: InvalidSyntaxErr},
+		{expr: "(1+2", wantErr: InvalidSyntaxErr},
+		{expr: "1+2)", wantErr: InvalidSyntaxErr},
+		{expr: "1..2", wantErr: InvalidSyntaxErr},
+		{expr: "abc", wantErr: InvalidSyntaxErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Evaluate(tt.expr, 2)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestIsExpression(t *testing.T) {
+	assert.False(t, IsExpression(Normalize("1 500,50")))
+	assert.True(t, IsExpression(Normalize("1200÷3")))
+	assert.Equal(t, "1200÷3", Pretty("1200/3"))
+}
+
+func TestEvaluate_Precision(t *testing.T) {
+	got, err := Evaluate("0.001/3", 8)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00033333", got.String())
+}
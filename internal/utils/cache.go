@@ -5,9 +5,10 @@ import (
 	"time"
 )
 
-func GetCalcCacheKey(userID int64, currency string, days int64) string {
+// GetCalcCacheKey builds key of cached report, generation changes whenever user's data changes
+func GetCalcCacheKey(userID int64, generation string, currency string, days int64) string {
 	date := time.Now().Format(nowDateFormat)
-	return fmt.Sprintf("CALC_%s_%d_%s_%d", date, userID, currency, days)
+	return fmt.Sprintf("CALC_%s_%d_%s_%s_%d", date, userID, generation, currency, days)
 }
 
 var nowDateFormat = "2006-01-02"
@@ -0,0 +1,8 @@
+package utils
+
+import "time"
+
+// EndOfMonth returns the last day of month of the date, limits are set until it
+func EndOfMonth(date time.Time) time.Time {
+	return date.AddDate(0, 1, -date.Day())
+}
@@ -2,17 +2,19 @@ package expenses
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
 	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/utils/money"
 	"sort"
 )
 
-func Format(result map[string]decimal.Decimal, categoriesMap map[string]model.CategoryData, period, currency string) string {
+func Format(lang i18n.Lang, result map[string]decimal.Decimal, categoriesMap map[string]model.CategoryData, period string,
+	currency model.CurrencyData) string {
 	var formatted bytes.Buffer
-	formatted.WriteString(fmt.Sprintf("Расходы за период '%s':\n\n", period))
+	formatted.WriteString(i18n.T(lang, i18n.ReportHeader, i18n.Period(lang, period)))
 	if len(result) == 0 {
-		formatted.WriteString("Нет трат")
+		formatted.WriteString(i18n.T(lang, i18n.NoExpenses))
 		return formatted.String()
 	}
 	categoriesList := make([]string, 0)
@@ -26,8 +28,7 @@ func Format(result map[string]decimal.Decimal, categoriesMap map[string]model.Ca
 	for _, categoryID := range categoriesList {
 		formatted.WriteString(categoriesMap[categoryID].Name)
 		formatted.WriteString(": ")
-		formatted.WriteString(result[categoryID].Round(2).String())
-		formatted.WriteString(" " + currency)
+		formatted.WriteString(money.Format(lang, result[categoryID], currency))
 		formatted.WriteRune('\n')
 		formatted.WriteRune('\n')
 	}
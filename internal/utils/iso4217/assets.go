@@ -0,0 +1,26 @@
+package iso4217
+
+import "strings"
+
+// assets are crypto currencies and precious metals, their rates are loaded from asset provider,
+// metals are measured in troy ounces as ISO 4217 defines for XAU and XAG
+var assets = map[string]Currency{
+	"BTC":  {Code: "BTC", Name: "биткоин", Symbol: "₿", Kind: Crypto, Precision: 8},
+	"ETH":  {Code: "ETH", Name: "эфир", Symbol: "Ξ", Kind: Crypto, Precision: 8},
+	"TON":  {Code: "TON", Name: "тон", Symbol: "TON", Kind: Crypto, Precision: 4},
+	"USDT": {Code: "USDT", Name: "тезер", Symbol: "USDT", Kind: Crypto, Precision: 2},
+	"USDC": {Code: "USDC", Name: "USD coin", Symbol: "USDC", Kind: Crypto, Precision: 2},
+	"XAU":  {Code: "XAU", Name: "золото, унц.", Symbol: "XAU", Kind: Metal, Precision: 4},
+	"XAG":  {Code: "XAG", Name: "серебро, унц.", Symbol: "XAG", Kind: Metal, Precision: 4},
+}
+
+// IsAsset reports whether code is crypto currency or precious metal rather than fiat money
+func IsAsset(code string) bool {
+	_, ok := assets[strings.ToUpper(code)]
+	return ok
+}
+
+// ValidAssetCode checks that code is either ISO 4217 code or known asset code, e.g. USDT
+func ValidAssetCode(code string) bool {
+	return ValidCode(code) || IsAsset(code)
+}
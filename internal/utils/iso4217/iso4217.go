@@ -0,0 +1,96 @@
+package iso4217
+
+import "strings"
+
+// DefaultPrecision is number of fractional digits of fiat currencies
+const DefaultPrecision = 2
+
+// Kind tells fiat money from assets which are tracked like currencies
+type Kind int
+
+const (
+	Fiat Kind = iota
+	Crypto
+	Metal
+)
+
+// Currency describes ISO 4217 currency or asset as it is stored in currency table
+type Currency struct {
+	Code      string
+	Name      string
+	Symbol    string
+	Kind      Kind
+	Precision int32
+}
+
+// catalog contains widely traded currencies, other codes must be enabled with explicit symbol
+var catalog = map[string]Currency{
+	"AED": {Code: "AED", Name: "дирх.", Symbol: "د.إ"},
+	"AMD": {Code: "AMD", Name: "драм", Symbol: "֏"},
+	"AUD": {Code: "AUD", Name: "австр. долл.", Symbol: "A$"},
+	"AZN": {Code: "AZN", Name: "манат", Symbol: "₼"},
+	"BRL": {Code: "BRL", Name: "реал", Symbol: "R$"},
+	"BYN": {Code: "BYN", Name: "бел. руб.", Symbol: "Br"},
+	"CAD": {Code: "CAD", Name: "канад. долл.", Symbol: "C$"},
+	"CHF": {Code: "CHF", Name: "франк", Symbol: "₣"},
+	"CNY": {Code: "CNY", Name: "юан.", Symbol: "¥"},
+	"CZK": {Code: "CZK", Name: "чеш. крона", Symbol: "Kč"},
+	"DKK": {Code: "DKK", Name: "дат. крона", Symbol: "kr"},
+	"EGP": {Code: "EGP", Name: "егип. фунт", Symbol: "E£"},
+	"EUR": {Code: "EUR", Name: "евро", Symbol: "€"},
+	"GBP": {Code: "GBP", Name: "фунт", Symbol: "£"},
+	"GEL": {Code: "GEL", Name: "лари", Symbol: "₾"},
+	"HKD": {Code: "HKD", Name: "гонконг. долл.", Symbol: "HK$"},
+	"HUF": {Code: "HUF", Name: "форинт", Symbol: "Ft"},
+	"IDR": {Code: "IDR", Name: "рупия", Symbol: "Rp"},
+	"ILS": {Code: "ILS", Name: "шекель", Symbol: "₪"},
+	"INR": {Code: "INR", Name: "инд. рупия", Symbol: "₹"},
+	"JPY": {Code: "JPY", Name: "иена", Symbol: "¥"},
+	"KGS": {Code: "KGS", Name: "сом", Symbol: "с"},
+	"KRW": {Code: "KRW", Name: "вона", Symbol: "₩"},
+	"KZT": {Code: "KZT", Name: "тенге", Symbol: "₸"},
+	"MDL": {Code: "MDL", Name: "молд. лей", Symbol: "L"},
+	"MXN": {Code: "MXN", Name: "мекс. песо", Symbol: "Mex$"},
+	"NOK": {Code: "NOK", Name: "норв. крона", Symbol: "kr"},
+	"NZD": {Code: "NZD", Name: "новозел. долл.", Symbol: "NZ$"},
+	"PLN": {Code: "PLN", Name: "злотый", Symbol: "zł"},
+	"RON": {Code: "RON", Name: "рум. лей", Symbol: "lei"},
+	"RSD": {Code: "RSD", Name: "динар", Symbol: "дин."},
+	"RUB": {Code: "RUB", Name: "руб.", Symbol: "₽"},
+	"SEK": {Code: "SEK", Name: "швед. крона", Symbol: "kr"},
+	"SGD": {Code: "SGD", Name: "сингап. долл.", Symbol: "S$"},
+	"THB": {Code: "THB", Name: "бат", Symbol: "฿"},
+	"TJS": {Code: "TJS", Name: "сомони", Symbol: "SM"},
+	"TRY": {Code: "TRY", Name: "лира", Symbol: "₺"},
+	"UAH": {Code: "UAH", Name: "гривна", Symbol: "₴"},
+	"USD": {Code: "USD", Name: "долл.", Symbol: "$"},
+	"UZS": {Code: "UZS", Name: "сум", Symbol: "soʻm"},
+	"VND": {Code: "VND", Name: "донг", Symbol: "₫"},
+	"ZAR": {Code: "ZAR", Name: "рэнд", Symbol: "R"},
+}
+
+// Lookup returns known currency or asset by case-insensitive code
+func Lookup(code string) (Currency, bool) {
+	code = strings.ToUpper(code)
+	if c, ok := assets[code]; ok {
+		return c, true
+	}
+	c, ok := catalog[code]
+	if ok {
+		c.Precision = DefaultPrecision
+	}
+	return c, ok
+}
+
+// ValidCode checks ISO 4217 code format: three latin letters
+func ValidCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package iso4217
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("gel")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "GEL", Name: "лари", Symbol: "₾", Precision: DefaultPrecision}, c)
+
+	c, ok = Lookup("btc")
+	assert.True(t, ok)
+	assert.Equal(t, Crypto, c.Kind)
+	assert.EqualValues(t, 8, c.Precision)
+
+	_, ok = Lookup("XXX")
+	assert.False(t, ok)
+}
+
+func TestCatalog_CodesAreValid(t *testing.T) {
+	for code, c := range catalog {
+		assert.True(t, ValidCode(code), code)
+		assert.Equal(t, code, c.Code)
+		assert.NotEmpty(t, c.Symbol, code)
+	}
+	assert.False(t, ValidCode("usd"))
+	assert.False(t, ValidCode("US"))
+}
+
+func TestAssets(t *testing.T) {
+	for code, c := range assets {
+		assert.Equal(t, code, c.Code)
+		assert.NotEqual(t, Fiat, c.Kind, code)
+		assert.Positive(t, c.Precision, code)
+		assert.True(t, ValidAssetCode(code), code)
+	}
+	assert.True(t, IsAsset("usdt"))
+	assert.False(t, IsAsset("USD"))
+	assert.False(t, ValidAssetCode("USDX"))
+}
@@ -0,0 +1,140 @@
+package money
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+const precision = 2
+
+// ratePrecision keeps small cross rates like JPY to EUR meaningful
+const ratePrecision = 4
+
+// rateSignificantDigits are kept for rates smaller than ratePrecision allows to show
+const rateSignificantDigits = 3
+
+// nbsp keeps amount and currency sign on the same line in telegram messages
+const nbsp = "\u00a0"
+
+type style struct {
+	groupSeparator   string
+	decimalSeparator string
+	symbolFirst      bool
+}
+
+var styles = map[i18n.Lang]style{
+	i18n.RU: {groupSeparator: nbsp, decimalSeparator: ",", symbolFirst: false},
+	i18n.EN: {groupSeparator: ",", decimalSeparator: ".", symbolFirst: true},
+}
+
+// Format prints amount with thousands separators, fixed two decimals and currency sign placed
+// according to language, e.g. "1 234,50 ₽" for russian and "$1,234.50" for english,
+// currencies with higher precision like BTC show significant digits up to their precision
+func Format(lang i18n.Lang, amount decimal.Decimal, currency model.CurrencyData) string {
+	places := currency.Decimals()
+	sign := ""
+	if amount.IsNegative() && !amount.Round(places).IsZero() {
+		sign = "-"
+	}
+	return withCurrency(getStyle(lang), sign, formatTrimmed(lang, amount.Abs(), places), currency)
+}
+
+// Preview prints amount which is still being entered on keypad, fractional part is kept as typed
+func Preview(lang i18n.Lang, raw string, currency model.CurrencyData) string {
+	st := getStyle(lang)
+	if raw == "" {
+		raw = "0"
+	}
+	integer, fraction, hasPoint := strings.Cut(raw, ".")
+	number := groupThousands(integer, st.groupSeparator)
+	if hasPoint {
+		number += st.decimalSeparator + fraction
+	}
+	return withCurrency(st, "", number, currency)
+}
+
+// FormatNumber prints amount without currency sign using language separators
+func FormatNumber(lang i18n.Lang, amount decimal.Decimal) string {
+	return formatFixed(lang, amount, precision)
+}
+
+// FormatRate prints exchange rate with four decimals using language separators,
+// tiny rates like RUB to BTC keep three significant digits instead
+func FormatRate(lang i18n.Lang, rate decimal.Decimal) string {
+	places := int32(ratePrecision)
+	if zeros := leadingFractionZeros(rate); zeros+rateSignificantDigits > places {
+		places = zeros + rateSignificantDigits
+	}
+	return formatFixed(lang, rate, places)
+}
+
+// leadingFractionZeros counts zeros between decimal point and first significant digit of number below one
+func leadingFractionZeros(number decimal.Decimal) int32 {
+	number = number.Abs()
+	if number.IsZero() || number.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return 0
+	}
+	return -number.Exponent() - int32(len(number.Coefficient().String()))
+}
+
+// formatTrimmed prints amount with given number of decimals dropping trailing zeros beyond two decimals
+func formatTrimmed(lang i18n.Lang, amount decimal.Decimal, places int32) string {
+	if places <= precision {
+		return formatFixed(lang, amount, precision)
+	}
+	fixed := formatFixed(lang, amount, places)
+	for trimmed := places; trimmed > precision && strings.HasSuffix(fixed, "0"); trimmed-- {
+		fixed = fixed[:len(fixed)-1]
+	}
+	return fixed
+}
+
+func formatFixed(lang i18n.Lang, amount decimal.Decimal, places int32) string {
+	st := getStyle(lang)
+
+	fixed := amount.StringFixed(places)
+	sign := ""
+	if strings.HasPrefix(fixed, "-") {
+		sign, fixed = "-", fixed[1:]
+	}
+	integer, fraction, _ := strings.Cut(fixed, ".")
+	return sign + groupThousands(integer, st.groupSeparator) + st.decimalSeparator + fraction
+}
+
+func groupThousands(integer, separator string) string {
+	if len(integer) <= 3 {
+		return integer
+	}
+	var b strings.Builder
+	head := len(integer) % 3
+	if head > 0 {
+		b.WriteString(integer[:head])
+	}
+	for i := head; i < len(integer); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(integer[i : i+3])
+	}
+	return b.String()
+}
+
+func withCurrency(st style, sign, number string, currency model.CurrencyData) string {
+	if currency.Symbol == "" {
+		return sign + number + nbsp + currency.ID
+	}
+	if st.symbolFirst {
+		return sign + currency.Symbol + number
+	}
+	return sign + number + nbsp + currency.Symbol
+}
+
+func getStyle(lang i18n.Lang) style {
+	if st, ok := styles[lang]; ok {
+		return st
+	}
+	return styles[i18n.DefaultLang]
+}
@@ -0,0 +1,56 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/i18n"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+func TestFormat(t *testing.T) {
+	rub := model.CurrencyData{ID: "RUB", Symbol: "₽"}
+	usd := model.CurrencyData{ID: "USD", Symbol: "$"}
+	unknown := model.CurrencyData{ID: "XYZ"}
+	btc := model.CurrencyData{ID: "BTC", Symbol: "₿", Precision: 8}
+
+	tests := []struct {
+		name     string
+		lang     i18n.Lang
+		amount   string
+		currency model.CurrencyData
+		want     string
+	}{
+		{name: "ru thousands", lang: i18n.RU, amount: "1234.5", currency: rub, want: "1\u00a0234,50\u00a0₽"},
+		{name: "en thousands", lang: i18n.EN, amount: "1234.5", currency: usd, want: "$1,234.50"},
+		{name: "ru millions", lang: i18n.RU, amount: "2132134", currency: usd, want: "2\u00a0132\u00a0134,00\u00a0$"},
+		{name: "en small", lang: i18n.EN, amount: "0.005", currency: rub, want: "₽0.01"},
+		{name: "en negative", lang: i18n.EN, amount: "-1000", currency: usd, want: "-$1,000.00"},
+		{name: "ru negative rounded to zero", lang: i18n.RU, amount: "-0.001", currency: rub, want: "0,00\u00a0₽"},
+		{name: "missing symbol", lang: i18n.EN, amount: "100", currency: unknown, want: "100.00\u00a0XYZ"},
+		{name: "crypto keeps significant digits", lang: i18n.RU, amount: "0.00150000", currency: btc, want: "0,0015\u00a0₿"},
+		{name: "crypto rounded to precision", lang: i18n.EN, amount: "0.123456789", currency: btc, want: "₿0.12345679"},
+		{name: "crypto whole amount", lang: i18n.EN, amount: "2", currency: btc, want: "₿2.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.lang, decimal.RequireFromString(tt.amount), tt.currency))
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	rub := model.CurrencyData{ID: "RUB", Symbol: "₽"}
+	usd := model.CurrencyData{ID: "USD", Symbol: "$"}
+
+	assert.Equal(t, "0\u00a0₽", Preview(i18n.RU, "", rub))
+	assert.Equal(t, "1\u00a0234,\u00a0₽", Preview(i18n.RU, "1234.", rub))
+	assert.Equal(t, "$12,345.5", Preview(i18n.EN, "12345.5", usd))
+}
+
+func TestFormatRate(t *testing.T) {
+	assert.Equal(t, "0,0113", FormatRate(i18n.RU, decimal.RequireFromString("0.01125")))
+	assert.Equal(t, "1,234.5000", FormatRate(i18n.EN, decimal.RequireFromString("1234.5")))
+	assert.Equal(t, "0.000000632", FormatRate(i18n.EN, decimal.RequireFromString("0.00000063215")))
+}
@@ -0,0 +1,41 @@
+package receipt
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg" // photos sent to Telegram are JPEG
+	_ "image/png"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+)
+
+// maxImagePixels keeps memory of decoded image bounded, photos of Telegram are at most 2560 pixels wide
+const maxImagePixels = 4096 * 4096
+
+// DecodeQR finds QR code on JPEG or PNG image and returns its content
+func DecodeQR(data []byte) (string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(err, "cannot read image")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return "", errors.Errorf("image %dx%d is too large", config.Width, config.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(err, "cannot decode image")
+	}
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot binarize image")
+	}
+	hints := map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_TRY_HARDER: true}
+	result, err := qrcode.NewQRCodeReader().Decode(bitmap, hints)
+	if err != nil {
+		return "", errors.Wrap(constants.QRCodeNotFoundErr, err.Error())
+	}
+	return result.GetText(), nil
+}
@@ -0,0 +1,79 @@
+package receipt
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/model"
+)
+
+// purchaseOperation is value of n parameter of receipts of purchases, the others are returns and receipts of sellers
+const purchaseOperation = "1"
+
+// dateLayouts are formats of t parameter, seconds are omitted by most cash registers
+var dateLayouts = []string{"20060102T150405", "20060102T1504"}
+
+// IsQRString reports whether text looks like content of receipt QR code, e.g.
+// t=20261019T1230&s=1234.56&fn=9960440300000000&i=12345&fp=1234567890&n=1
+func IsQRString(text string) bool {
+	text = strings.TrimSpace(text)
+	return strings.Contains(text, "fn=") && strings.Contains(text, "fp=") && strings.Contains(text, "s=") &&
+		!strings.ContainsAny(text, " \n")
+}
+
+// Parse reads content of receipt QR code, date of receipt is local time of cash register which is taken
+// as local time of server
+func Parse(text string) (model.Receipt, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(text))
+	if err != nil {
+		return model.Receipt{}, errors.Wrap(constants.InvalidReceiptErr, err.Error())
+	}
+	if n := values.Get("n"); n != "" && n != purchaseOperation {
+		return model.Receipt{}, errors.Wrapf(constants.InvalidReceiptErr, "operation %s is not purchase", n)
+	}
+	receipt := model.Receipt{
+		FiscalDriveNumber:    values.Get("fn"),
+		FiscalDocumentNumber: values.Get("i"),
+		FiscalSign:           values.Get("fp"),
+	}
+	for name, value := range map[string]string{
+		"fn": receipt.FiscalDriveNumber, "i": receipt.FiscalDocumentNumber, "fp": receipt.FiscalSign,
+	} {
+		if !isNumber(value) {
+			return model.Receipt{}, errors.Wrapf(constants.InvalidReceiptErr, "%s must be a number", name)
+		}
+	}
+	receipt.Total, err = decimal.NewFromString(values.Get("s"))
+	if err != nil || !receipt.Total.IsPositive() {
+		return model.Receipt{}, errors.Wrap(constants.InvalidReceiptErr, "s must be a positive amount")
+	}
+	if receipt.Date, err = parseDate(values.Get("t")); err != nil {
+		return model.Receipt{}, err
+	}
+	return receipt, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, errors.Wrapf(constants.InvalidReceiptErr, "t must be date like 20261019T1230, got '%s'", value)
+}
+
+func isNumber(value string) bool {
+	if value == "" || len(value) > 20 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package receipt
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/constants"
+)
+
+const qrString = "t=20261019T1230&s=1234.56&fn=9960440300000000&i=12345&fp=1234567890&n=1"
+
+func TestParse(t *testing.T) {
+	receipt, err := Parse(qrString)
+
+	require.NoError(t, err)
+	assert.Equal(t, "9960440300000000", receipt.FiscalDriveNumber)
+	assert.Equal(t, "12345", receipt.FiscalDocumentNumber)
+	assert.Equal(t, "1234567890", receipt.FiscalSign)
+	assert.Equal(t, "1234.56", receipt.Total.String())
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 30, 0, 0, time.Local), receipt.Date)
+
+	receipt, err = Parse("t=20261019T123015&s=10.00&fn=1&i=2&fp=3")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 30, 15, 0, time.Local), receipt.Date)
+}
+
+func TestParse_RejectsInvalidReceipts(t *testing.T) {
+	for _, text := range []string{
+		"t=20261019T1230&s=1234.56&fn=1&i=2&fp=3&n=2",
+		"t=20261019T1230&s=0&fn=1&i=2&fp=3",
+		"t=20261019T1230&s=ten&fn=1&i=2&fp=3",
+		"t=yesterday&s=1&fn=1&i=2&fp=3",
+		"t=20261019T1230&s=1&fn=1&i=2",
+		"t=20261019T1230&s=1&fn=1&i=2&fp=3x",
+		"%zz",
+	} {
+		_, err := Parse(text)
+		assert.ErrorIs(t, err, constants.InvalidReceiptErr, text)
+	}
+}
+
+func TestIsQRString(t *testing.T) {
+	assert.True(t, IsQRString(qrString))
+	assert.True(t, IsQRString(" "+qrString+"\n"))
+	assert.False(t, IsQRString("150"))
+	assert.False(t, IsQRString("my receipt fn=1 fp=2 s=3"))
+}
+
+func TestDecodeQR(t *testing.T) {
+	matrix, err := qrcode.NewQRCodeWriter().Encode(qrString, gozxing.BarcodeFormat_QR_CODE, 300, 300, nil)
+	require.NoError(t, err)
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, matrix))
+
+	text, err := DecodeQR(encoded.Bytes())
+
+	require.NoError(t, err)
+	assert.Equal(t, qrString, text)
+}
+
+func TestDecodeQR_WithoutCode(t *testing.T) {
+	matrix, err := gozxing.NewBitMatrix(300, 300)
+	require.NoError(t, err)
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, matrix))
+
+	_, err = DecodeQR(encoded.Bytes())
+	assert.ErrorIs(t, err, constants.QRCodeNotFoundErr)
+
+	_, err = DecodeQR([]byte("not an image"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package sparkline
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var ticks = []rune("▁▂▃▄▅▆▇█")
+
+// Render draws values as one line of block characters scaled between minimum and maximum
+func Render(values []decimal.Decimal) string {
+	if len(values) == 0 {
+		return ""
+	}
+	minimum, maximum := values[0], values[0]
+	for _, v := range values[1:] {
+		minimum = decimal.Min(minimum, v)
+		maximum = decimal.Max(maximum, v)
+	}
+	span := maximum.Sub(minimum)
+	last := decimal.NewFromInt(int64(len(ticks) - 1))
+
+	var b strings.Builder
+	for _, v := range values {
+		if span.IsZero() { // flat line keeps the middle height
+			b.WriteRune(ticks[len(ticks)/2])
+			continue
+		}
+		idx := v.Sub(minimum).Mul(last).DivRound(span, 0).IntPart()
+		b.WriteRune(ticks[idx])
+	}
+	return b.String()
+}
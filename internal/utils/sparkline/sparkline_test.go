@@ -0,0 +1,34 @@
+package sparkline
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	values := func(in ...int64) []decimal.Decimal {
+		res := make([]decimal.Decimal, 0, len(in))
+		for _, v := range in {
+			res = append(res, decimal.NewFromInt(v))
+		}
+		return res
+	}
+
+	tests := []struct {
+		name   string
+		values []decimal.Decimal
+		want   string
+	}{
+		{name: "empty", values: nil, want: ""},
+		{name: "growing", values: values(1, 2, 3, 4, 5, 6, 7, 8), want: "▁▂▃▄▅▆▇█"},
+		{name: "min and max", values: values(85, 92, 85), want: "▁█▁"},
+		{name: "flat", values: values(88, 88, 88), want: "▅▅▅"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.values))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package workerpool
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/logger"
+	"gitlab.ozon.dev/dmitryssaenko/financial-tg-bot/internal/metrics"
+	"go.uber.org/zap"
+)
+
+var ClosedErr = errors.New("worker pool is closed")
+
+// Task gets context which is canceled only when pool could not drain in time
+type Task func(ctx context.Context)
+
+// Pool runs tasks concurrently, tasks with the same key are run one by one in submission order.
+// Every worker owns bounded queue of its shard, submission waits while the queue is full
+type Pool struct {
+	name   string
+	queues []chan Task
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func New(name string, workers int, queueSize int) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		name:   name,
+		queues: make([]chan Task, workers),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan Task, queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+// Submit queues task to shard of key, it waits for free place until ctx is done
+func (p *Pool) Submit(ctx context.Context, key int64, task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ClosedErr
+	}
+
+	queue := p.queues[shard(key, len(p.queues))]
+	select {
+	case queue <- task:
+		metrics.WorkerPoolQueueGauge.WithLabelValues(p.name).Inc()
+		return nil
+	default:
+	}
+
+	metrics.WorkerPoolBlockedCounter.WithLabelValues(p.name).Inc()
+	startedAt := time.Now()
+	defer func() {
+		metrics.WorkerPoolWaitHistogram.WithLabelValues(p.name).Observe(time.Since(startedAt).Seconds())
+	}()
+	select {
+	case queue <- task:
+		metrics.WorkerPoolQueueGauge.WithLabelValues(p.name).Inc()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting tasks and waits until queued ones are done,
+// context of tasks is canceled when they are not done within timeout
+func (p *Pool) Close(timeout time.Duration) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		logger.Warn("worker pool is not drained in time, canceling tasks", zap.String("pool", p.name))
+		p.cancel()
+		<-done
+	}
+	p.cancel()
+}
+
+func (p *Pool) work(queue chan Task) {
+	defer p.wg.Done()
+	for task := range queue {
+		metrics.WorkerPoolQueueGauge.WithLabelValues(p.name).Dec()
+		p.run(task)
+	}
+}
+
+// run isolates panic of single task so worker keeps serving its shard
+func (p *Pool) run(task Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			metrics.WorkerPoolPanicCounter.WithLabelValues(p.name).Inc()
+			logger.Error("task panicked",
+				zap.String("pool", p.name),
+				zap.Any("panic", r),
+				zap.ByteString("stack", debug.Stack()))
+		}
+	}()
+	task(p.ctx)
+}
+
+func shard(key int64, shards int) int {
+	k := key % int64(shards)
+	if k < 0 {
+		k = -k
+	}
+	return int(k)
+}
@@ -0,0 +1,124 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_KeepsOrderOfSameKey(t *testing.T) {
+	p := New("test", 4, 10)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	got := map[int64][]int{}
+	for i := 0; i < 50; i++ {
+		for _, key := range []int64{1, 2, -3} {
+			i, key := i, key
+			assert.NoError(t, p.Submit(ctx, key, func(context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				got[key] = append(got[key], i)
+			}))
+		}
+	}
+	p.Close(time.Second)
+
+	for _, key := range []int64{1, 2, -3} {
+		assert.Len(t, got[key], 50)
+		for i, v := range got[key] {
+			assert.Equal(t, i, v, "key %d", key)
+		}
+	}
+}
+
+func TestPool_SlowKeyDoesNotBlockOthers(t *testing.T) {
+	p := New("test", 2, 10)
+	defer p.Close(time.Second)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	assert.NoError(t, p.Submit(ctx, 0, func(context.Context) { <-release }))
+	done := make(chan struct{})
+	assert.NoError(t, p.Submit(ctx, 1, func(context.Context) { close(done) }))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task of another shard is blocked by slow task")
+	}
+	close(release)
+}
+
+func TestPool_RecoversPanic(t *testing.T) {
+	p := New("test", 1, 10)
+	ctx := context.Background()
+
+	done := false
+	assert.NoError(t, p.Submit(ctx, 1, func(context.Context) { panic("boom") }))
+	assert.NoError(t, p.Submit(ctx, 1, func(context.Context) { done = true }))
+	p.Close(time.Second)
+
+	assert.True(t, done)
+}
+
+func TestPool_CloseDrainsQueuedTasks(t *testing.T) {
+	p := New("test", 1, 10)
+	ctx := context.Background()
+
+	var processed int
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, p.Submit(ctx, 1, func(ctx context.Context) {
+			time.Sleep(time.Millisecond)
+			if ctx.Err() == nil {
+				processed++
+			}
+		}))
+	}
+	p.Close(time.Second)
+
+	assert.Equal(t, 5, processed)
+	assert.True(t, errors.Is(p.Submit(ctx, 1, func(context.Context) {}), ClosedErr))
+}
+
+func TestPool_CloseCancelsTasksAfterTimeout(t *testing.T) {
+	p := New("test", 1, 10)
+
+	canceled := make(chan struct{})
+	assert.NoError(t, p.Submit(context.Background(), 1, func(ctx context.Context) {
+		<-ctx.Done()
+		close(canceled)
+	}))
+	p.Close(10 * time.Millisecond)
+
+	select {
+	case <-canceled:
+	default:
+		t.Fatal("task context is not canceled")
+	}
+}
+
+func TestPool_SubmitToFullQueueWaitsForContext(t *testing.T) {
+	p := New("test", 1, 1)
+	release := make(chan struct{})
+	defer p.Close(time.Second)
+	defer close(release)
+
+	started := make(chan struct{})
+	assert.NoError(t, p.Submit(context.Background(), 1, func(context.Context) {
+		close(started)
+		<-release
+	}))
+	<-started
+	assert.NoError(t, p.Submit(context.Background(), 1, func(context.Context) {}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := p.Submit(ctx, 1, func(context.Context) {})
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.category_translation
+(
+    category_id TEXT NOT NULL REFERENCES route256.financial_bot.category (id),
+    lang        TEXT NOT NULL,
+    name        TEXT NOT NULL,
+    PRIMARY KEY (category_id, lang)
+);
+
+INSERT INTO route256.financial_bot.category_translation (category_id, lang, name)
+SELECT id, 'ru', name_ru
+FROM route256.financial_bot.category;
+
+INSERT INTO route256.financial_bot.category_translation (category_id, lang, name)
+VALUES ('FASTFOOD', 'en', '🍔 Fast food'),
+       ('RESTAURANTS', 'en', '🍷 Restaurants'),
+       ('SUPERMARKETS', 'en', '🏪 Supermarkets'),
+       ('CLOTHES', 'en', '👔 Clothes'),
+       ('EDUCATION', 'en', '🎓 Education'),
+       ('TRANSPORT', 'en', '🚕 Transport'),
+       ('MEDICINE', 'en', '💊 Medicine'),
+       ('BEAUTY', 'en', '💅 Beauty'),
+       ('ENTERTAINMENT', 'en', '🎡 Entertainment'),
+       ('UNSCHEDULED', 'en', '🕐 Unscheduled'),
+       ('OTHERS', 'en', '💸 Others');
+
+ALTER TABLE route256.financial_bot.category
+    DROP COLUMN name_ru;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.category
+    ADD COLUMN name_ru TEXT;
+
+UPDATE route256.financial_bot.category c
+SET name_ru = COALESCE(t.name, c.id)
+FROM route256.financial_bot.category_translation t
+WHERE t.category_id = c.id
+  AND t.lang = 'ru';
+
+UPDATE route256.financial_bot.category
+SET name_ru = id
+WHERE name_ru IS NULL;
+
+ALTER TABLE route256.financial_bot.category
+    ALTER COLUMN name_ru SET NOT NULL;
+
+DROP TABLE IF EXISTS route256.financial_bot.category_translation;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.user
+    ADD COLUMN language_code TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.user
+    DROP COLUMN language_code;
+-- +goose StatementEnd
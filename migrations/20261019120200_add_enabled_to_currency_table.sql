@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.currency
+    ADD COLUMN enabled BOOLEAN NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.currency
+    DROP COLUMN enabled;
+-- +goose StatementEnd
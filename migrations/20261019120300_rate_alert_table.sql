@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.rate_alert
+(
+    id          BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id     BIGINT    NOT NULL REFERENCES route256.financial_bot.user (id),
+    currency_id TEXT      NOT NULL REFERENCES route256.financial_bot.currency (id),
+    direction   TEXT      NOT NULL CHECK (direction IN ('below', 'above')),
+    threshold   DECIMAL   NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX rate_alert_currency_id_idx ON route256.financial_bot.rate_alert (currency_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.rate_alert_currency_id_idx;
+DROP TABLE IF EXISTS route256.financial_bot.rate_alert;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.currency
+    ADD COLUMN decimals SMALLINT NOT NULL DEFAULT 2;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.currency
+    DROP COLUMN decimals;
+-- +goose StatementEnd
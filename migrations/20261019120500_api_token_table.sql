@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.api_token
+(
+    id         BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id    BIGINT    NOT NULL REFERENCES route256.financial_bot.user (id),
+    token_hash TEXT      NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX api_token_user_id_idx ON route256.financial_bot.api_token (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.api_token_user_id_idx;
+DROP TABLE IF EXISTS route256.financial_bot.api_token;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.outbox
+(
+    id              BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    idempotency_key UUID      NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    event_type      TEXT      NOT NULL,
+    user_id         BIGINT    NOT NULL,
+    payload         JSONB     NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT now(),
+    attempts        INT       NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_error      TEXT,
+    published_at    TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON route256.financial_bot.outbox (next_attempt_at, id) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.outbox_pending_idx;
+DROP TABLE IF EXISTS route256.financial_bot.outbox;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.user_webhook
+(
+    id          BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id     BIGINT    NOT NULL REFERENCES route256.financial_bot.user (id),
+    url         TEXT      NOT NULL,
+    secret      TEXT      NOT NULL,
+    event_types TEXT[]    NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX user_webhook_user_id_idx ON route256.financial_bot.user_webhook (user_id);
+
+CREATE TABLE route256.financial_bot.webhook_delivery
+(
+    id              BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    webhook_id      BIGINT    NOT NULL REFERENCES route256.financial_bot.user_webhook (id) ON DELETE CASCADE,
+    idempotency_key UUID      NOT NULL,
+    event_type      TEXT      NOT NULL,
+    attempt         INT       NOT NULL,
+    status_code     INT       NOT NULL DEFAULT 0,
+    error           TEXT      NOT NULL DEFAULT '',
+    delivered       BOOLEAN   NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_delivery_event_idx ON route256.financial_bot.webhook_delivery (idempotency_key);
+CREATE INDEX webhook_delivery_webhook_id_idx ON route256.financial_bot.webhook_delivery (webhook_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.webhook_delivery_webhook_id_idx;
+DROP INDEX IF EXISTS route256.financial_bot.webhook_delivery_event_idx;
+DROP TABLE IF EXISTS route256.financial_bot.webhook_delivery;
+DROP INDEX IF EXISTS route256.financial_bot.user_webhook_user_id_idx;
+DROP TABLE IF EXISTS route256.financial_bot.user_webhook;
+-- +goose StatementEnd
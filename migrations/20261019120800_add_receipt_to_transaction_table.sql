@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.transaction
+    ADD COLUMN fiscal_drive_number    TEXT,
+    ADD COLUMN fiscal_document_number TEXT,
+    ADD COLUMN fiscal_sign            TEXT;
+
+CREATE UNIQUE INDEX transaction_receipt_idx ON route256.financial_bot.transaction
+    (user_id, fiscal_drive_number, fiscal_document_number, fiscal_sign) WHERE fiscal_sign IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.transaction_receipt_idx;
+ALTER TABLE route256.financial_bot.transaction
+    DROP COLUMN fiscal_sign,
+    DROP COLUMN fiscal_document_number,
+    DROP COLUMN fiscal_drive_number;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.transaction_attachment
+(
+    transaction_id BIGINT    NOT NULL PRIMARY KEY REFERENCES route256.financial_bot.transaction (id) ON DELETE CASCADE,
+    kind           TEXT      NOT NULL,
+    file_id        TEXT      NOT NULL,
+    file_name      TEXT      NOT NULL DEFAULT '',
+    mime_type      TEXT      NOT NULL DEFAULT '',
+    size           BIGINT    NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS route256.financial_bot.transaction_attachment;
+-- +goose StatementEnd
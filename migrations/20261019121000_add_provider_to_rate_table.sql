@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.rate
+    ADD COLUMN provider       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN effective_date DATE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE route256.financial_bot.rate
+    DROP COLUMN effective_date,
+    DROP COLUMN provider;
+-- +goose StatementEnd
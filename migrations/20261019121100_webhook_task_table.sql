@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE route256.financial_bot.webhook_task
+(
+    id              BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    webhook_id      BIGINT    NOT NULL REFERENCES route256.financial_bot.user_webhook (id) ON DELETE CASCADE,
+    idempotency_key UUID      NOT NULL,
+    event_type      TEXT      NOT NULL,
+    body            TEXT      NOT NULL,
+    attempts        INT       NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    finished_at     TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (idempotency_key, webhook_id)
+);
+
+CREATE INDEX webhook_task_pending_idx ON route256.financial_bot.webhook_task (next_attempt_at, id) WHERE finished_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS route256.financial_bot.webhook_task_pending_idx;
+DROP TABLE IF EXISTS route256.financial_bot.webhook_task;
+-- +goose StatementEnd